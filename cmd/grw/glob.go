@@ -0,0 +1,88 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
+	"github.com/spatialcurrent/go-reader-writer/pkg/io"
+)
+
+// globReader concatenates the resources that match an input uri with wildcards, with the separator between each resource.
+type globReader struct {
+	matches   *grw.GlobReader
+	separator []byte
+	pending   []byte    // the part of the separator that has not been read yet
+	current   io.Reader // the reader for the current resource, or nil if the current resource has been read
+}
+
+// openInput opens the resource at the uri of the input,
+// or the resources that match the uri of the input, if the path of the uri has wildcards.
+func openInput(input *grw.ReadFromResourceInput, separator []byte) (*grw.ReadFromResourceOutput, error) {
+	if grw.IsGlob(input.URI) {
+		return openGlob(input, separator)
+	}
+	return grw.ReadFromResource(input)
+}
+
+// openGlob opens the resources that match the uri of the input as a single reader.
+// Each resource is decompressed separately.
+// Returns an error if no resources match the uri.
+func openGlob(input *grw.ReadFromResourceInput, separator []byte) (*grw.ReadFromResourceOutput, error) {
+	matches, err := grw.ReadFromGlob(context.Background(), input)
+	if err != nil {
+		return nil, err
+	}
+	if !matches.Next() {
+		err = matches.Err()
+		_ = matches.Close()
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New("no resources match the uri")
+	}
+	return &grw.ReadFromResourceOutput{
+		Reader: &globReader{matches: matches, separator: separator, current: matches.Reader()},
+	}, nil
+}
+
+// Read reads from the current resource, and then from the separator and the next resource once the current resource is read.
+func (r *globReader) Read(p []byte) (int, error) {
+	for {
+		if len(r.pending) > 0 {
+			n := copy(p, r.pending)
+			r.pending = r.pending[n:]
+			return n, nil
+		}
+		if r.current != nil {
+			n, err := r.current.Read(p)
+			if err == io.EOF {
+				r.current = nil
+				if n > 0 {
+					return n, nil
+				}
+				continue
+			}
+			return n, err
+		}
+		if !r.matches.Next() {
+			if err := r.matches.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		r.pending, r.current = r.separator, r.matches.Reader()
+	}
+}
+
+// Close closes the current resource and releases the connections used to list and read the resources.
+func (r *globReader) Close() error {
+	return r.matches.Close()
+}
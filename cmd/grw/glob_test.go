@@ -0,0 +1,93 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	pkgalg "github.com/spatialcurrent/go-reader-writer/pkg/alg"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grwtest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/io"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+func readInput(t *testing.T, input *grw.ReadFromResourceInput) string {
+	output, err := openInput(input, []byte("\n"))
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(output.Reader)
+	require.NoError(t, err)
+	return string(got)
+}
+
+func TestOpenInputPresignedURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/a.csv" || r.URL.Query().Get("X-Amz-Signature") != "abc?" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer server.Close()
+
+	got := readInput(t, &grw.ReadFromResourceInput{
+		URI: server.URL + "/a.csv?X-Amz-Expires=60&X-Amz-Signature=" + url.QueryEscape("abc?"),
+		Alg: pkgalg.AlgorithmNone,
+	})
+	assert.Equal(t, "hello world", got)
+}
+
+func TestOpenInputS3Version(t *testing.T) {
+	server := grwtest.NewS3Server()
+	defer server.Close()
+	require.NoError(t, server.CreateBucket("bucket"))
+	client := server.S3Client()
+	_, err := client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("a.csv"),
+		Body:   strings.NewReader("hello world"),
+	})
+	require.NoError(t, err)
+
+	got := readInput(t, &grw.ReadFromResourceInput{
+		URI:      "s3://bucket/a.csv?versionId=null",
+		Alg:      pkgalg.AlgorithmNone,
+		S3Client: client,
+	})
+	assert.Equal(t, "hello world", got)
+}
+
+func TestOpenInputSSHCommand(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.csv"), []byte("hello world"), 0600))
+
+	// the wildcard is expanded by the shell on the server
+	got := readInput(t, &grw.ReadFromResourceInput{
+		URI:             fmt.Sprintf("ssh+cmd://%s@%s?cmd=cat+%s", server.User, server.Addr(), filepath.Join(dir, "*.csv")),
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	assert.Equal(t, "hello world", got)
+}
@@ -0,0 +1,125 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/cli"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
+	"github.com/spatialcurrent/go-reader-writer/pkg/stat"
+)
+
+// newListCommand returns the ls subcommand, which prints the entries in a directory or prefix.
+func newListCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:                   `ls [flags] <URI>`,
+		DisableFlagsInUseLine: true,
+		Short:                 "list the entries in a directory or prefix",
+		Long: `list the entries in the directory or prefix at the uri, one per line, with a trailing slash for directories and prefixes.
+Paths are printed relative to the uri.  If the uri is not a directory or prefix, then the resource itself is printed.
+With --long, the mode, size, and last modified time of each entry are printed before the path.
+With --json, each entry is printed as a JSON object in the same format as the stat subcommand.`,
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v, err := cli.InitViper(cmd.Flags())
+			if err != nil {
+				return fmt.Errorf("error initializing viper: %w", err)
+			}
+
+			long, asJSON := v.GetBool(cli.FlagLong), v.GetBool(cli.FlagJSON)
+			if long && asJSON {
+				return errors.New("cannot use both --long and --json")
+			}
+
+			maxDepth := v.GetInt(cli.FlagMaxDepth)
+			if maxDepth < 0 {
+				return fmt.Errorf("invalid max depth %d", maxDepth)
+			}
+
+			uri := args[0]
+
+			resourceOptions, err := initResourceOptions(v, uri, "")
+			if err != nil {
+				return err
+			}
+
+			lister, err := grw.List(context.Background(), uri, &grw.ListOptions{
+				ResourceOptions: *resourceOptions,
+				Recursive:       v.GetBool(cli.FlagRecursive) || maxDepth > 0,
+				MaxDepth:        maxDepth,
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = lister.Close() }()
+
+			w := bufio.NewWriter(os.Stdout)
+			for lister.Next() {
+				entry := lister.Entry()
+				switch {
+				case asJSON:
+					b, errMarshal := json.Marshal(entry)
+					if errMarshal != nil {
+						return fmt.Errorf("error encoding entry %q: %w", entry.URI(), errMarshal)
+					}
+					_, err = fmt.Fprintln(w, string(b))
+				case long:
+					_, err = fmt.Fprintf(w, "%s %12d %-20s %s\n", entry.Mode(), entry.Size(), formatModTime(entry), formatPath(lister.Path(), entry))
+				default:
+					_, err = fmt.Fprintln(w, formatPath(lister.Path(), entry))
+				}
+				if err != nil {
+					return fmt.Errorf("error writing to stdout: %w", err)
+				}
+			}
+			err = w.Flush()
+			if err != nil {
+				return fmt.Errorf("error writing to stdout: %w", err)
+			}
+			err = lister.Err()
+			if err != nil {
+				return err
+			}
+			return lister.Close()
+		},
+	}
+	flag := command.Flags()
+	flag.BoolP(cli.FlagLong, "l", false, "print the mode, size, and last modified time of each entry")
+	flag.Bool(cli.FlagJSON, false, "print each entry as a JSON object")
+	flag.BoolP(cli.FlagRecursive, "R", false, "list the entries in subdirectories and prefixes")
+	flag.Int(cli.FlagMaxDepth, 0, "if greater than zero, the maximum depth of the listed entries, where 1 is the entries of the directory at the uri, implies --recursive")
+	cli.InitResourceFlags(flag)
+	return command
+}
+
+// formatPath returns the path of the entry with a trailing slash if the entry is a directory or prefix.
+func formatPath(p string, entry *stat.ResourceInfo) string {
+	if entry.IsDir() {
+		return p + "/"
+	}
+	return p
+}
+
+// formatModTime returns the last modified time of the entry in UTC, or "-" if unknown.
+func formatModTime(entry *stat.ResourceInfo) string {
+	if entry.ModTime().IsZero() {
+		return "-"
+	}
+	return entry.ModTime().UTC().Format(time.RFC3339)
+}
@@ -10,7 +10,9 @@
 package main
 
 import (
-	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	stdos "os"
 	"os/signal"
@@ -22,13 +24,13 @@ import (
 	"time"
 
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/pkg/sftp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	awssession "github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 
@@ -36,10 +38,14 @@ import (
 	"github.com/spatialcurrent/go-reader-writer/pkg/cli"
 	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
 	"github.com/spatialcurrent/go-reader-writer/pkg/io"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
 	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
 	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/webdav"
 	"github.com/spatialcurrent/go-reader-writer/pkg/nop"
 	"github.com/spatialcurrent/go-reader-writer/pkg/os"
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
 	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
 )
 
@@ -72,15 +78,37 @@ func initPrivateKeys(inputPath string, outputPath string) ([]byte, []byte, error
 	return inputPrivateKey, outputPrivateKey, nil
 }
 
-func initS3Client(v *viper.Viper, inputURI string, outputURI string) (*s3.S3, *awssession.Session, error) {
+func initRetryPolicy(v *viper.Viper) *retry.Policy {
+	return &retry.Policy{
+		Attempts:  v.GetInt(cli.FlagRetryAttempts),
+		BaseDelay: v.GetDuration(cli.FlagRetryBaseDelay),
+		MaxDelay:  v.GetDuration(cli.FlagRetryMaxDelay),
+		Jitter:    v.GetFloat64(cli.FlagRetryJitter),
+	}
+}
+
+// initMFATokenProvider returns a function that returns the MFA token code used when assuming an AWS IAM role.
+// If the token is not set, then the code is read from stdin, unless the input is read from stdin.
+func initMFATokenProvider(token string, inputURI string) func() (string, error) {
+	if len(token) > 0 {
+		return func() (string, error) {
+			return token, nil
+		}
+	}
+	switch inputURI {
+	case "-", "stdin", "/dev/stdin":
+		return func() (string, error) {
+			return "", fmt.Errorf("cannot read the MFA token code from stdin when reading the input from stdin, use --%s", cli.FlagAWSMFAToken)
+		}
+	}
+	return stscreds.StdinTokenProvider
+}
+
+func initS3Client(v *viper.Viper, inputURI string, outputURI string, retryPolicy *retry.Policy) (*s3.S3, *awssession.Session, error) {
 	if (!strings.HasPrefix(inputURI, "s3://")) && (!strings.HasPrefix(outputURI, "s3://")) {
 		return nil, nil, nil
 	}
 
-	accessKeyID := v.GetString(cli.FlagAWSAccessKeyID)
-	secretAccessKey := v.GetString(cli.FlagAWSSecretAccessKey)
-	sessionToken := v.GetString(cli.FlagAWSSessionToken)
-
 	region := v.GetString(cli.FlagAWSRegion)
 	if len(region) == 0 {
 		if defaultRegion := v.GetString(cli.FlagAWSDefaultRegion); len(defaultRegion) > 0 {
@@ -88,69 +116,205 @@ func initS3Client(v *viper.Viper, inputURI string, outputURI string) (*s3.S3, *a
 		}
 	}
 
-	config := aws.Config{
-		MaxRetries: aws.Int(3),
-		Region:     aws.String(region),
+	return grw.NewS3Client(&grw.NewS3ClientInput{
+		Profile:          v.GetString(cli.FlagAWSProfile),
+		Region:           region,
+		AccessKeyID:      v.GetString(cli.FlagAWSAccessKeyID),
+		SecretAccessKey:  v.GetString(cli.FlagAWSSecretAccessKey),
+		SessionToken:     v.GetString(cli.FlagAWSSessionToken),
+		RoleARN:          v.GetString(cli.FlagAWSRoleARN),
+		RoleSessionName:  v.GetString(cli.FlagAWSRoleSessionName),
+		ExternalID:       v.GetString(cli.FlagAWSExternalID),
+		MFASerial:        v.GetString(cli.FlagAWSMFASerial),
+		MFATokenProvider: initMFATokenProvider(v.GetString(cli.FlagAWSMFAToken), inputURI),
+		Endpoint:         v.GetString(cli.FlagAWSEndpointURL),
+		UsePathStyle:     v.GetBool(cli.FlagAWSS3UsePathStyle),
+		DisableSSL:       v.GetBool(cli.FlagAWSDisableSSL),
+		Retry:            retryPolicy,
+	})
+}
+
+func initGCSClient(v *viper.Viper, inputURI string, outputURI string) (*gcs.Client, error) {
+	if (!strings.HasPrefix(inputURI, "gs://")) && (!strings.HasPrefix(outputURI, "gs://")) {
+		return nil, nil
 	}
+	return gcs.NewClient(&gcs.NewClientInput{
+		CredentialsFile: v.GetString(cli.FlagGCSCredentials),
+		Endpoint:        v.GetString(cli.FlagGCSEndpoint),
+	})
+}
 
-	if len(accessKeyID) > 0 && len(secretAccessKey) > 0 {
-		config.Credentials = credentials.NewStaticCredentials(
-			accessKeyID,
-			secretAccessKey,
-			sessionToken)
+// isAzureBlobURI returns true if the uri uses the azblob scheme or is a https url of a blob on Azure Blob Storage.
+func isAzureBlobURI(uri string) bool {
+	if strings.HasPrefix(uri, "azblob://") {
+		return true
 	}
+	_, ok := azblob.ParseBlobURL(uri)
+	return ok
+}
 
-	session, err := awssession.NewSessionWithOptions(awssession.Options{
-		Config: config,
+func initAzureBlobClient(v *viper.Viper, inputURI string, outputURI string) (*azblob.Client, error) {
+	if (!isAzureBlobURI(inputURI)) && (!isAzureBlobURI(outputURI)) {
+		return nil, nil
+	}
+	endpoint := v.GetString(cli.FlagAzureStorageEndpoint)
+	sasToken := v.GetString(cli.FlagAzureStorageSASToken)
+	if len(endpoint) == 0 {
+		// use the endpoint and shared access signature of a https url
+		for _, uri := range []string{inputURI, outputURI} {
+			if u, ok := azblob.ParseBlobURL(uri); ok {
+				endpoint = u.Endpoint
+				if len(sasToken) == 0 {
+					sasToken = u.SASToken
+				}
+				break
+			}
+		}
+	}
+	return azblob.NewClient(&azblob.NewClientInput{
+		Account:          v.GetString(cli.FlagAzureStorageAccount),
+		Key:              v.GetString(cli.FlagAzureStorageKey),
+		SASToken:         sasToken,
+		ConnectionString: v.GetString(cli.FlagAzureStorageConnectionString),
+		Endpoint:         endpoint,
+	})
+}
+
+// isSSHURI returns true if the uri uses the sftp, ssh, or ssh+cmd scheme.
+func isSSHURI(uri string) bool {
+	switch scheme, _ := splitter.SplitURI(uri); scheme {
+	case ssh2.SchemeSFTP, ssh2.SchemeSSH, ssh2.SchemeSSHCommand:
+		return true
+	}
+	return false
+}
+
+// initSSHConfig returns the ssh config used to resolve the hosts in SFTP and SSH uris.
+// If neither uri is for a SSH server or the path is "none", then returns nil.
+func initSSHConfig(v *viper.Viper, inputURI string, outputURI string) (*ssh2.Config, error) {
+	if (!isSSHURI(inputURI)) && (!isSSHURI(outputURI)) {
+		return nil, nil
+	}
+	p := v.GetString(cli.FlagSSHConfig)
+	if len(p) == 0 || p == "none" {
+		return nil, nil
+	}
+	return ssh2.LoadConfig(p)
+}
+
+// initHostKeyCallback returns the callback used to verify the host key of the SSH server for the uri.
+// If no known_hosts files are set, then the UserKnownHostsFile for the host in the ssh config is used.
+// If the uri is not for a SSH server, then returns nil.
+func initHostKeyCallback(v *viper.Viper, uri string, fingerprint string, sshConfig *ssh2.Config) (ssh.HostKeyCallback, error) {
+	if !isSSHURI(uri) {
+		return nil, nil
+	}
+	knownHostsFiles := v.GetStringSlice(cli.FlagSSHKnownHosts)
+	if len(knownHostsFiles) == 0 && sshConfig != nil {
+		_, fullpath := splitter.SplitURI(uri)
+		authority := strings.SplitN(strings.SplitN(fullpath, "/", 2)[0], "?", 2)[0]
+		_, host, _ := splitter.SplitAuthority(authority)
+		knownHostsFiles = sshConfig.Host(host).UserKnownHostsFiles
+	}
+	return ssh2.NewHostKeyCallback(&ssh2.NewHostKeyCallbackInput{
+		KnownHostsFiles: knownHostsFiles,
+		Fingerprint:     fingerprint,
+		AcceptNew:       v.GetBool(cli.FlagSSHAcceptNewHostKeys),
+		Insecure:        v.GetBool(cli.FlagInsecureIgnoreHostKey),
 	})
+}
+
+// initPrivateKeyPassphrase returns the passphrase of the private key used to connect to the SSH server for the uri.
+// If the private key is encrypted and the passphrase is not set, then the passphrase is read from the terminal.
+func initPrivateKeyPassphrase(uri string, privateKey []byte, passphrase string, flag string) ([]byte, error) {
+	if len(passphrase) > 0 {
+		return []byte(passphrase), nil
+	}
+	if (!isSSHURI(uri)) || len(privateKey) == 0 {
+		return nil, nil
+	}
+	_, err := ssh.ParsePrivateKey(privateKey)
+	var passphraseMissingError *ssh.PassphraseMissingError
+	if !errors.As(err, &passphraseMissingError) {
+		return nil, nil
+	}
+	tty, err := stdos.OpenFile("/dev/tty", stdos.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("private key is encrypted, but cannot prompt for the passphrase without a terminal, use --%s: %w", flag, err)
+	}
+	defer tty.Close()
+	fmt.Fprintf(tty, "Enter passphrase for the private key for %s: ", uri)
+	b, err := term.ReadPassword(int(tty.Fd()))
+	fmt.Fprintln(tty)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error creating new AWS session: %w", err)
+		return nil, fmt.Errorf("error reading passphrase: %w", err)
+	}
+	return b, nil
+}
+
+// initJumpHostKeyCallback returns the callback used to verify the host keys of jump hosts.
+// The fingerprints of the input and output are not used for jump hosts.
+// If no known_hosts files are set and host key verification is not changed,
+// then returns nil, so the UserKnownHostsFile for each jump host in the ssh config is used.
+func initJumpHostKeyCallback(v *viper.Viper, inputURI string, outputURI string) (ssh.HostKeyCallback, error) {
+	if (!isSSHURI(inputURI)) && (!isSSHURI(outputURI)) {
+		return nil, nil
+	}
+	knownHostsFiles := v.GetStringSlice(cli.FlagSSHKnownHosts)
+	acceptNew := v.GetBool(cli.FlagSSHAcceptNewHostKeys)
+	insecure := v.GetBool(cli.FlagInsecureIgnoreHostKey)
+	if len(knownHostsFiles) == 0 && !acceptNew && !insecure {
+		return nil, nil
 	}
-	return s3.New(session), session, nil
+	return ssh2.NewHostKeyCallback(&ssh2.NewHostKeyCallbackInput{
+		KnownHostsFiles: knownHostsFiles,
+		AcceptNew:       acceptNew,
+		Insecure:        insecure,
+	})
 }
 
-func initSFTPClient(uri string, password string, privateKeyBytes []byte) (*sftp.Client, *ssh.Client, error) {
-	if !strings.HasPrefix(uri, "sftp://") {
+// initSFTPClient returns the SFTP and SSH clients for the uri.
+// For ssh and ssh+cmd uris, only the SSH client is returned.
+// If the uri is not for a SSH server, then returns nil clients.
+func initSFTPClient(uri string, input *grw.NewSSHClientOptionsInput, retryPolicy *retry.Policy) (*sftp.Client, *ssh.Client, error) {
+	if !isSSHURI(uri) {
 		return nil, nil, nil
 	}
 
-	options := []ssh2.ClientOption{}
-
-	if len(privateKeyBytes) > 0 {
-		privateKey, err := ssh.ParsePrivateKey(privateKeyBytes)
-		if err != nil {
-			return nil, nil, fmt.Errorf("error parsing private key: %w", err)
-		}
-		options = append(options, func(config *ssh2.ClientConfig) error {
-			config.Auth = []ssh.AuthMethod{
-				ssh.PublicKeys(privateKey),
-			}
-			return nil
-		})
-	} else if len(password) > 0 {
-		options = append(options, func(config *ssh2.ClientConfig) error {
-			config.Auth = []ssh.AuthMethod{
-				ssh.Password(password),
-			}
-			return nil
-		})
+	options, err := grw.NewSSHClientOptions(input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error initializing SSH client options: %w", err)
 	}
 
-	sshClient, err := ssh2.Dial(uri, options...)
+	var sshClient *ssh2.Client
+	err = retryPolicy.Do(context.Background(), func() error {
+		c, errDial := ssh2.Dial(uri, options...)
+		if errDial != nil {
+			return errDial
+		}
+		sshClient = c
+		return nil
+	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating SSH client: %w", err)
 	}
 
-	sftpClient, err := sftp.NewClient(sshClient.Client)
+	if scheme, _ := splitter.SplitURI(uri); scheme != ssh2.SchemeSFTP {
+		return nil, sshClient.Client, nil
+	}
+
+	sftpClient, err := sftp.NewClient(sshClient.Client, sftp.UseConcurrentWrites(true))
 	if err != nil {
+		_ = sshClient.Close() // attempt to close the underlying SSH connection
 		return nil, nil, fmt.Errorf("error creating SFTP client: %w", err)
 	}
 
 	return sftpClient, sshClient.Client, nil
 }
 
-func checkURIRead(uri string, sftpClient *sftp.Client) error {
-	if uri != "-" {
+func checkURIRead(uri string, sftpClient *sftp.Client, sshClient *ssh.Client) error {
+	// the resources that match an uri with wildcards are checked when opened
+	if uri != "-" && !grw.IsGlob(uri) {
 		scheme, path := splitter.SplitURI(uri)
 		switch scheme {
 		case "sftp":
@@ -158,11 +322,90 @@ func checkURIRead(uri string, sftpClient *sftp.Client) error {
 			if err != nil {
 				return fmt.Errorf("resource %q cannot be read: %w", uri, err)
 			}
+		case "ssh":
+			err := ssh2.CheckFileRead(sshClient, strings.SplitN(path, "/", 2)[1])
+			if err != nil {
+				return fmt.Errorf("resource %q cannot be read: %w", uri, err)
+			}
 		case "file", "":
 			err := os.CheckURIRead(uri)
 			if err != nil {
 				return fmt.Errorf("resource %q cannot be read: %w", uri, err)
 			}
+		case "webdav", "webdavs":
+			exists, fi, err := webdav.Stat(context.Background(), uri)
+			if err != nil {
+				return fmt.Errorf("resource %q cannot be read: %w", uri, err)
+			}
+			if !exists {
+				return fmt.Errorf("resource %q does not exist", uri)
+			}
+			if fi.IsDir() {
+				return fmt.Errorf("resource %q is a collection", uri)
+			}
+		}
+	}
+	return nil
+}
+
+func checkGCSWrite(client *gcs.Client, uri string, appendToFile bool, overwrite bool) error {
+	if appendToFile {
+		return fmt.Errorf("resource %q cannot be appended to, since Google Cloud Storage does not support appending", uri)
+	}
+	_, path := splitter.SplitURI(uri)
+	bucket, object, err := gcs.SplitPath(path)
+	if err != nil {
+		return err
+	}
+	exists, _, err := gcs.Stat(context.Background(), client, bucket, object)
+	if err != nil {
+		return fmt.Errorf("error stating resource %q: %w", uri, err)
+	}
+	if exists && !overwrite {
+		return fmt.Errorf("resource %q already exists and overwrite is false", uri)
+	}
+	return nil
+}
+
+func checkAzureBlobWrite(client *azblob.Client, uri string, appendToFile bool, overwrite bool) error {
+	if appendToFile {
+		return fmt.Errorf("resource %q cannot be appended to, since Azure Blob Storage does not support appending to block blobs", uri)
+	}
+	var container, blob string
+	if u, ok := azblob.ParseBlobURL(uri); ok {
+		container, blob = u.Container, u.Blob
+	} else {
+		_, path := splitter.SplitURI(uri)
+		c, b, err := azblob.SplitPath(path)
+		if err != nil {
+			return err
+		}
+		container, blob = c, b
+	}
+	exists, _, err := azblob.Stat(context.Background(), client, container, blob)
+	if err != nil {
+		return fmt.Errorf("error stating resource %q: %w", uri, err)
+	}
+	if exists && !overwrite {
+		return fmt.Errorf("resource %q already exists and overwrite is false", uri)
+	}
+	return nil
+}
+
+func checkWebDAVWrite(uri string, appendToFile bool, overwrite bool) error {
+	if appendToFile {
+		return fmt.Errorf("resource %q cannot be appended to, since WebDAV does not support appending", uri)
+	}
+	exists, fi, err := webdav.Stat(context.Background(), uri)
+	if err != nil {
+		return fmt.Errorf("error stating resource %q: %w", uri, err)
+	}
+	if exists {
+		if fi.IsDir() {
+			return fmt.Errorf("resource %q is a collection", uri)
+		}
+		if !overwrite {
+			return fmt.Errorf("resource %q already exists and overwrite is false", uri)
 		}
 	}
 	return nil
@@ -178,6 +421,7 @@ func main() {
 grw requires input and output locations to be specified.
 If the output uri is a device, then the append flag is not required.
 Supports the following compression algorithms: ` + strings.Join(grw.Algorithms, ", "),
+		Args:          cobra.ArbitraryArgs,
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -208,8 +452,6 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 			inputURI := args[0]
 			outputURI := args[1]
 
-			_, outputPath := splitter.SplitURI(outputURI)
-
 			verbose := v.GetBool(cli.FlagVerbose)
 
 			outputModeString := v.GetString(cli.FlagOutputMode)
@@ -219,7 +461,39 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 				return fmt.Errorf("invalid output mode %q: %w", outputModeString, outputModeErr)
 			}
 
+			outputDirModeString := v.GetString(cli.FlagOutputDirMode)
+
+			outputDirMode, outputDirModeErr := strconv.ParseUint(outputDirModeString, 0, 32)
+			if outputDirModeErr != nil {
+				return fmt.Errorf("invalid output directory mode %q: %w", outputDirModeString, outputDirModeErr)
+			}
+
 			outputACL := v.GetString(cli.FlagOutputACL)
+			outputCacheControl := v.GetString(cli.FlagOutputCacheControl)
+			outputContentEncoding := v.GetString(cli.FlagOutputContentEncoding)
+			outputContentType := v.GetString(cli.FlagOutputContentType)
+			outputStorageClass := v.GetString(cli.FlagOutputStorageClass)
+			outputSSE := v.GetString(cli.FlagOutputSSE)
+			outputSSEKMSKeyID := v.GetString(cli.FlagOutputSSEKMSKeyID)
+
+			outputMetadata, err := cli.ParseKeyValuePairs(v.GetStringSlice(cli.FlagOutputMetadata))
+			if err != nil {
+				return fmt.Errorf("invalid output metadata: %w", err)
+			}
+
+			outputTags, err := cli.ParseKeyValuePairs(v.GetStringSlice(cli.FlagOutputTags))
+			if err != nil {
+				return fmt.Errorf("invalid output tags: %w", err)
+			}
+
+			outputSSECustomerKey := ""
+			if str := v.GetString(cli.FlagOutputSSECustomerKey); len(str) > 0 {
+				key, errDecode := base64.StdEncoding.DecodeString(str)
+				if errDecode != nil {
+					return fmt.Errorf("error decoding customer-provided key: %w", errDecode)
+				}
+				outputSSECustomerKey = string(key)
+			}
 
 			outputBufferSize := v.GetInt(cli.FlagOutputBufferSize)
 			if outputBufferSize < 0 {
@@ -239,68 +513,201 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 				return fmt.Errorf("error initializing private keys: %w", err)
 			}
 
-			s3Client, _, err := initS3Client(v, inputURI, outputURI)
+			retryPolicy := initRetryPolicy(v)
+
+			s3Client, _, err := initS3Client(v, inputURI, outputURI, retryPolicy)
 			if err != nil {
 				return fmt.Errorf("error initializing AWS S3 client: %w", err)
 			}
 
-			inputSFTPClient, inputSSHClient, err := initSFTPClient(inputURI, inputPassword, inputPrivateKey)
+			gcsClient, err := initGCSClient(v, inputURI, outputURI)
+			if err != nil {
+				return fmt.Errorf("error initializing Google Cloud Storage client: %w", err)
+			}
+
+			azureBlobClient, err := initAzureBlobClient(v, inputURI, outputURI)
+			if err != nil {
+				return fmt.Errorf("error initializing Azure Blob Storage client: %w", err)
+			}
+
+			sshConfig, err := initSSHConfig(v, inputURI, outputURI)
+			if err != nil {
+				return fmt.Errorf("error initializing ssh config: %w", err)
+			}
+
+			inputHostKeyCallback, err := initHostKeyCallback(v, inputURI, v.GetString(cli.FlagInputHostKeyFingerprint), sshConfig)
+			if err != nil {
+				return fmt.Errorf("error initializing host key verification for input: %w", err)
+			}
+
+			outputHostKeyCallback, err := initHostKeyCallback(v, outputURI, v.GetString(cli.FlagOutputHostKeyFingerprint), sshConfig)
+			if err != nil {
+				return fmt.Errorf("error initializing host key verification for output: %w", err)
+			}
+
+			inputPrivateKeyPassphrase, err := initPrivateKeyPassphrase(inputURI, inputPrivateKey, v.GetString(cli.FlagInputPrivateKeyPassphrase), cli.FlagInputPrivateKeyPassphrase)
+			if err != nil {
+				return fmt.Errorf("error initializing passphrase for input private key: %w", err)
+			}
+
+			outputPrivateKeyPassphrase, err := initPrivateKeyPassphrase(outputURI, outputPrivateKey, v.GetString(cli.FlagOutputPrivateKeyPassphrase), cli.FlagOutputPrivateKeyPassphrase)
+			if err != nil {
+				return fmt.Errorf("error initializing passphrase for output private key: %w", err)
+			}
+
+			sshAgentSocket := v.GetString(cli.FlagSSHAuthSock)
+
+			jumpHostKeyCallback, err := initJumpHostKeyCallback(v, inputURI, outputURI)
+			if err != nil {
+				return fmt.Errorf("error initializing host key verification for jump hosts: %w", err)
+			}
+
+			inputJumpHosts := v.GetStringSlice(cli.FlagInputJumpHost)
+
+			outputJumpHosts := v.GetStringSlice(cli.FlagOutputJumpHost)
+
+			inputSFTPClient, inputSSHClient, err := initSFTPClient(inputURI, &grw.NewSSHClientOptionsInput{
+				Password:             inputPassword,
+				PrivateKey:           inputPrivateKey,
+				PrivateKeyPassphrase: inputPrivateKeyPassphrase,
+				SSHAgentSocket:       sshAgentSocket,
+				SSHConfig:            sshConfig,
+				HostKeyCallback:      inputHostKeyCallback,
+				JumpHosts:            inputJumpHosts,
+				JumpHostKeyCallback:  jumpHostKeyCallback,
+			}, retryPolicy)
 			if err != nil {
 				return fmt.Errorf("error initializing SFTP client for input at %q: %w", inputURI, err)
 			}
 
-			outputSFTPClient, outputSSHClient, err := initSFTPClient(outputURI, outputPassword, outputPrivateKey)
+			outputSFTPClient, outputSSHClient, err := initSFTPClient(outputURI, &grw.NewSSHClientOptionsInput{
+				Password:             outputPassword,
+				PrivateKey:           outputPrivateKey,
+				PrivateKeyPassphrase: outputPrivateKeyPassphrase,
+				SSHAgentSocket:       sshAgentSocket,
+				SSHConfig:            sshConfig,
+				HostKeyCallback:      outputHostKeyCallback,
+				JumpHosts:            outputJumpHosts,
+				JumpHostKeyCallback:  jumpHostKeyCallback,
+			}, retryPolicy)
 			if err != nil {
 				return fmt.Errorf("error initializing SFTP client for output at %q: %w", outputURI, err)
 			}
 
 			inputCompression := v.GetString(cli.FlagInputCompression)
+			detectInputCompression := inputCompression == cli.CompressionAuto
+			if detectInputCompression {
+				inputCompression = ""
+			}
 			inputDictionary := v.GetString(cli.FlagInputDictionary)
 
-			err = checkURIRead(inputURI, inputSFTPClient)
+			err = checkURIRead(inputURI, inputSFTPClient, inputSSHClient)
 			if err != nil {
-				_ = inputSFTPClient.Close()
-				_ = inputSSHClient.Close()
+				if inputSFTPClient != nil {
+					_ = inputSFTPClient.Close()
+				}
+				if inputSSHClient != nil {
+					_ = inputSSHClient.Close()
+				}
 				return fmt.Errorf("input resource %q not valid: %w", inputURI, err)
 			}
 
-			readFromResourceOutput, err := grw.ReadFromResource(&grw.ReadFromResourceInput{
-				URI:        inputURI,
-				Alg:        inputCompression,
-				Dict:       []byte(inputDictionary),
-				BufferSize: v.GetInt(cli.FlagInputBufferSize),
-				S3Client:   s3Client,
-				SSHClient:  inputSSHClient,
-				SFTPClient: inputSFTPClient,
-				Password:   inputPassword,
-				PrivateKey: inputPrivateKey,
-			})
+			resume := v.GetBool(cli.FlagResume)
+			if resume && grw.IsGlob(inputURI) {
+				return fmt.Errorf("cannot resume a transfer from input uri %q with wildcards", inputURI)
+			}
+
+			inputOffset, inputValidator := int64(0), ""
+			if resume {
+				inputOffset, inputValidator, err = loadResume(inputURI, outputURI)
+				if err != nil {
+					return fmt.Errorf("error resuming output at uri %q: %w", outputURI, err)
+				}
+				if verbose && inputOffset > 0 {
+					fmt.Fprintf(os.Stderr, "Resuming from byte %d\n", inputOffset)
+				}
+				if inputOffset > 0 && len(inputValidator) == 0 {
+					fmt.Fprintf(os.Stderr, "warning: resuming without checking whether the input at uri %q has changed, since no ETag or Last-Modified date was saved\n", inputURI)
+				}
+			}
+
+			readFromResourceInput := &grw.ReadFromResourceInput{
+				URI:                  inputURI,
+				Alg:                  inputCompression,
+				DetectAlg:            detectInputCompression,
+				Dict:                 []byte(inputDictionary),
+				BufferSize:           v.GetInt(cli.FlagInputBufferSize),
+				Offset:               inputOffset,
+				IfRange:              inputValidator,
+				AzureBlobClient:      azureBlobClient,
+				GCSClient:            gcsClient,
+				S3Client:             s3Client,
+				RequestPayer:         v.GetBool(cli.FlagAWSS3RequesterPays),
+				SSHClient:            inputSSHClient,
+				SFTPClient:           inputSFTPClient,
+				Password:             inputPassword,
+				PrivateKey:           inputPrivateKey,
+				PrivateKeyPassphrase: inputPrivateKeyPassphrase,
+				SSHAgentSocket:       sshAgentSocket,
+				SSHConfig:            sshConfig,
+				HostKeyCallback:      inputHostKeyCallback,
+				JumpHosts:            inputJumpHosts,
+				JumpHostKeyCallback:  jumpHostKeyCallback,
+				Retry:                retryPolicy,
+			}
+			readFromResourceOutput, err := openInput(readFromResourceInput, []byte(v.GetString(cli.FlagInputSeparator)))
+			if err != nil && resume && errors.Is(err, grw.ErrResourceChanged) {
+				// the input has changed since the transfer started, so restart from the beginning.
+				if verbose {
+					fmt.Fprintf(os.Stderr, "Input at uri %q has changed, restarting from byte 0\n", inputURI)
+				}
+				err = truncateResume(outputURI)
+				if err != nil {
+					return fmt.Errorf("error resuming output at uri %q: %w", outputURI, err)
+				}
+				readFromResourceInput.Offset, readFromResourceInput.IfRange = 0, ""
+				readFromResourceInput.SSHClient, readFromResourceInput.SFTPClient = nil, nil
+				readFromResourceOutput, err = grw.ReadFromResource(readFromResourceInput)
+			}
 			if err != nil {
 				return fmt.Errorf("error opening resource at uri %q: %w", inputURI, err)
 			}
+			if verbose && readFromResourceOutput.Metadata != nil {
+				if etag := readFromResourceOutput.Metadata.ETag; len(etag) > 0 {
+					fmt.Fprintf(os.Stderr, "Input ETag: %s\n", etag)
+				}
+				if versionID := readFromResourceOutput.Metadata.VersionID; len(versionID) > 0 {
+					fmt.Fprintf(os.Stderr, "Input version: %s\n", versionID)
+				}
+			}
+			if resume {
+				if validator := readFromResourceOutput.Metadata.Validator(); len(validator) > 0 {
+					err = saveResume(inputURI, outputURI, validator)
+					if err != nil {
+						return fmt.Errorf("error resuming output at uri %q: %w", outputURI, err)
+					}
+				}
+			}
 			inputReader := readFromResourceOutput.Reader
 
 			outputCompression := v.GetString(cli.FlagOutputCompression)
 			outputDictionary := v.GetString(cli.FlagOutputDictionary)
 			outputOverwrite := v.GetBool(cli.FlagOutputOverwrite)
 			outputAppend := v.GetBool(cli.FlagOutputAppend)
+			if resume {
+				// continue writing from the end of the output file
+				outputAppend = true
+			}
 
 			splitLines := v.GetInt(cli.FlagSplitLines)
 
 			var outputWriter io.WriteCloser
-			var outputBuffer io.Buffer
 
 			if outputURI == "-" {
 				outputWriter, err = grw.WrapWriter(nop.NewWriteCloser(os.Stdout), outputCompression, []byte(outputDictionary), grw.NoBuffer)
 				if err != nil {
 					return fmt.Errorf("error opening stdout: %w", err)
 				}
-			} else if strings.HasPrefix(outputURI, "s3://") {
-				outputBuffer = new(bytes.Buffer)
-				outputWriter, err = grw.WrapWriter(nop.NewWriteCloser(outputBuffer), outputCompression, []byte(outputDictionary), grw.NoBuffer)
-				if err != nil {
-					return fmt.Errorf("error opening bytes buffer for %q: %w", outputURI, err)
-				}
 			} else {
 				uri := outputURI
 				if splitLines > 0 {
@@ -313,18 +720,58 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 						return fmt.Errorf("cannot write to resource at uri %q: %w", outputURI, err)
 					}
 					writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
-						ACL:        outputACL,
-						Append:     outputAppend,
-						Alg:        outputCompression,
-						BufferSize: outputBufferSize,
-						Dict:       []byte(outputDictionary),
-						Mode:       uint32(outputMode),
-						Password:   outputPassword,
-						PrivateKey: outputPrivateKey,
-						S3Client:   s3Client,
-						SSHClient:  outputSSHClient,
-						SFTPClient: outputSFTPClient,
-						URI:        uri,
+						ACL:                  outputACL,
+						Append:               outputAppend,
+						Atomic:               v.GetBool(cli.FlagOutputAtomic),
+						Alg:                  outputCompression,
+						BufferSize:           outputBufferSize,
+						Dict:                 []byte(outputDictionary),
+						DirMode:              uint32(outputDirMode),
+						Mode:                 uint32(outputMode),
+						Parents:              v.GetBool(cli.FlagOutputMkdirs),
+						Password:             outputPassword,
+						PrivateKey:           outputPrivateKey,
+						PrivateKeyPassphrase: outputPrivateKeyPassphrase,
+						SSHAgentSocket:       sshAgentSocket,
+						SSHConfig:            sshConfig,
+						HostKeyCallback:      outputHostKeyCallback,
+						JumpHosts:            outputJumpHosts,
+						JumpHostKeyCallback:  jumpHostKeyCallback,
+						Retry:                retryPolicy,
+						S3Client:             s3Client,
+						SSHClient:            outputSSHClient,
+						SFTPClient:           outputSFTPClient,
+						URI:                  uri,
+					})
+					if errWriteToResource != nil {
+						return fmt.Errorf("error writing to resource at uri %q: %w", outputURI, errWriteToResource)
+					}
+					outputWriter = writeToResourceOutput.Writer
+				} else if scheme == "ssh" || scheme == "ssh+cmd" {
+					if scheme == "ssh" {
+						err = ssh2.CheckFileWrite(outputSSHClient, strings.SplitN(path, "/", 2)[1], outputAppend, outputOverwrite)
+						if err != nil {
+							return fmt.Errorf("cannot write to resource at uri %q: %w", outputURI, err)
+						}
+					}
+					writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
+						Alg:                  outputCompression,
+						Append:               outputAppend,
+						BufferSize:           outputBufferSize,
+						Dict:                 []byte(outputDictionary),
+						Mode:                 uint32(outputMode),
+						Parents:              v.GetBool(cli.FlagOutputMkdirs),
+						Password:             outputPassword,
+						PrivateKey:           outputPrivateKey,
+						PrivateKeyPassphrase: outputPrivateKeyPassphrase,
+						SSHAgentSocket:       sshAgentSocket,
+						SSHConfig:            sshConfig,
+						HostKeyCallback:      outputHostKeyCallback,
+						JumpHosts:            outputJumpHosts,
+						JumpHostKeyCallback:  jumpHostKeyCallback,
+						Retry:                retryPolicy,
+						SSHClient:            outputSSHClient,
+						URI:                  uri,
 					})
 					if errWriteToResource != nil {
 						return fmt.Errorf("error writing to resource at uri %q: %w", outputURI, errWriteToResource)
@@ -341,24 +788,115 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 							return fmt.Errorf("error statting uri %q: %w", uri, errStat)
 						}
 						if !exists {
-							err = os.MkdirAll(filepath.Dir(path), 0770)
+							err = os.MkdirAll(filepath.Dir(path), stdos.FileMode(outputDirMode))
 							if err != nil {
 								return fmt.Errorf("error creating parent directories for uri %q: %w", uri, err)
 							}
 						}
 					}
 					writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
-						ACL:        outputACL,
+						ACL:                  outputACL,
+						Alg:                  outputCompression,
+						Append:               outputAppend,
+						BufferSize:           outputBufferSize,
+						Dict:                 []byte(outputDictionary),
+						Mode:                 uint32(outputMode),
+						Password:             outputPassword,
+						PrivateKey:           outputPrivateKey,
+						PrivateKeyPassphrase: outputPrivateKeyPassphrase,
+						SSHAgentSocket:       sshAgentSocket,
+						SSHConfig:            sshConfig,
+						HostKeyCallback:      outputHostKeyCallback,
+						JumpHosts:            outputJumpHosts,
+						JumpHostKeyCallback:  jumpHostKeyCallback,
+						Retry:                retryPolicy,
+						S3Client:             s3Client,
+						SSHClient:            outputSSHClient,
+						SFTPClient:           outputSFTPClient,
+						URI:                  uri,
+					})
+					if errWriteToResource != nil {
+						return fmt.Errorf("error writing to resource at uri %q: %w", outputURI, errWriteToResource)
+					}
+					outputWriter = writeToResourceOutput.Writer
+				} else if scheme == "s3" {
+					if outputAppend {
+						return fmt.Errorf("cannot write to resource at uri %q: AWS S3 does not support appending", outputURI)
+					}
+					writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
+						ACL:                  outputACL,
+						Alg:                  outputCompression,
+						BufferSize:           outputBufferSize,
+						CacheControl:         outputCacheControl,
+						ContentEncoding:      outputContentEncoding,
+						ContentType:          outputContentType,
+						Metadata:             outputMetadata,
+						Dict:                 []byte(outputDictionary),
+						S3Client:             s3Client,
+						ServerSideEncryption: outputSSE,
+						SSECustomerKey:       outputSSECustomerKey,
+						SSEKMSKeyID:          outputSSEKMSKeyID,
+						StorageClass:         outputStorageClass,
+						Tags:                 outputTags,
+						URI:                  uri,
+					})
+					if errWriteToResource != nil {
+						return fmt.Errorf("error writing to resource at uri %q: %w", outputURI, errWriteToResource)
+					}
+					outputWriter = writeToResourceOutput.Writer
+				} else if scheme == "azblob" || isAzureBlobURI(uri) {
+					err = checkAzureBlobWrite(azureBlobClient, uri, outputAppend, outputOverwrite)
+					if err != nil {
+						return fmt.Errorf("cannot write to resource at uri %q: %w", outputURI, err)
+					}
+					writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
+						Alg:             outputCompression,
+						AzureBlobClient: azureBlobClient,
+						CacheControl:    outputCacheControl,
+						ContentEncoding: outputContentEncoding,
+						ContentType:     outputContentType,
+						Metadata:        outputMetadata,
+						BufferSize:      outputBufferSize,
+						Dict:            []byte(outputDictionary),
+						Retry:           retryPolicy,
+						URI:             uri,
+					})
+					if errWriteToResource != nil {
+						return fmt.Errorf("error writing to resource at uri %q: %w", outputURI, errWriteToResource)
+					}
+					outputWriter = writeToResourceOutput.Writer
+				} else if scheme == "gs" {
+					err = checkGCSWrite(gcsClient, uri, outputAppend, outputOverwrite)
+					if err != nil {
+						return fmt.Errorf("cannot write to resource at uri %q: %w", outputURI, err)
+					}
+					writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
+						Alg:             outputCompression,
+						BufferSize:      outputBufferSize,
+						Dict:            []byte(outputDictionary),
+						GCSClient:       gcsClient,
+						CacheControl:    outputCacheControl,
+						ContentEncoding: outputContentEncoding,
+						ContentType:     outputContentType,
+						Metadata:        outputMetadata,
+						Retry:           retryPolicy,
+						URI:             uri,
+					})
+					if errWriteToResource != nil {
+						return fmt.Errorf("error writing to resource at uri %q: %w", outputURI, errWriteToResource)
+					}
+					outputWriter = writeToResourceOutput.Writer
+				} else if scheme == "webdav" || scheme == "webdavs" {
+					err = checkWebDAVWrite(uri, outputAppend, outputOverwrite)
+					if err != nil {
+						return fmt.Errorf("cannot write to resource at uri %q: %w", outputURI, err)
+					}
+					writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
 						Alg:        outputCompression,
-						Append:     outputAppend,
 						BufferSize: outputBufferSize,
 						Dict:       []byte(outputDictionary),
-						Mode:       uint32(outputMode),
-						Password:   outputPassword,
-						PrivateKey: outputPrivateKey,
-						S3Client:   s3Client,
-						SSHClient:  outputSSHClient,
-						SFTPClient: outputSFTPClient,
+						Parents:    v.GetBool(cli.FlagOutputMkdirs),
+						Retry:      retryPolicy,
 						URI:        uri,
 					})
 					if errWriteToResource != nil {
@@ -396,6 +934,7 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 			}()
 
 			brokenPipe := false
+			incomplete := false
 			if splitLines > 0 {
 				go func() {
 					eof := false
@@ -435,18 +974,60 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 									break
 								}
 								writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
-									ACL:        outputACL,
-									Alg:        outputCompression,
-									Append:     outputAppend,
-									BufferSize: outputBufferSize,
-									Dict:       []byte(outputDictionary),
-									Mode:       uint32(outputMode),
-									Password:   outputPassword,
-									PrivateKey: outputPrivateKey,
-									S3Client:   s3Client,
-									SSHClient:  outputSSHClient,
-									SFTPClient: outputSFTPClient,
-									URI:        uri,
+									ACL:                  outputACL,
+									Alg:                  outputCompression,
+									Append:               outputAppend,
+									Atomic:               v.GetBool(cli.FlagOutputAtomic),
+									BufferSize:           outputBufferSize,
+									Dict:                 []byte(outputDictionary),
+									DirMode:              uint32(outputDirMode),
+									Mode:                 uint32(outputMode),
+									Parents:              v.GetBool(cli.FlagOutputMkdirs),
+									Password:             outputPassword,
+									PrivateKey:           outputPrivateKey,
+									PrivateKeyPassphrase: outputPrivateKeyPassphrase,
+									SSHAgentSocket:       sshAgentSocket,
+									SSHConfig:            sshConfig,
+									HostKeyCallback:      outputHostKeyCallback,
+									JumpHosts:            outputJumpHosts,
+									JumpHostKeyCallback:  jumpHostKeyCallback,
+									Retry:                retryPolicy,
+									S3Client:             s3Client,
+									SSHClient:            outputSSHClient,
+									SFTPClient:           outputSFTPClient,
+									URI:                  uri,
+								})
+								if errWriteToResource != nil {
+									fmt.Fprint(os.Stderr, fmt.Errorf("error opening resource at uri %q: %w", outputURI, errWriteToResource).Error())
+									break
+								}
+								outputWriter = writeToResourceOutput.Writer
+							} else if scheme == "ssh" || scheme == "ssh+cmd" {
+								if scheme == "ssh" {
+									errCheckFileWrite := ssh2.CheckFileWrite(outputSSHClient, strings.SplitN(path, "/", 2)[1], outputAppend, outputOverwrite)
+									if errCheckFileWrite != nil {
+										fmt.Fprint(os.Stderr, fmt.Errorf("cannot write to resource at uri %q: %w", uri, errCheckFileWrite).Error())
+										break
+									}
+								}
+								writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
+									Alg:                  outputCompression,
+									Append:               outputAppend,
+									BufferSize:           outputBufferSize,
+									Dict:                 []byte(outputDictionary),
+									Mode:                 uint32(outputMode),
+									Parents:              v.GetBool(cli.FlagOutputMkdirs),
+									Password:             outputPassword,
+									PrivateKey:           outputPrivateKey,
+									PrivateKeyPassphrase: outputPrivateKeyPassphrase,
+									SSHAgentSocket:       sshAgentSocket,
+									SSHConfig:            sshConfig,
+									HostKeyCallback:      outputHostKeyCallback,
+									JumpHosts:            outputJumpHosts,
+									JumpHostKeyCallback:  jumpHostKeyCallback,
+									Retry:                retryPolicy,
+									SSHClient:            outputSSHClient,
+									URI:                  uri,
 								})
 								if errWriteToResource != nil {
 									fmt.Fprint(os.Stderr, fmt.Errorf("error opening resource at uri %q: %w", outputURI, errWriteToResource).Error())
@@ -466,7 +1047,7 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 										break
 									}
 									if !exists {
-										errMkdirAll := os.MkdirAll(filepath.Dir(path), 0770)
+										errMkdirAll := os.MkdirAll(filepath.Dir(path), stdos.FileMode(outputDirMode))
 										if errMkdirAll != nil {
 											fmt.Fprint(os.Stderr, fmt.Errorf("error creating parent directories for uri %q: %w", uri, errMkdirAll).Error())
 											break
@@ -474,17 +1055,112 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 									}
 								}
 								writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
-									ACL:        outputACL,
+									ACL:                  outputACL,
+									Alg:                  outputCompression,
+									Append:               outputAppend,
+									BufferSize:           outputBufferSize,
+									Dict:                 []byte(outputDictionary),
+									Mode:                 uint32(outputMode),
+									Password:             outputPassword,
+									PrivateKey:           outputPrivateKey,
+									PrivateKeyPassphrase: outputPrivateKeyPassphrase,
+									SSHAgentSocket:       sshAgentSocket,
+									SSHConfig:            sshConfig,
+									HostKeyCallback:      outputHostKeyCallback,
+									JumpHosts:            outputJumpHosts,
+									JumpHostKeyCallback:  jumpHostKeyCallback,
+									Retry:                retryPolicy,
+									S3Client:             s3Client,
+									SSHClient:            outputSSHClient,
+									SFTPClient:           outputSFTPClient,
+									URI:                  uri,
+								})
+								if errWriteToResource != nil {
+									fmt.Fprint(os.Stderr, fmt.Errorf("error opening resource at uri %q: %w", outputURI, errWriteToResource).Error())
+									break
+								}
+								outputWriter = writeToResourceOutput.Writer
+							} else if scheme == "s3" {
+								writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
+									ACL:                  outputACL,
+									Alg:                  outputCompression,
+									BufferSize:           outputBufferSize,
+									CacheControl:         outputCacheControl,
+									ContentEncoding:      outputContentEncoding,
+									ContentType:          outputContentType,
+									Metadata:             outputMetadata,
+									Dict:                 []byte(outputDictionary),
+									S3Client:             s3Client,
+									ServerSideEncryption: outputSSE,
+									SSECustomerKey:       outputSSECustomerKey,
+									SSEKMSKeyID:          outputSSEKMSKeyID,
+									StorageClass:         outputStorageClass,
+									Tags:                 outputTags,
+									URI:                  uri,
+								})
+								if errWriteToResource != nil {
+									fmt.Fprint(os.Stderr, fmt.Errorf("error opening resource at uri %q: %w", outputURI, errWriteToResource).Error())
+									break
+								}
+								outputWriter = writeToResourceOutput.Writer
+							} else if scheme == "azblob" || isAzureBlobURI(uri) {
+								errCheckAzureBlobWrite := checkAzureBlobWrite(azureBlobClient, uri, outputAppend, outputOverwrite)
+								if errCheckAzureBlobWrite != nil {
+									fmt.Fprint(os.Stderr, fmt.Errorf("cannot write to resource at uri %q: %w", uri, errCheckAzureBlobWrite).Error())
+									break
+								}
+								writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
+									Alg:             outputCompression,
+									AzureBlobClient: azureBlobClient,
+									CacheControl:    outputCacheControl,
+									ContentEncoding: outputContentEncoding,
+									ContentType:     outputContentType,
+									Metadata:        outputMetadata,
+									BufferSize:      outputBufferSize,
+									Dict:            []byte(outputDictionary),
+									Retry:           retryPolicy,
+									URI:             uri,
+								})
+								if errWriteToResource != nil {
+									fmt.Fprint(os.Stderr, fmt.Errorf("error opening resource at uri %q: %w", outputURI, errWriteToResource).Error())
+									break
+								}
+								outputWriter = writeToResourceOutput.Writer
+							} else if scheme == "gs" {
+								errCheckGCSWrite := checkGCSWrite(gcsClient, uri, outputAppend, outputOverwrite)
+								if errCheckGCSWrite != nil {
+									fmt.Fprint(os.Stderr, fmt.Errorf("cannot write to resource at uri %q: %w", uri, errCheckGCSWrite).Error())
+									break
+								}
+								writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
+									Alg:             outputCompression,
+									BufferSize:      outputBufferSize,
+									Dict:            []byte(outputDictionary),
+									GCSClient:       gcsClient,
+									CacheControl:    outputCacheControl,
+									ContentEncoding: outputContentEncoding,
+									ContentType:     outputContentType,
+									Metadata:        outputMetadata,
+									Retry:           retryPolicy,
+									URI:             uri,
+								})
+								if errWriteToResource != nil {
+									fmt.Fprint(os.Stderr, fmt.Errorf("error opening resource at uri %q: %w", outputURI, errWriteToResource).Error())
+									break
+								}
+								outputWriter = writeToResourceOutput.Writer
+							} else if scheme == "webdav" || scheme == "webdavs" {
+								errCheckWebDAVWrite := checkWebDAVWrite(uri, outputAppend, outputOverwrite)
+								if errCheckWebDAVWrite != nil {
+									fmt.Fprint(os.Stderr, fmt.Errorf("cannot write to resource at uri %q: %w", uri, errCheckWebDAVWrite).Error())
+									break
+								}
+								writeToResourceOutput, errWriteToResource := grw.WriteToResource(&grw.WriteToResourceInput{
 									Alg:        outputCompression,
-									Append:     outputAppend,
 									BufferSize: outputBufferSize,
 									Dict:       []byte(outputDictionary),
-									Mode:       uint32(outputMode),
-									Password:   outputPassword,
-									PrivateKey: outputPrivateKey,
-									S3Client:   s3Client,
-									SSHClient:  outputSSHClient,
-									SFTPClient: outputSFTPClient,
+									Parents:    v.GetBool(cli.FlagOutputMkdirs),
+									Retry:      retryPolicy,
 									URI:        uri,
 								})
 								if errWriteToResource != nil {
@@ -543,6 +1219,7 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 								// will then use n > 0, n < len(b), and return EOF
 							} else {
 								fmt.Fprintln(os.Stderr, fmt.Errorf("error reading from resource at uri %q: %w", inputURI, errRead).Error())
+								incomplete = true
 								break
 							}
 						}
@@ -561,6 +1238,7 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 									}
 								}
 								fmt.Fprintln(os.Stderr, fmt.Errorf("error writing to resource at uri %q: %w", outputURI, err).Error())
+								incomplete = true
 							}
 						}
 
@@ -582,20 +1260,17 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 				os.Exit(1)
 			}
 
-			if strings.HasPrefix(outputURI, "s3://") {
-				i := strings.Index(outputPath, "/")
-				if i == -1 {
-					return fmt.Errorf("path missing bucket: %w", err)
+			if incomplete {
+				if resume {
+					fmt.Fprintf(os.Stderr, "the output at uri %q is incomplete, run again with --%s to continue\n", outputURI, cli.FlagResume)
 				}
-				errUpload := grw.UploadS3Object(&grw.UploadS3ObjectInput{
-					ACL:    outputACL,
-					Bucket: outputPath[0:i],
-					Client: s3Client,
-					Key:    outputPath[i+1:],
-					Object: outputBuffer,
-				})
-				if errUpload != nil {
-					return fmt.Errorf("error uploading to AWS S3 at uri %q: %w", outputURI, errUpload)
+				os.Exit(1)
+			}
+
+			if resume {
+				err = removeResume(outputURI)
+				if err != nil {
+					return fmt.Errorf("error completing output at uri %q: %w", outputURI, err)
 				}
 			}
 
@@ -622,6 +1297,11 @@ Supports the following compression algorithms: ` + strings.Join(grw.Algorithms,
 	}
 	cli.InitFlags(rootCommand.Flags())
 
+	rootCommand.AddCommand(newStatCommand())
+	rootCommand.AddCommand(newListCommand())
+	rootCommand.AddCommand(newRemoveCommand())
+	rootCommand.AddCommand(newMoveCommand())
+
 	if err := rootCommand.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, "grw: "+err.Error())
 		fmt.Fprintln(os.Stderr, "Try grw --help for more information.")
@@ -0,0 +1,59 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/cli"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
+)
+
+// newMoveCommand returns the mv subcommand, which moves a resource from one uri to another.
+func newMoveCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:                   `mv [flags] <SOURCE> <DESTINATION>`,
+		DisableFlagsInUseLine: true,
+		Short:                 "move a resource",
+		Long: `move the resource at the source uri to the destination uri.
+If the destination ends with a slash or is an existing directory or prefix, then the resource is moved into it with the same name.
+Local files and files on the same SFTP server are renamed, and objects within AWS S3 are copied on the server and then removed.
+Otherwise, the resource is copied through this client, and the source is removed once the copy has the same size.
+Resources cannot be moved between different SSH servers, since the SSH flags apply to a single server.`,
+		Args:          cobra.ExactArgs(2),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v, err := cli.InitViper(cmd.Flags())
+			if err != nil {
+				return fmt.Errorf("error initializing viper: %w", err)
+			}
+
+			source, destination := args[0], args[1]
+
+			resourceOptions, err := initResourceOptions(v, source, destination)
+			if err != nil {
+				return err
+			}
+
+			return grw.Move(context.Background(), source, destination, &grw.MoveOptions{
+				ResourceOptions: *resourceOptions,
+				Overwrite:       v.GetBool(cli.FlagOverwrite),
+				Parents:         v.GetBool(cli.FlagMkdirs),
+			})
+		},
+	}
+	flag := command.Flags()
+	flag.BoolP(cli.FlagOverwrite, "o", false, "overwrite the destination if it already exists")
+	flag.BoolP(cli.FlagMkdirs, "m", false, "make the parent directories of the destination if missing")
+	cli.InitResourceFlags(flag)
+	return command
+}
@@ -0,0 +1,85 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/cli"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
+)
+
+// initResourceOptions returns the clients and credentials used by subcommands to access the resources at the uris,
+// as set by the flags initialized by cli.InitResourceFlags.  The second uri is blank if the subcommand only accesses one resource.
+func initResourceOptions(v *viper.Viper, uri string, otherURI string) (*grw.ResourceOptions, error) {
+	retryPolicy := initRetryPolicy(v)
+
+	s3Client, _, err := initS3Client(v, uri, otherURI, retryPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing AWS S3 client: %w", err)
+	}
+
+	gcsClient, err := initGCSClient(v, uri, otherURI)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing Google Cloud Storage client: %w", err)
+	}
+
+	azureBlobClient, err := initAzureBlobClient(v, uri, otherURI)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing Azure Blob Storage client: %w", err)
+	}
+
+	sshConfig, err := initSSHConfig(v, uri, otherURI)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing ssh config: %w", err)
+	}
+
+	// the host key callback and passphrase are initialized for the first SSH server
+	sshURI := uri
+	if !isSSHURI(sshURI) {
+		sshURI = otherURI
+	}
+
+	hostKeyCallback, err := initHostKeyCallback(v, sshURI, v.GetString(cli.FlagHostKeyFingerprint), sshConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing host key verification: %w", err)
+	}
+
+	privateKey, err := initPrivateKey(v.GetString(cli.FlagPrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("error initializing private key: %w", err)
+	}
+
+	privateKeyPassphrase, err := initPrivateKeyPassphrase(sshURI, privateKey, v.GetString(cli.FlagPrivateKeyPassphrase), cli.FlagPrivateKeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing passphrase for private key: %w", err)
+	}
+
+	jumpHostKeyCallback, err := initJumpHostKeyCallback(v, uri, otherURI)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing host key verification for jump hosts: %w", err)
+	}
+
+	return &grw.ResourceOptions{
+		AzureBlobClient:      azureBlobClient,
+		GCSClient:            gcsClient,
+		S3Client:             s3Client,
+		RequestPayer:         v.GetBool(cli.FlagAWSS3RequesterPays),
+		Password:             v.GetString(cli.FlagPassword),
+		PrivateKey:           privateKey,
+		PrivateKeyPassphrase: privateKeyPassphrase,
+		SSHAgentSocket:       v.GetString(cli.FlagSSHAuthSock),
+		SSHConfig:            sshConfig,
+		HostKeyCallback:      hostKeyCallback,
+		JumpHosts:            v.GetStringSlice(cli.FlagJumpHost),
+		JumpHostKeyCallback:  jumpHostKeyCallback,
+		Retry:                retryPolicy,
+	}, nil
+}
@@ -0,0 +1,125 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdos "os"
+
+	homedir "github.com/mitchellh/go-homedir"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+)
+
+const (
+	resumeSuffix = ".grw-resume"
+)
+
+// resumeState is saved next to a partially written output file,
+// so that the next attempt can check that the input has not changed since the transfer started.
+type resumeState struct {
+	URI       string `json:"uri"`       // uri of the input resource
+	Validator string `json:"validator"` // ETag or Last-Modified date of the input resource
+}
+
+// resumePath returns the expanded path of the output file.
+func resumePath(outputURI string) (string, error) {
+	_, path := splitter.SplitURI(outputURI)
+	pathExpanded, err := homedir.Expand(path)
+	if err != nil {
+		return "", fmt.Errorf("error expanding file path %q: %w", path, err)
+	}
+	return pathExpanded, nil
+}
+
+// loadResume returns the size of the partially written output file and
+// the validator of the input resource saved when the transfer started, if any.
+// Returns an error if the transfer to the output file was started from a different input uri.
+//
+// If no validator was saved, then the resume is unchecked, since a change to the input cannot be detected.
+// No validator is saved if the input has no ETag or Last-Modified date, such as a file read over FTP, SFTP, or SSH,
+// or if the output file was not written with resume enabled.
+func loadResume(inputURI string, outputURI string) (int64, string, error) {
+	path, err := resumePath(outputURI)
+	if err != nil {
+		return 0, "", err
+	}
+	info, err := stdos.Stat(path)
+	if err != nil {
+		if stdos.IsNotExist(err) {
+			return 0, "", nil
+		}
+		return 0, "", fmt.Errorf("error stating output file at path %q: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return 0, "", fmt.Errorf("cannot resume writing to output at path %q: not a regular file", path)
+	}
+	b, err := stdos.ReadFile(path + resumeSuffix)
+	if err != nil {
+		if errors.Is(err, stdos.ErrNotExist) {
+			return info.Size(), "", nil
+		}
+		return 0, "", fmt.Errorf("error reading resume state from %q: %w", path+resumeSuffix, err)
+	}
+	state := resumeState{}
+	err = json.Unmarshal(b, &state)
+	if err != nil {
+		return 0, "", fmt.Errorf("error parsing resume state from %q: %w", path+resumeSuffix, err)
+	}
+	if state.URI != inputURI {
+		return 0, "", fmt.Errorf(
+			"cannot resume writing to output at path %q: the transfer was started from input uri %q, remove the output file and %q to restart",
+			path, state.URI, path+resumeSuffix)
+	}
+	return info.Size(), state.Validator, nil
+}
+
+// saveResume saves the validator of the input resource next to the output file.
+func saveResume(inputURI string, outputURI string, validator string) error {
+	path, err := resumePath(outputURI)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(resumeState{URI: inputURI, Validator: validator})
+	if err != nil {
+		return fmt.Errorf("error serializing resume state: %w", err)
+	}
+	err = stdos.WriteFile(path+resumeSuffix, b, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing resume state to %q: %w", path+resumeSuffix, err)
+	}
+	return nil
+}
+
+// removeResume removes the saved resume state, if any, after the transfer completes.
+func removeResume(outputURI string) error {
+	path, err := resumePath(outputURI)
+	if err != nil {
+		return err
+	}
+	err = stdos.Remove(path + resumeSuffix)
+	if err != nil && !stdos.IsNotExist(err) {
+		return fmt.Errorf("error removing resume state at %q: %w", path+resumeSuffix, err)
+	}
+	return nil
+}
+
+// truncateResume truncates the output file when the input resource has changed and the transfer restarts.
+func truncateResume(outputURI string) error {
+	path, err := resumePath(outputURI)
+	if err != nil {
+		return err
+	}
+	err = stdos.Truncate(path, 0)
+	if err != nil {
+		return fmt.Errorf("error truncating output file at path %q: %w", path, err)
+	}
+	return nil
+}
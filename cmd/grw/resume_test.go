@@ -0,0 +1,48 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadResume(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "out.txt")
+
+	// the output does not exist, so the transfer starts from the beginning
+	offset, validator, err := loadResume("https://example.com/a.txt", p)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+	assert.Equal(t, "", validator)
+
+	// no validator was saved, so the resume is unchecked
+	require.NoError(t, os.WriteFile(p, []byte("hello"), 0600))
+	offset, validator, err = loadResume("sftp://example.com/a.txt", p)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), offset)
+	assert.Equal(t, "", validator)
+
+	require.NoError(t, saveResume("https://example.com/a.txt", p, `"abc"`))
+	offset, validator, err = loadResume("https://example.com/a.txt", p)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), offset)
+	assert.Equal(t, `"abc"`, validator)
+
+	// the transfer was started from a different input
+	_, _, err = loadResume("https://example.com/b.txt", p)
+	assert.Error(t, err)
+
+	require.NoError(t, removeResume(p))
+	_, err = os.Stat(p + resumeSuffix)
+	assert.True(t, os.IsNotExist(err))
+}
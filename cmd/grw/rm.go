@@ -0,0 +1,72 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/cli"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
+)
+
+// newRemoveCommand returns the rm subcommand, which removes the resources at one or more uris.
+func newRemoveCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:                   `rm [flags] <URI>...`,
+		DisableFlagsInUseLine: true,
+		Short:                 "remove resources",
+		Long: `remove the resources at the uris, in order.
+Directories and prefixes are only removed with --recursive, which removes their contents first.
+With --dry-run, the uri of each resource that would be removed is printed, but nothing is removed.
+With --verbose, the uri of each resource is printed as it is removed.`,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v, err := cli.InitViper(cmd.Flags())
+			if err != nil {
+				return fmt.Errorf("error initializing viper: %w", err)
+			}
+
+			dryRun := v.GetBool(cli.FlagDryRun)
+			verbose := v.GetBool(cli.FlagVerbose)
+
+			for _, uri := range args {
+				resourceOptions, errOptions := initResourceOptions(v, uri, "")
+				if errOptions != nil {
+					return errOptions
+				}
+				options := &grw.RemoveOptions{
+					ResourceOptions: *resourceOptions,
+					Recursive:       v.GetBool(cli.FlagRecursive),
+					DryRun:          dryRun,
+				}
+				if dryRun || verbose {
+					options.OnRemove = func(uri string) {
+						_, _ = fmt.Fprintln(os.Stdout, uri)
+					}
+				}
+				err = grw.Remove(context.Background(), uri, options)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	flag := command.Flags()
+	flag.BoolP(cli.FlagRecursive, "r", false, "remove directories and prefixes along with their contents")
+	flag.Bool(cli.FlagDryRun, false, "print the uri of each resource that would be removed without removing it")
+	flag.BoolP(cli.FlagVerbose, "v", false, "print the uri of each resource as it is removed")
+	cli.InitResourceFlags(flag)
+	return command
+}
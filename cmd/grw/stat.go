@@ -0,0 +1,62 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/cli"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
+)
+
+// newStatCommand returns the stat subcommand, which prints information about a resource as JSON.
+func newStatCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:                   `stat [flags] <URI>`,
+		DisableFlagsInUseLine: true,
+		Short:                 "print information about a resource as JSON",
+		Long: `print information about the resource at the uri as a JSON object, with the uri, name, size,
+last modified time, mode, content type, and ETag of the resource, and whether the resource is a directory or a prefix.
+Fields that are not known for the scheme of the uri are omitted.
+Exits with an error if the resource does not exist.`,
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v, err := cli.InitViper(cmd.Flags())
+			if err != nil {
+				return fmt.Errorf("error initializing viper: %w", err)
+			}
+
+			uri := args[0]
+
+			options, err := initResourceOptions(v, uri, "")
+			if err != nil {
+				return err
+			}
+
+			info, err := grw.Stat(context.Background(), uri, options)
+			if err != nil {
+				return err
+			}
+
+			b, err := json.Marshal(info)
+			if err != nil {
+				return fmt.Errorf("error encoding information about resource at uri %q: %w", uri, err)
+			}
+			fmt.Println(string(b))
+			return nil
+		},
+	}
+	cli.InitResourceFlags(command.Flags())
+	return command
+}
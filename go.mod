@@ -18,6 +18,8 @@ require (
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/crypto v0.0.0-20211108221036-ceb1ce70b4fa
 	golang.org/x/mobile v0.0.0-20211109191125-d61a72f26a1a
+	golang.org/x/net v0.0.0-20210805182204-aaa1db679c0d
+	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1
 	golang.org/x/tools v0.1.8-0.20211022200916-316ba0b74098
 	honnef.co/go/tools v0.2.2
 )
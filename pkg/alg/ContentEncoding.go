@@ -0,0 +1,23 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package alg
+
+// ContentEncoding returns the HTTP content coding for data compressed with the given algorithm,
+// or a blank string if the algorithm has no registered content coding.
+// The "deflate" content coding is the zlib format, rather than raw DEFLATE.
+//
+//   - https://www.iana.org/assignments/http-parameters/http-parameters.xhtml#content-coding
+func ContentEncoding(alg string) string {
+	switch alg {
+	case AlgorithmGzip:
+		return "gzip"
+	case AlgorithmZlib:
+		return "deflate"
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package alg
+
+import (
+	"strings"
+)
+
+// FromContentEncoding returns the algorithm used to decode data with the given HTTP content coding.
+// FromContentEncoding is the inverse of ContentEncoding.
+// A blank content encoding or "identity" returns AlgorithmNone.
+// If the content coding is not supported, such as "br" or "zstd", or multiple content codings are listed,
+// then returns false.
+//
+//   - https://www.iana.org/assignments/http-parameters/http-parameters.xhtml#content-coding
+func FromContentEncoding(contentEncoding string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "", "identity":
+		return AlgorithmNone, true
+	case "gzip", "x-gzip":
+		return AlgorithmGzip, true
+	case "deflate":
+		return AlgorithmZlib, true
+	}
+	return "", false
+}
@@ -0,0 +1,41 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package alg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContentEncoding(t *testing.T) {
+	testCases := []struct {
+		contentEncoding string
+		alg             string
+		ok              bool
+	}{
+		{"", AlgorithmNone, true},
+		{"identity", AlgorithmNone, true},
+		{"gzip", AlgorithmGzip, true},
+		{"X-GZIP", AlgorithmGzip, true},
+		{"deflate", AlgorithmZlib, true},
+		{"br", "", false},
+		{"zstd", "", false},
+		{"gzip, br", "", false},
+	}
+	for _, testCase := range testCases {
+		alg, ok := FromContentEncoding(testCase.contentEncoding)
+		assert.Equal(t, testCase.alg, alg, testCase.contentEncoding)
+		assert.Equal(t, testCase.ok, ok, testCase.contentEncoding)
+	}
+	for _, alg := range []string{AlgorithmGzip, AlgorithmZlib} {
+		got, ok := FromContentEncoding(ContentEncoding(alg))
+		assert.True(t, ok)
+		assert.Equal(t, alg, got)
+	}
+}
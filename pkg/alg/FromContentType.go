@@ -0,0 +1,35 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package alg
+
+import (
+	"mime"
+)
+
+// FromContentType returns the algorithm used to decode data with the given media type,
+// such as "application/gzip" or "application/x-bzip2".
+// If the media type is not a known compressed format, then returns AlgorithmNone.
+func FromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return AlgorithmNone
+	}
+	switch mediaType {
+	case "application/gzip", "application/x-gzip", "application/gzip-compressed", "application/x-gzip-compressed":
+		return AlgorithmGzip
+	case "application/x-bzip2", "application/bzip2":
+		return AlgorithmBzip2
+	case "application/zlib":
+		return AlgorithmZlib
+	case "application/x-snappy-framed":
+		return AlgorithmSnappy
+	case "application/zip", "application/x-zip-compressed":
+		return AlgorithmZip
+	}
+	return AlgorithmNone
+}
@@ -0,0 +1,30 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package alg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContentType(t *testing.T) {
+	testCases := map[string]string{
+		"":                          AlgorithmNone,
+		"text/plain; charset=utf-8": AlgorithmNone,
+		"application/gzip":          AlgorithmGzip,
+		"application/x-gzip":        AlgorithmGzip,
+		"application/x-bzip2":       AlgorithmBzip2,
+		"application/zip":           AlgorithmZip,
+		"application/zlib":          AlgorithmZlib,
+		"invalid/":                  AlgorithmNone,
+	}
+	for contentType, alg := range testCases {
+		assert.Equal(t, alg, FromContentType(contentType), contentType)
+	}
+}
@@ -0,0 +1,32 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package alg
+
+import (
+	"path"
+	"strings"
+)
+
+// FromExtension returns the algorithm used to decode a file with the extension of the given path or uri,
+// such as ".gz" or ".bz2".  The extension is not case sensitive.
+// If the extension is not a known compressed format, then returns AlgorithmNone.
+func FromExtension(p string) string {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".gz", ".gzip":
+		return AlgorithmGzip
+	case ".bz2":
+		return AlgorithmBzip2
+	case ".zz", ".zlib":
+		return AlgorithmZlib
+	case ".sz":
+		return AlgorithmSnappy
+	case ".zip":
+		return AlgorithmZip
+	}
+	return AlgorithmNone
+}
@@ -0,0 +1,31 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package alg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromExtension(t *testing.T) {
+	testCases := map[string]string{
+		"":                            AlgorithmNone,
+		"data.csv":                    AlgorithmNone,
+		"s3://bucket/logs/part-0.gz":  AlgorithmGzip,
+		"/tmp/DATA.CSV.GZ":            AlgorithmGzip,
+		"sftp://host/in/data.csv.bz2": AlgorithmBzip2,
+		"archive.zip":                 AlgorithmZip,
+		"data.zz":                     AlgorithmZlib,
+		"data.sz":                     AlgorithmSnappy,
+		"gs://bucket/no-extension/gz": AlgorithmNone,
+	}
+	for p, alg := range testCases {
+		assert.Equal(t, alg, FromExtension(p), p)
+	}
+}
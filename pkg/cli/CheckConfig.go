@@ -8,12 +8,65 @@
 package cli
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/viper"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
 )
 
+func checkResume(args []string, v *viper.Viper) error {
+	switch args[0] {
+	case "stdin", "/dev/stdin", "-":
+		return fmt.Errorf("input %q is a device", args[0])
+	}
+	switch args[1] {
+	case "stdout", "/dev/stdout", "-":
+		return fmt.Errorf("output %q is a device", args[1])
+	}
+	if scheme, _ := splitter.SplitURI(args[1]); scheme != "" && scheme != "file" {
+		return fmt.Errorf("output %q is not a local file", args[1])
+	}
+	// the offset into the input must be the same as the size of the output
+	if inputCompression := v.GetString(FlagInputCompression); inputCompression != "none" && inputCompression != "" {
+		return fmt.Errorf("input compression is %q, but must be \"none\"", inputCompression)
+	}
+	if outputCompression := v.GetString(FlagOutputCompression); outputCompression != "none" && outputCompression != "" {
+		return fmt.Errorf("output compression is %q, but must be \"none\"", outputCompression)
+	}
+	if v.GetInt(FlagSplitLines) > 0 {
+		return fmt.Errorf("cannot split by lines")
+	}
+	return nil
+}
+
+func checkServerSideEncryption(v *viper.Viper) error {
+	sse := v.GetString(FlagOutputSSE)
+	switch sse {
+	case "", "AES256", "aws:kms":
+	default:
+		return fmt.Errorf("unknown server-side encryption %q: must be \"AES256\" or \"aws:kms\"", sse)
+	}
+	if len(v.GetString(FlagOutputSSEKMSKeyID)) > 0 && sse != "aws:kms" {
+		return fmt.Errorf("a KMS key ID requires server-side encryption \"aws:kms\"")
+	}
+	if customerKey := v.GetString(FlagOutputSSECustomerKey); len(customerKey) > 0 {
+		if len(sse) > 0 {
+			return fmt.Errorf("a customer-provided key cannot be used with server-side encryption %q", sse)
+		}
+		key, err := base64.StdEncoding.DecodeString(customerKey)
+		if err != nil {
+			return fmt.Errorf("error decoding customer-provided key: %w", err)
+		}
+		if len(key) != 32 {
+			return fmt.Errorf("customer-provided key is %d bytes, but must be 32 bytes", len(key))
+		}
+	}
+	return nil
+}
+
 func CheckConfig(args []string, v *viper.Viper) error {
 
 	if len(args) == 0 {
@@ -24,6 +77,51 @@ func CheckConfig(args []string, v *viper.Viper) error {
 		return fmt.Errorf("extra positional arguments")
 	}
 
+	if retryAttempts := v.GetInt(FlagRetryAttempts); retryAttempts < 1 {
+		return fmt.Errorf("invalid number of retry attempts %d: must be at least 1", retryAttempts)
+	}
+
+	if retryJitter := v.GetFloat64(FlagRetryJitter); retryJitter < 0 || retryJitter > 1 {
+		return fmt.Errorf("invalid retry jitter %v: must be between 0 and 1", retryJitter)
+	}
+
+	if _, err := ParseKeyValuePairs(v.GetStringSlice(FlagOutputMetadata)); err != nil {
+		return fmt.Errorf("invalid output metadata: %w", err)
+	}
+
+	if _, err := ParseKeyValuePairs(v.GetStringSlice(FlagOutputTags)); err != nil {
+		return fmt.Errorf("invalid output tags: %w", err)
+	}
+
+	if err := checkServerSideEncryption(v); err != nil {
+		return fmt.Errorf("invalid server-side encryption: %w", err)
+	}
+
+	if v.GetBool(FlagInsecureIgnoreHostKey) {
+		if v.GetBool(FlagSSHAcceptNewHostKeys) {
+			return fmt.Errorf("cannot accept new host keys when ignoring host keys")
+		}
+		if len(v.GetString(FlagInputHostKeyFingerprint)) > 0 || len(v.GetString(FlagOutputHostKeyFingerprint)) > 0 {
+			return fmt.Errorf("cannot verify host key fingerprints when ignoring host keys")
+		}
+	}
+
+	if v.GetBool(FlagOutputAtomic) {
+		if scheme, _ := splitter.SplitURI(args[1]); scheme != "sftp" {
+			return fmt.Errorf("cannot write atomically to output %q: atomic writes are only supported for SFTP", args[1])
+		}
+		if v.GetBool(FlagOutputAppend) {
+			return fmt.Errorf("cannot append to output when writing atomically")
+		}
+	}
+
+	if v.GetBool(FlagResume) {
+		err := checkResume(args, v)
+		if err != nil {
+			return fmt.Errorf("cannot resume: %w", err)
+		}
+	}
+
 	splitLines := v.GetInt(FlagSplitLines)
 	if splitLines > 0 {
 		if len(args) < 2 {
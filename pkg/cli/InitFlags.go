@@ -11,33 +11,52 @@ import (
 	"fmt"
 
 	"github.com/spf13/pflag"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
 )
 
 func InitFlags(flag *pflag.FlagSet) {
-	flag.String(FlagAWSProfile, "", "AWS Profile")
-	flag.String(FlagAWSDefaultRegion, "", "AWS Default Region")
-	flag.StringP(FlagAWSRegion, "", "", "AWS Region (overrides default region)")
-	flag.StringP(FlagAWSAccessKeyID, "", "", "AWS Access Key ID")
-	flag.StringP(FlagAWSSecretAccessKey, "", "", "AWS Secret Access Key")
-	flag.StringP(FlagAWSSessionToken, "", "", "AWS Session Token")
+	initStorageFlags(flag)
 
-	flag.String(FlagInputCompression, "none", "the input compression")
+	flag.String(FlagInputCompression, "none", fmt.Sprintf("the input compression, or %q to choose the compression from the content encoding or content type of a remote input, and for each input that matches an input uri with wildcards, from the file extension", CompressionAuto))
 	flag.String(FlagInputDictionary, "", "the input dictionary")
 	flag.Int(FlagInputBufferSize, DefaultBufferSize, "the input reader buffer size")
 	flag.String(FlagInputPrivateKey, "", "Use the provided private key to connect to the input.")
 	flag.String(FlagInputPassword, "", "Use the provided password to connect to the input.")
+	flag.String(FlagInputPrivateKeyPassphrase, "", "passphrase of the encrypted private key used to connect to the input, if not set then the passphrase is read from the terminal when required")
+	flag.String(FlagInputHostKeyFingerprint, "", "SHA256 or MD5 fingerprint of the host key of the input SSH server, if set then the known_hosts files are not used for the input")
+	flag.StringSlice(FlagInputJumpHost, []string{}, "jump hosts used to reach the input SSH server, each as [user[:password]@]host[:port], overrides the ProxyJump in the ssh config, set to \"none\" to connect directly")
+	flag.String(FlagInputSeparator, "", "separator written between the inputs that match an input uri with wildcards, such as $'\\n'")
 
 	flag.String(FlagOutputACL, "", "ACL of an output file in AWS S3")
+	flag.String(FlagOutputContentType, "", "content type of an output object in AWS S3, Google Cloud Storage, or Azure Blob Storage")
+	flag.String(FlagOutputContentEncoding, "", "content encoding of an output object in AWS S3, Google Cloud Storage, or Azure Blob Storage, for AWS S3 defaults to the encoding of the output compression")
+	flag.String(FlagOutputCacheControl, "", "cache control of an output object in AWS S3, Google Cloud Storage, or Azure Blob Storage")
+	flag.StringSlice(FlagOutputMetadata, []string{}, "user metadata of an output object in AWS S3, Google Cloud Storage, or Azure Blob Storage, as key=value pairs")
+	flag.StringSlice(FlagOutputTags, []string{}, "tags of an output object in AWS S3, as key=value pairs")
+	flag.String(FlagOutputStorageClass, "", "storage class of an output object in AWS S3, such as STANDARD_IA or GLACIER")
+	flag.String(FlagOutputSSE, "", "server-side encryption of an output object in AWS S3, either \"AES256\" (SSE-S3) or \"aws:kms\" (SSE-KMS)")
+	flag.String(FlagOutputSSEKMSKeyID, "", "ID or ARN of the KMS key used to encrypt an output object in AWS S3 with SSE-KMS")
+	flag.String(FlagOutputSSECustomerKey, "", "base64-encoded 256-bit key used to encrypt an output object in AWS S3 with a customer-provided key (SSE-C)")
 	flag.String(FlagOutputCompression, "none", "the output compression")
 	flag.String(FlagOutputDictionary, "", "the output dictionary")
 	flag.IntP(FlagOutputBufferSize, "b", -1, "The output writer buffer size. The default for stdout is 0.  The default for files is 4096.")
 
 	flag.BoolP(FlagOutputMkdirs, "m", false, "make directories if missing for output file")
+	flag.String(FlagOutputDirMode, "0770", "the mode of the directories made for local and SFTP output files")
 	flag.String(FlagOutputMode, "0600", "the mode of the output file")
 	flag.BoolP(FlagOutputAppend, "a", false, "append to output files")
+	flag.Bool(FlagOutputAtomic, false, "write an output file on a SFTP server to a temporary file and rename it to the output file once complete, so readers never see a partial file")
 	flag.BoolP(FlagOutputOverwrite, "o", false, "overwrite output if it already exists")
 	flag.String(FlagOutputPrivateKey, "", "Use the provided private key to connect to the output.")
 	flag.String(FlagOutputPassword, "", "Use the provided password to connect to the output.")
+	flag.String(FlagOutputPrivateKeyPassphrase, "", "passphrase of the encrypted private key used to connect to the output, if not set then the passphrase is read from the terminal when required")
+	flag.String(FlagOutputHostKeyFingerprint, "", "SHA256 or MD5 fingerprint of the host key of the output SSH server, if set then the known_hosts files are not used for the output")
+	flag.StringSlice(FlagOutputJumpHost, []string{}, "jump hosts used to reach the output SSH server, each as [user[:password]@]host[:port], overrides the ProxyJump in the ssh config, set to \"none\" to connect directly")
+
+	flag.Bool(FlagResume, false, "resume a transfer by reading the input from the current size of the output file, restarting if the ETag or Last-Modified date of the input has changed, inputs without either, such as files read over FTP, SFTP, or SSH, are not checked for changes")
+
+	initRetryFlags(flag)
 
 	flag.IntP(
 		FlagSplitLines,
@@ -46,6 +65,53 @@ func InitFlags(flag *pflag.FlagSet) {
 		fmt.Sprintf("split output by a number of lines, replaces %q in output uri with file number starting with 1.", NumberReplacementCharacter),
 	)
 
+	initSSHFlags(flag)
+
 	flag.Bool(FlagVersion, false, "show version")
 	flag.BoolP(FlagVerbose, "v", false, "verbose output")
 }
+
+// initStorageFlags initializes the flags for the credentials and endpoints of AWS S3, Azure Blob Storage, and Google Cloud Storage.
+func initStorageFlags(flag *pflag.FlagSet) {
+	flag.String(FlagAWSProfile, "", "AWS Profile in the shared config and credentials files")
+	flag.String(FlagAWSDefaultRegion, "", "AWS Default Region")
+	flag.StringP(FlagAWSRegion, "", "", "AWS Region (overrides default region)")
+	flag.StringP(FlagAWSAccessKeyID, "", "", "AWS Access Key ID")
+	flag.StringP(FlagAWSSecretAccessKey, "", "", "AWS Secret Access Key")
+	flag.StringP(FlagAWSSessionToken, "", "", "AWS Session Token")
+	flag.String(FlagAWSRoleARN, "", "ARN of an AWS IAM role to assume")
+	flag.String(FlagAWSRoleSessionName, "", "session name used when assuming the AWS IAM role")
+	flag.String(FlagAWSExternalID, "", "external ID used when assuming the AWS IAM role")
+	flag.String(FlagAWSMFASerial, "", "serial number or ARN of the MFA device used when assuming the AWS IAM role")
+	flag.String(FlagAWSMFAToken, "", "MFA token code used when assuming an AWS IAM role, if not set then the code is read from stdin when required")
+	flag.String(FlagAWSEndpointURL, "", "endpoint url of a S3-compatible service, such as MinIO, Ceph RGW, or LocalStack")
+	flag.Bool(FlagAWSS3UsePathStyle, false, "address S3 buckets in the path of the url rather than the host name, as required by most S3-compatible services")
+	flag.Bool(FlagAWSDisableSSL, false, "use http rather than https when connecting to AWS S3")
+	flag.Bool(FlagAWSS3RequesterPays, false, "confirm that you pay for reading from a requester pays bucket on AWS S3")
+
+	flag.String(FlagAzureStorageAccount, "", "name of the Azure storage account")
+	flag.String(FlagAzureStorageKey, "", "shared key of the Azure storage account")
+	flag.String(FlagAzureStorageSASToken, "", "shared access signature for Azure Blob Storage")
+	flag.String(FlagAzureStorageConnectionString, "", "connection string of the Azure storage account")
+	flag.String(FlagAzureStorageEndpoint, "", "endpoint of the Azure Blob Storage service, such as for a local Azurite emulator")
+
+	flag.String(FlagGCSCredentials, "", "path to a Google service account or application default credentials JSON file")
+	flag.String(FlagGCSEndpoint, "", "endpoint of the Google Cloud Storage JSON API, such as for a local fake GCS server")
+}
+
+// initRetryFlags initializes the flags for the retry policy.
+func initRetryFlags(flag *pflag.FlagSet) {
+	flag.Int(FlagRetryAttempts, retry.DefaultAttempts, "maximum number of attempts when connecting to or reading from a remote resource, including the first attempt")
+	flag.Duration(FlagRetryBaseDelay, retry.DefaultBaseDelay, "delay before the first retry, doubled for every following retry")
+	flag.Duration(FlagRetryMaxDelay, retry.DefaultMaxDelay, "maximum delay between retries")
+	flag.Float64(FlagRetryJitter, retry.DefaultJitter, "fraction of the delay between retries that is randomized, between 0 and 1")
+}
+
+// initSSHFlags initializes the flags for connecting to SSH servers that are shared by the input and output.
+func initSSHFlags(flag *pflag.FlagSet) {
+	flag.String(FlagSSHConfig, "~/.ssh/config", "path to the ssh config file used to resolve the hosts in SFTP uris, set to \"none\" to not read a ssh config file")
+	flag.StringSlice(FlagSSHKnownHosts, []string{}, "paths to known_hosts files used to verify the host keys of SSH servers, defaults to ~/.ssh/known_hosts")
+	flag.Bool(FlagSSHAcceptNewHostKeys, false, "accept the host keys of SSH servers that are not in the known_hosts files and add them to the first known_hosts file, changed host keys are still rejected")
+	flag.String(FlagSSHAuthSock, "", "path to the socket of a SSH agent used to authenticate with SSH servers, defaults to the SSH_AUTH_SOCK environment variable")
+	flag.Bool(FlagInsecureIgnoreHostKey, false, "do not verify the host keys of SSH servers, which allows man-in-the-middle attacks")
+}
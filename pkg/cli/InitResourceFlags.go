@@ -0,0 +1,28 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package cli
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// InitResourceFlags initializes the flags for subcommands that act on resources by uri, such as stat,
+// which include the credentials used to connect to the resource, but not the input and output flags.
+func InitResourceFlags(flag *pflag.FlagSet) {
+	initStorageFlags(flag)
+
+	flag.String(FlagPrivateKey, "", "private key used to connect to the SSH server")
+	flag.String(FlagPassword, "", "password used to connect to the SSH server")
+	flag.String(FlagPrivateKeyPassphrase, "", "passphrase of the encrypted private key used to connect to the SSH server, if not set then the passphrase is read from the terminal when required")
+	flag.String(FlagHostKeyFingerprint, "", "SHA256 or MD5 fingerprint of the host key of the SSH server, if set then the known_hosts files are not used")
+	flag.StringSlice(FlagJumpHost, []string{}, "jump hosts used to reach the SSH server, each as [user[:password]@]host[:port], overrides the ProxyJump in the ssh config, set to \"none\" to connect directly")
+
+	initRetryFlags(flag)
+
+	initSSHFlags(flag)
+}
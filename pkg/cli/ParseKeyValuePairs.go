@@ -0,0 +1,30 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseKeyValuePairs parses a slice of "key=value" pairs into a map.
+// The value may be blank, but the key must not be.
+func ParseKeyValuePairs(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		i := strings.Index(pair, "=")
+		if i < 1 {
+			return nil, fmt.Errorf("invalid key-value pair %q: must be formatted as key=value", pair)
+		}
+		m[pair[0:i]] = pair[i+1:]
+	}
+	return m, nil
+}
@@ -0,0 +1,30 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeyValuePairs(t *testing.T) {
+	m, err := ParseKeyValuePairs([]string{"a=1", "b=", "c=x=y"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "", "c": "x=y"}, m)
+
+	m, err = ParseKeyValuePairs([]string{})
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+
+	_, err = ParseKeyValuePairs([]string{"=1"})
+	assert.Error(t, err)
+
+	_, err = ParseKeyValuePairs([]string{"a"})
+	assert.Error(t, err)
+}
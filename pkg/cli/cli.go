@@ -8,32 +8,90 @@
 package cli
 
 const (
-	FlagAWSProfile         = "aws-profile"
-	FlagAWSDefaultRegion   = "aws-default-region"
-	FlagAWSRegion          = "aws-region"
-	FlagAWSAccessKeyID     = "aws-access-key-id"
-	FlagAWSSecretAccessKey = "aws-secret-access-key"
-	FlagAWSSessionToken    = "aws-session-token"
-	FlagInputCompression   = "input-compression"
-	FlagInputDictionary    = "input-dictionary"
-	FlagInputBufferSize    = "input-buffer-size"
-	FlagInputPrivateKey    = "input-private-key"
-	FlagInputPassword      = "input-password"
-	FlagOutputACL          = "output-acl"
-	FlagOutputCompression  = "output-compression"
-	FlagOutputBufferSize   = "output-buffer-size"
-	FlagOutputAppend       = "output-append"
-	FlagOutputMkdirs       = "output-mkdirs"
-	FlagOutputMode         = "output-mode"
-	FlagOutputOverwrite    = "output-overwrite"
-	FlagOutputDictionary   = "output-dictionary"
-	FlagOutputPrivateKey   = "output-private-key"
-	FlagOutputPassword     = "output-password"
-	FlagSplitLines         = "split-lines"
-	FlagVersion            = "version"
-	FlagVerbose            = "verbose"
+	FlagAWSProfile                   = "aws-profile"
+	FlagAWSDefaultRegion             = "aws-default-region"
+	FlagAWSRegion                    = "aws-region"
+	FlagAWSAccessKeyID               = "aws-access-key-id"
+	FlagAWSSecretAccessKey           = "aws-secret-access-key"
+	FlagAWSSessionToken              = "aws-session-token"
+	FlagAWSRoleARN                   = "aws-role-arn"
+	FlagAWSRoleSessionName           = "aws-role-session-name"
+	FlagAWSExternalID                = "aws-external-id"
+	FlagAWSMFASerial                 = "aws-mfa-serial"
+	FlagAWSMFAToken                  = "aws-mfa-token"
+	FlagAWSEndpointURL               = "aws-endpoint-url"
+	FlagAWSS3UsePathStyle            = "aws-s3-use-path-style"
+	FlagAWSDisableSSL                = "aws-disable-ssl"
+	FlagAWSS3RequesterPays           = "aws-s3-requester-pays"
+	FlagAzureStorageAccount          = "azure-storage-account"
+	FlagAzureStorageKey              = "azure-storage-key"
+	FlagAzureStorageSASToken         = "azure-storage-sas-token"
+	FlagAzureStorageConnectionString = "azure-storage-connection-string"
+	FlagAzureStorageEndpoint         = "azure-storage-endpoint"
+	FlagDryRun                       = "dry-run"
+	FlagGCSCredentials               = "gcs-credentials"
+	FlagGCSEndpoint                  = "gcs-endpoint"
+	FlagHostKeyFingerprint           = "host-key-fingerprint"
+	FlagInputCompression             = "input-compression"
+	FlagInputDictionary              = "input-dictionary"
+	FlagInputBufferSize              = "input-buffer-size"
+	FlagInputPrivateKey              = "input-private-key"
+	FlagInputPassword                = "input-password"
+	FlagInputPrivateKeyPassphrase    = "input-private-key-passphrase"
+	FlagInputHostKeyFingerprint      = "input-host-key-fingerprint"
+	FlagInputJumpHost                = "input-jump-host"
+	FlagInputSeparator               = "input-separator"
+	FlagInsecureIgnoreHostKey        = "insecure-ignore-host-key"
+	FlagJSON                         = "json"
+	FlagJumpHost                     = "jump-host"
+	FlagLong                         = "long"
+	FlagMaxDepth                     = "max-depth"
+	FlagMkdirs                       = "mkdirs"
+	FlagOutputACL                    = "output-acl"
+	FlagOutputCacheControl           = "output-cache-control"
+	FlagOutputContentEncoding        = "output-content-encoding"
+	FlagOutputContentType            = "output-content-type"
+	FlagOutputMetadata               = "output-metadata"
+	FlagOutputSSE                    = "output-sse"
+	FlagOutputSSECustomerKey         = "output-sse-customer-key"
+	FlagOutputSSEKMSKeyID            = "output-sse-kms-key-id"
+	FlagOutputStorageClass           = "output-storage-class"
+	FlagOutputTags                   = "output-tags"
+	FlagOutputCompression            = "output-compression"
+	FlagOutputBufferSize             = "output-buffer-size"
+	FlagOutputAppend                 = "output-append"
+	FlagOutputAtomic                 = "output-atomic"
+	FlagOutputMkdirs                 = "output-mkdirs"
+	FlagOutputDirMode                = "output-dir-mode"
+	FlagOutputMode                   = "output-mode"
+	FlagOutputOverwrite              = "output-overwrite"
+	FlagOutputDictionary             = "output-dictionary"
+	FlagOutputPrivateKey             = "output-private-key"
+	FlagOutputPassword               = "output-password"
+	FlagOutputPrivateKeyPassphrase   = "output-private-key-passphrase"
+	FlagOutputHostKeyFingerprint     = "output-host-key-fingerprint"
+	FlagOutputJumpHost               = "output-jump-host"
+	FlagOverwrite                    = "overwrite"
+	FlagPassword                     = "password"
+	FlagPrivateKey                   = "private-key"
+	FlagPrivateKeyPassphrase         = "private-key-passphrase"
+	FlagRecursive                    = "recursive"
+	FlagResume                       = "resume"
+	FlagRetryAttempts                = "retry-attempts"
+	FlagRetryBaseDelay               = "retry-base-delay"
+	FlagRetryMaxDelay                = "retry-max-delay"
+	FlagRetryJitter                  = "retry-jitter"
+	FlagSplitLines                   = "split-lines"
+	FlagSSHConfig                    = "ssh-config"
+	FlagSSHKnownHosts                = "ssh-known-hosts"
+	FlagSSHAcceptNewHostKeys         = "ssh-accept-new-host-keys"
+	FlagSSHAuthSock                  = "ssh-auth-sock"
+	FlagVersion                      = "version"
+	FlagVerbose                      = "verbose"
 
 	DefaultBufferSize = 4096
 
+	CompressionAuto = "auto" // choose the input compression from the content encoding or content type of the input
+
 	NumberReplacementCharacter = "#"
 )
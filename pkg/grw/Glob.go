@@ -0,0 +1,116 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdos "os"
+	"path"
+	"strings"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+)
+
+// Glob returns a lister of the resources that match the pattern, using the clients and credentials in the options, if any.
+// The options may be nil.  The caller must close the lister when finished.
+//
+// The pattern is a uri whose path contains the wildcards supported by path.Match,
+// such as "s3://bucket/logs/2026-10-*/part-*.gz" or "sftp://host/in/*.csv".
+// Each element of the path is matched separately, so a wildcard never matches a "/".
+// The resources are found by listing the directories or prefixes that match the leading elements of the pattern,
+// starting from the longest path without wildcards, so only the schemes supported by List can be globbed.
+// The host, bucket, or container of a remote uri cannot contain wildcards.
+// The path of each entry is relative to the longest path without wildcards.
+// Directories and prefixes that match the pattern are returned as well as files.
+//
+// If the pattern has no wildcards, then the lister returns the resource at the uri, if it exists.
+// If no resources match the pattern, then the lister returns no entries.
+func Glob(ctx context.Context, pattern string, options *ResourceOptions) (*Lister, error) {
+	if options == nil {
+		options = &ResourceOptions{}
+	}
+
+	uri := stripQuery(pattern)
+	query := pattern[len(uri):]
+
+	scheme, fullpath := splitter.SplitURI(uri)
+
+	if _, ok := azblob.ParseBlobURL(pattern); ok {
+		scheme = schemes.SchemeAzureBlob
+	}
+
+	if !IsGlob(pattern) {
+		info, err := Stat(ctx, pattern, options)
+		if err != nil {
+			if errors.Is(err, stdos.ErrNotExist) {
+				return &Lister{ctx: ctx}, nil
+			}
+			return nil, err
+		}
+		return &Lister{ctx: ctx, stack: []*listFrame{newInfoFrame(pattern, info)}}, nil
+	}
+
+	err := checkList(pattern, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := strings.Split(strings.TrimSuffix(fullpath, "/"), "/")
+	i := 0
+	for i < len(elements) && !strings.ContainsAny(elements[i], "*?[") {
+		i++
+	}
+	if i == 0 && scheme != schemes.SchemeFile && scheme != "" {
+		return nil, fmt.Errorf("error globbing uri %q: the host, bucket, or container cannot contain wildcards", pattern)
+	}
+	patterns := elements[i:]
+	for _, p := range patterns {
+		if _, errMatch := path.Match(p, ""); errMatch != nil {
+			return nil, fmt.Errorf("error globbing uri %q: %w", pattern, errMatch)
+		}
+	}
+
+	base := strings.Join(elements[:i], "/")
+	if len(base) == 0 {
+		if strings.HasPrefix(fullpath, "/") {
+			base = "/"
+		} else {
+			base = "."
+		}
+	}
+	baseURI := strings.TrimSuffix(uri, fullpath) + base + query
+
+	info, err := Stat(ctx, baseURI, options)
+	if err != nil {
+		if errors.Is(err, stdos.ErrNotExist) {
+			return &Lister{ctx: ctx}, nil
+		}
+		return nil, err
+	}
+	if !info.IsDir() {
+		return &Lister{ctx: ctx}, nil
+	}
+
+	lister, err := newLister(ctx, baseURI, scheme, base, info, &ListOptions{
+		ResourceOptions: *options,
+		Recursive:       true,
+		MaxDepth:        len(patterns),
+	})
+	if err != nil {
+		return nil, err
+	}
+	lister.match = func(name string, depth int) (bool, bool) {
+		matched, _ := path.Match(patterns[depth-1], name)
+		return matched && depth == len(patterns), matched && depth < len(patterns)
+	}
+	return lister, nil
+}
@@ -0,0 +1,145 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"fmt"
+
+	pkgalg "github.com/spatialcurrent/go-reader-writer/pkg/alg"
+	"github.com/spatialcurrent/go-reader-writer/pkg/io"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/stat"
+)
+
+// GlobReader iterates over readers for the resources that match a pattern, as returned by ReadFromGlob.
+// Each call to Next closes the previous reader and opens a reader for the next matching resource.
+// The readers are owned by the GlobReader, so callers must not close them.
+//
+//	matches, err := grw.ReadFromGlob(ctx, &grw.ReadFromResourceInput{URI: "s3://bucket/logs/*.gz", S3Client: client})
+//	if err != nil {
+//		return err
+//	}
+//	defer matches.Close()
+//	for matches.Next() {
+//		_, err := io.Copy(w, matches.Reader())
+//		if err != nil {
+//			return err
+//		}
+//	}
+//	if err := matches.Err(); err != nil {
+//		return err
+//	}
+type GlobReader struct {
+	input  *ReadFromResourceInput // input used to open each matching resource
+	lister *Lister
+	pool   *sftp2.Pool // pool of SFTP sessions created for the matches, if any
+	entry  *stat.ResourceInfo
+	output *ReadFromResourceOutput
+	err    error
+}
+
+// Next closes the current reader and opens a reader for the next matching resource.
+// Directories and prefixes that match the pattern are skipped.
+// Returns false when there are no more matches or an error occurs.
+func (r *GlobReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	err := r.closeReader()
+	if err != nil {
+		r.err = err
+		return false
+	}
+	for r.lister.Next() {
+		entry := r.lister.Entry()
+		if entry.IsDir() {
+			continue
+		}
+		input := *r.input
+		input.URI = entry.URI()
+		if len(input.Alg) == 0 && input.DetectAlg {
+			if alg := pkgalg.FromExtension(entry.Name()); alg != pkgalg.AlgorithmNone {
+				input.Alg = alg
+			}
+		}
+		output, errRead := ReadFromResource(&input)
+		if errRead != nil {
+			r.err = fmt.Errorf("error opening resource at uri %q: %w", input.URI, errRead)
+			return false
+		}
+		r.entry, r.output = entry, output
+		return true
+	}
+	r.err = r.lister.Err()
+	return false
+}
+
+// closeReader closes the current reader, if any.
+func (r *GlobReader) closeReader() error {
+	if r.output == nil {
+		return nil
+	}
+	uri := r.entry.URI()
+	err := r.output.Reader.Close()
+	r.entry, r.output = nil, nil
+	if err != nil {
+		return fmt.Errorf("error closing resource at uri %q: %w", uri, err)
+	}
+	return nil
+}
+
+// Entry returns information about the current matching resource, or nil if Next has not been called or returned false.
+func (r *GlobReader) Entry() *stat.ResourceInfo {
+	return r.entry
+}
+
+// Reader returns the reader for the current matching resource, or nil if Next has not been called or returned false.
+// The reader is decompressed with the algorithm in the input.
+// If no algorithm is set and DetectAlg is true, then the algorithm is chosen separately for each resource,
+// first from the extension of the name of the resource, such as ".gz", and then from the metadata of the resource.
+func (r *GlobReader) Reader() io.ReadCloser {
+	if r.output == nil {
+		return nil
+	}
+	return r.output.Reader
+}
+
+// Metadata returns the metadata of the current matching resource, which is nil if not known.
+func (r *GlobReader) Metadata() *Metadata {
+	if r.output == nil {
+		return nil
+	}
+	return r.output.Metadata
+}
+
+// Err returns the first error that occurred while listing or opening the matching resources, if any.
+func (r *GlobReader) Err() error {
+	return r.err
+}
+
+// Close closes the current reader and releases the connections used to list and read the matching resources.
+// Close can be called more than once.
+func (r *GlobReader) Close() error {
+	errCloseReader := r.closeReader()
+	errCloseLister := r.lister.Close()
+	var errClosePool error
+	if r.pool != nil {
+		errClosePool = r.pool.Close()
+		r.pool = nil
+	}
+	if errCloseReader != nil {
+		return errCloseReader
+	}
+	if errCloseLister != nil {
+		return fmt.Errorf("error closing lister: %w", errCloseLister)
+	}
+	if errClosePool != nil {
+		return fmt.Errorf("error closing SFTP pool: %w", errClosePool)
+	}
+	return nil
+}
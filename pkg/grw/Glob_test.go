@@ -0,0 +1,82 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsGlob(t *testing.T) {
+	testCases := map[string]bool{
+		"-":                       false,
+		"/tmp/a.csv":              false,
+		"/tmp/*.csv":              true,
+		"s3://bucket/logs/part-?": true,
+		"sftp://host/in/[ab].csv": true,
+		"https://example.com/a?b": false,
+		"https://example.com/*.csv?X-Amz-Signature=abc": true,
+		"s3://bucket/a.csv?versionId=abc":               false,
+		"ssh+cmd://host?cmd=cat+a.csv":                  false,
+		"/tmp/a?.csv":                                   true,
+		"https://myaccount.blob.core.windows.net/container/a.csv?sv=2020-08-04&sig=abc": false,
+		"https://myaccount.blob.core.windows.net/container/*.csv?sv=2020-08-04&sig=abc": true,
+	}
+	for uri, expected := range testCases {
+		assert.Equal(t, expected, IsGlob(uri), uri)
+	}
+}
+
+func TestGlob(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "in", "b"), 0750))
+	for _, name := range []string{"a.csv", "b.csv", "c.txt", "b/d.csv"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "in", filepath.FromSlash(name)), []byte("hello world"), 0640))
+	}
+
+	glob := func(pattern string) []string {
+		lister, err := Glob(context.Background(), pattern, nil)
+		require.NoError(t, err)
+		defer lister.Close()
+		paths := []string{}
+		for lister.Next() {
+			paths = append(paths, lister.Entry().URI())
+		}
+		require.NoError(t, lister.Err())
+		return paths
+	}
+
+	assert.Equal(t, []string{dir + "/in/a.csv", dir + "/in/b.csv"}, glob(dir+"/in/*.csv"))
+	assert.Equal(t, []string{dir + "/in/b", dir + "/in/b.csv"}, glob(dir+"/in/b*"))
+	assert.Equal(t, []string{dir + "/in/b/d.csv"}, glob(dir+"/*/?/*.csv"))
+	assert.Equal(t, []string{dir + "/in/c.txt"}, glob(dir+"/in/c.txt"))
+	assert.Empty(t, glob(dir+"/in/*.json"))
+	assert.Empty(t, glob(dir+"/missing/*.csv"))
+	assert.Empty(t, glob(dir+"/in/a.csv/*"))
+}
+
+func TestGlobInvalid(t *testing.T) {
+	_, err := Glob(context.Background(), "s3://bucket-*/a.csv", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot contain wildcards")
+
+	_, err = Glob(context.Background(), "/tmp/[a.csv", nil)
+	require.Error(t, err)
+
+	_, err = Glob(context.Background(), "https://example.com/*.csv", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be listed")
+
+	_, err = ReadFromGlob(context.Background(), &ReadFromResourceInput{URI: "/tmp/*.csv", Offset: 10})
+	require.Error(t, err)
+}
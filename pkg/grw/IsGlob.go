@@ -0,0 +1,43 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"strings"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+)
+
+// IsGlob returns true if the path of the uri contains any of the wildcards "*", "?", or "[" supported by Glob.
+// The query of a http, https, or ssh+cmd uri is ignored, such as the signature of a presigned url,
+// the shared access signature of a blob on Azure Blob Storage, or the command run on a SSH server.
+// The versionId query parameter of a s3 uri is ignored as well.
+func IsGlob(uri string) bool {
+	if uri == "-" || uri == "stdin" {
+		return false
+	}
+	return strings.ContainsAny(stripQuery(uri), "*?[")
+}
+
+// stripQuery returns the uri without its query if the uri is a http, https, or ssh+cmd uri,
+// or a s3 uri with a version ID.
+func stripQuery(uri string) string {
+	scheme, p := splitter.SplitURI(uri)
+	switch scheme {
+	case schemes.SchemeHTTP, schemes.SchemeHTTPS, schemes.SchemeSSHCommand:
+		if i := strings.Index(uri, "?"); i != -1 {
+			return uri[:i]
+		}
+	case schemes.SchemeS3:
+		if bucket, key, versionID, err := splitS3Path(p); err == nil && len(versionID) > 0 {
+			return scheme + "://" + bucket + "/" + key
+		}
+	}
+	return uri
+}
@@ -0,0 +1,460 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdos "os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	homedir "github.com/mitchellh/go-homedir"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ftp"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/webdav"
+	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+	"github.com/spatialcurrent/go-reader-writer/pkg/stat"
+)
+
+// List returns a lister of the entries in the directory or prefix at the uri,
+// using the clients and credentials in the options, if any.  The options may be nil.
+// If the uri is not a directory or prefix, then the lister returns the resource itself.
+// The caller must close the lister when finished.
+//
+// Local directories are listed with os.ReadDir, directories on SFTP servers with the SFTP readdir request,
+// directories on FTP servers with MLSD or LIST, and WebDAV collections with a PROPFIND request.
+// Objects in AWS S3, Google Cloud Storage, and Azure Blob Storage are listed a page at a time with "/" as the delimiter,
+// so that the objects under a common prefix are returned as a single prefix entry.
+// The entries in a page are sorted by name.
+//
+// If the options are recursive, then the entries of subdirectories and prefixes are listed as well,
+// up to the max depth, if set.  Symbolic links to directories are not followed.
+//
+// HTTP resources and SSH commands cannot be listed.
+// If the resource does not exist, then the error wraps os.ErrNotExist.
+func List(ctx context.Context, uri string, options *ListOptions) (*Lister, error) {
+	if options == nil {
+		options = &ListOptions{}
+	}
+
+	if uri == "-" || uri == "stdin" {
+		return nil, errors.New("error listing stdin: stdin cannot be listed")
+	}
+
+	scheme, fullpath := splitter.SplitURI(uri)
+
+	if _, ok := azblob.ParseBlobURL(uri); ok {
+		scheme = schemes.SchemeAzureBlob
+	}
+
+	err := checkList(uri, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := Stat(ctx, uri, &options.ResourceOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLister(ctx, uri, scheme, fullpath, info, options)
+}
+
+// newLister returns a lister of the entries in the directory or prefix at the uri, which is described by the info.
+func newLister(ctx context.Context, uri string, scheme string, fullpath string, info *stat.ResourceInfo, options *ListOptions) (*Lister, error) {
+	lister := &Lister{
+		ctx:       ctx,
+		recursive: options.Recursive,
+		maxDepth:  options.MaxDepth,
+	}
+
+	if !info.IsDir() {
+		lister.stack = []*listFrame{newInfoFrame(uri, info)}
+		return lister, nil
+	}
+
+	var err error
+	switch scheme {
+	case schemes.SchemeFile, "":
+		lister.open, err = listFiles(uri, fullpath)
+	case schemes.SchemeSFTP:
+		lister.open, lister.release, err = listSFTPFiles(ctx, uri, fullpath, &options.ResourceOptions)
+	case schemes.SchemeFTP:
+		lister.open, lister.release, err = listFTPFiles(ctx, uri)
+	case schemes.SchemeWebDAV, schemes.SchemeWebDAVS:
+		lister.open = listWebDAVResources(uri, &options.ResourceOptions)
+	case schemes.SchemeS3:
+		lister.open, err = listS3Objects(uri, fullpath, &options.ResourceOptions)
+	case schemes.SchemeGCS:
+		lister.open, err = listGCSObjects(uri, fullpath, &options.ResourceOptions)
+	case schemes.SchemeAzureBlob:
+		lister.open, err = listAzureBlobs(uri, &options.ResourceOptions)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing resource at uri %q: %w", uri, err)
+	}
+
+	lister.stack = []*listFrame{{uri: uri, depth: 1, next: lister.open("")}}
+	return lister, nil
+}
+
+// newInfoFrame returns a frame that lists only the resource at the uri, which is described by the info.
+func newInfoFrame(uri string, info *stat.ResourceInfo) *listFrame {
+	return &listFrame{
+		uri:   uri,
+		depth: 1,
+		next: func(ctx context.Context) ([]*stat.ResourceInfo, bool, error) {
+			return []*stat.ResourceInfo{info}, false, nil
+		},
+	}
+}
+
+// checkList returns an error if the resource at the uri cannot be listed, because of the scheme of the uri.
+func checkList(uri string, scheme string) error {
+	switch scheme {
+	case schemes.SchemeFile, "", schemes.SchemeSFTP, schemes.SchemeFTP, schemes.SchemeWebDAV, schemes.SchemeWebDAVS,
+		schemes.SchemeS3, schemes.SchemeGCS, schemes.SchemeAzureBlob:
+		return nil
+	case schemes.SchemeHTTP, schemes.SchemeHTTPS, schemes.SchemeSSH, schemes.SchemeSSHCommand:
+		return fmt.Errorf("error listing resource at uri %q: resources with scheme %q cannot be listed", uri, scheme)
+	}
+	return &schemes.ErrUnknownScheme{Scheme: scheme}
+}
+
+// joinURI returns the uri of the path relative to the directory at the uri.
+func joinURI(uri string, p string) string {
+	if len(p) == 0 {
+		return uri
+	}
+	return strings.TrimSuffix(uri, "/") + "/" + p
+}
+
+// objectURI returns the uri of an object in a bucket or container,
+// using the endpoint and shared access signature of the uri if the uri is a https url of a blob on Azure Blob Storage.
+func objectURI(uri string, scheme string, bucket string, key string) string {
+	if u, ok := azblob.ParseBlobURL(uri); ok {
+		if len(u.SASToken) > 0 {
+			return u.Endpoint + "/" + bucket + "/" + key + "?" + u.SASToken
+		}
+		return u.Endpoint + "/" + bucket + "/" + key
+	}
+	return scheme + "://" + bucket + "/" + key
+}
+
+// sortEntries sorts the entries by name.
+func sortEntries(entries []*stat.ResourceInfo) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+}
+
+// listFiles returns a function that returns the pager for a local directory relative to the directory at the path.
+func listFiles(uri string, p string) (func(p string) listPager, error) {
+	root, err := homedir.Expand(p)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding file path %q: %w", p, err)
+	}
+	return func(p string) listPager {
+		return func(ctx context.Context) ([]*stat.ResourceInfo, bool, error) {
+			dirEntries, errRead := stdos.ReadDir(filepath.Join(root, filepath.FromSlash(p)))
+			if errRead != nil {
+				return nil, false, errRead
+			}
+			entries := make([]*stat.ResourceInfo, 0, len(dirEntries))
+			for _, dirEntry := range dirEntries {
+				fi, errInfo := dirEntry.Info()
+				if errInfo != nil {
+					if errors.Is(errInfo, stdos.ErrNotExist) {
+						continue // removed since the directory was read
+					}
+					return nil, false, errInfo
+				}
+				entries = append(entries, stat.NewResourceInfoFromFileInfo(joinURI(uri, path.Join(p, fi.Name())), fi))
+			}
+			return entries, false, nil
+		}
+	}, nil
+}
+
+// listSFTPFiles returns a function that returns the pager for a directory on a SFTP server
+// relative to the directory at the uri, and a function that releases the SFTP client.
+func listSFTPFiles(ctx context.Context, uri string, fullpath string, options *ResourceOptions) (func(p string) listPager, func() error, error) {
+	client, release, err := options.sftpClient(ctx, uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := remotePath(fullpath)
+	return func(p string) listPager {
+		return func(ctx context.Context) ([]*stat.ResourceInfo, bool, error) {
+			fileInfos, errRead := client.ReadDir(path.Join(root, p))
+			if errRead != nil {
+				return nil, false, errRead
+			}
+			entries := make([]*stat.ResourceInfo, 0, len(fileInfos))
+			for _, fi := range fileInfos {
+				if fi.Name() == "." || fi.Name() == ".." {
+					continue
+				}
+				entries = append(entries, stat.NewResourceInfoFromFileInfo(joinURI(uri, path.Join(p, fi.Name())), fi))
+			}
+			sortEntries(entries)
+			return entries, false, nil
+		}
+	}, release, nil
+}
+
+// listFTPFiles returns a function that returns the pager for a directory on a FTP server
+// relative to the directory at the uri, and a function that closes the connection.
+func listFTPFiles(ctx context.Context, uri string) (func(p string) listPager, func() error, error) {
+	conn, root, err := ftp.Dial(ctx, uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	return func(p string) listPager {
+		return func(ctx context.Context) ([]*stat.ResourceInfo, bool, error) {
+			fileInfos, errRead := ftp.ReadDir(conn, path.Join(root, p))
+			if errRead != nil {
+				return nil, false, errRead
+			}
+			entries := make([]*stat.ResourceInfo, 0, len(fileInfos))
+			for _, fi := range fileInfos {
+				entries = append(entries, stat.NewResourceInfoFromFileInfo(joinURI(uri, path.Join(p, fi.Name())), fi))
+			}
+			sortEntries(entries)
+			return entries, false, nil
+		}
+	}, conn.Quit, nil
+}
+
+// listWebDAVResources returns a function that returns the pager for a WebDAV collection relative to the collection at the uri.
+func listWebDAVResources(uri string, options *ResourceOptions) func(p string) listPager {
+	return func(p string) listPager {
+		return func(ctx context.Context) ([]*stat.ResourceInfo, bool, error) {
+			var fileInfos []*webdav.FileInfo
+			err := options.Retry.Do(ctx, func() error {
+				fis, err := webdav.ReadDir(ctx, joinURI(uri, p))
+				fileInfos = fis
+				return err
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			entries := make([]*stat.ResourceInfo, 0, len(fileInfos))
+			for _, fi := range fileInfos {
+				entries = append(entries, stat.NewResourceInfo(&stat.NewResourceInfoInput{
+					URI:         joinURI(uri, path.Join(p, fi.Name())),
+					Name:        fi.Name(),
+					Size:        fi.Size(),
+					ModTime:     fi.ModTime(),
+					Mode:        fi.Mode(),
+					ContentType: fi.ContentType(),
+					ETag:        fi.ETag(),
+					Dir:         fi.IsDir(),
+				}))
+			}
+			sortEntries(entries)
+			return entries, false, nil
+		}
+	}
+}
+
+// listPrefix returns the prefix of the keys of the objects in the directory at the path relative to the key.
+func listPrefix(key string, p string) string {
+	prefix := strings.TrimSuffix(key, "/")
+	if len(p) > 0 {
+		prefix = strings.TrimPrefix(prefix+"/"+p, "/")
+	}
+	if len(prefix) == 0 {
+		return ""
+	}
+	return prefix + "/"
+}
+
+// listS3Objects returns a function that returns the pager for the objects on AWS S3 under a prefix
+// relative to the prefix at the uri.
+func listS3Objects(uri string, fullpath string, options *ResourceOptions) (func(p string) listPager, error) {
+	if options.S3Client == nil {
+		return nil, errors.New("missing AWS S3 client")
+	}
+	bucket, key := fullpath, ""
+	if strings.Contains(fullpath, "/") {
+		b, k, _, err := splitS3Path(fullpath)
+		if err != nil {
+			return nil, err
+		}
+		bucket, key = b, k
+	}
+	return func(p string) listPager {
+		prefix := listPrefix(key, p)
+		var continuationToken *string
+		return func(ctx context.Context) ([]*stat.ResourceInfo, bool, error) {
+			input := &s3.ListObjectsV2Input{
+				Bucket:            aws.String(bucket),
+				Prefix:            aws.String(prefix),
+				Delimiter:         aws.String("/"),
+				ContinuationToken: continuationToken,
+			}
+			if options.RequestPayer {
+				input.RequestPayer = aws.String(s3.RequestPayerRequester)
+			}
+			output, err := options.S3Client.ListObjectsV2WithContext(ctx, input)
+			if err != nil {
+				return nil, false, err
+			}
+			entries := make([]*stat.ResourceInfo, 0, len(output.CommonPrefixes)+len(output.Contents))
+			for _, commonPrefix := range output.CommonPrefixes {
+				k := aws.StringValue(commonPrefix.Prefix)
+				entries = append(entries, stat.NewResourceInfo(&stat.NewResourceInfoInput{
+					URI:    objectURI(uri, schemes.SchemeS3, bucket, k),
+					Name:   baseName(k),
+					Prefix: true,
+				}))
+			}
+			for _, object := range output.Contents {
+				k := aws.StringValue(object.Key)
+				if k == prefix {
+					continue // the object that marks a folder
+				}
+				entries = append(entries, stat.NewResourceInfo(&stat.NewResourceInfoInput{
+					URI:     objectURI(uri, schemes.SchemeS3, bucket, k),
+					Name:    baseName(k),
+					Size:    aws.Int64Value(object.Size),
+					ModTime: aws.TimeValue(object.LastModified),
+					ETag:    aws.StringValue(object.ETag),
+				}))
+			}
+			sortEntries(entries)
+			continuationToken = output.NextContinuationToken
+			return entries, aws.BoolValue(output.IsTruncated) && continuationToken != nil, nil
+		}
+	}, nil
+}
+
+// listGCSObjects returns a function that returns the pager for the objects on Google Cloud Storage under a prefix
+// relative to the prefix at the uri.
+func listGCSObjects(uri string, fullpath string, options *ResourceOptions) (func(p string) listPager, error) {
+	bucket, object, err := gcs.SplitPath(fullpath)
+	if err != nil {
+		return nil, err
+	}
+	client := options.GCSClient
+	if client == nil {
+		client, err = gcs.NewClient(&gcs.NewClientInput{})
+		if err != nil {
+			return nil, fmt.Errorf("error creating Google Cloud Storage client: %w", err)
+		}
+	}
+	return func(p string) listPager {
+		prefix := listPrefix(object, p)
+		pageToken := ""
+		return func(ctx context.Context) ([]*stat.ResourceInfo, bool, error) {
+			var output *gcs.ListOutput
+			errDo := options.Retry.Do(ctx, func() error {
+				o, errList := gcs.List(ctx, &gcs.ListInput{
+					Client:    client,
+					Bucket:    bucket,
+					Prefix:    prefix,
+					Delimiter: "/",
+					PageToken: pageToken,
+				})
+				output = o
+				return errList
+			})
+			if errDo != nil {
+				return nil, false, errDo
+			}
+			entries := make([]*stat.ResourceInfo, 0, len(output.Prefixes)+len(output.Objects))
+			for _, name := range output.Prefixes {
+				entries = append(entries, stat.NewResourceInfo(&stat.NewResourceInfoInput{
+					URI:    objectURI(uri, schemes.SchemeGCS, bucket, name),
+					Name:   baseName(name),
+					Prefix: true,
+				}))
+			}
+			for _, attrs := range output.Objects {
+				if attrs.Name == prefix {
+					continue // the object that marks a folder
+				}
+				entries = append(entries, stat.NewResourceInfo(&stat.NewResourceInfoInput{
+					URI:         objectURI(uri, schemes.SchemeGCS, bucket, attrs.Name),
+					Name:        baseName(attrs.Name),
+					Size:        attrs.Size,
+					ModTime:     attrs.Updated,
+					ContentType: attrs.ContentType,
+					ETag:        attrs.ETag,
+				}))
+			}
+			sortEntries(entries)
+			pageToken = output.NextPageToken
+			return entries, len(pageToken) > 0, nil
+		}
+	}, nil
+}
+
+// listAzureBlobs returns a function that returns the pager for the blobs on Azure Blob Storage under a prefix
+// relative to the prefix at the uri.
+func listAzureBlobs(uri string, options *ResourceOptions) (func(p string) listPager, error) {
+	client, container, blob, err := azureBlobContainerClient(options.AzureBlobClient, uri)
+	if err != nil {
+		return nil, err
+	}
+	return func(p string) listPager {
+		prefix := listPrefix(blob, p)
+		marker := ""
+		return func(ctx context.Context) ([]*stat.ResourceInfo, bool, error) {
+			var output *azblob.ListOutput
+			errDo := options.Retry.Do(ctx, func() error {
+				o, errList := azblob.List(ctx, &azblob.ListInput{
+					Client:    client,
+					Container: container,
+					Prefix:    prefix,
+					Delimiter: "/",
+					Marker:    marker,
+				})
+				output = o
+				return errList
+			})
+			if errDo != nil {
+				return nil, false, errDo
+			}
+			entries := make([]*stat.ResourceInfo, 0, len(output.Prefixes)+len(output.Blobs))
+			for _, name := range output.Prefixes {
+				entries = append(entries, stat.NewResourceInfo(&stat.NewResourceInfoInput{
+					URI:    objectURI(uri, schemes.SchemeAzureBlob, container, name),
+					Name:   baseName(name),
+					Prefix: true,
+				}))
+			}
+			for _, properties := range output.Blobs {
+				if properties.Name == prefix {
+					continue // the blob that marks a folder
+				}
+				entries = append(entries, stat.NewResourceInfo(&stat.NewResourceInfoInput{
+					URI:         objectURI(uri, schemes.SchemeAzureBlob, container, properties.Name),
+					Name:        baseName(properties.Name),
+					Size:        properties.Size,
+					ModTime:     properties.LastModified,
+					ContentType: properties.ContentType,
+					ETag:        properties.ETag,
+				}))
+			}
+			sortEntries(entries)
+			marker = output.NextMarker
+			return entries, len(marker) > 0, nil
+		}
+	}, nil
+}
@@ -0,0 +1,15 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+// ListOptions contains the options for List, including the clients and credentials used to access the resources.
+type ListOptions struct {
+	ResourceOptions      // clients and credentials used to access the resources
+	Recursive       bool // list the entries in the subdirectories and prefixes of the listed directory
+	MaxDepth        int  // if recursive and greater than zero, the maximum depth of the entries, where 1 is the entries of the listed directory
+}
@@ -0,0 +1,90 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/grwtest"
+)
+
+func TestListS3Pages(t *testing.T) {
+	server := grwtest.NewS3Server()
+	defer server.Close()
+	require.NoError(t, server.CreateBucket("bucket"))
+
+	// more objects than fit in a single page
+	require.NoError(t, os.MkdirAll(server.Path("bucket/logs/z"), 0750))
+	for i := 0; i < 1001; i++ {
+		require.NoError(t, os.WriteFile(server.Path(fmt.Sprintf("bucket/logs/%04d.txt", i)), []byte("hello world"), 0640))
+	}
+	require.NoError(t, os.WriteFile(server.Path("bucket/logs/z/a.txt"), []byte("hello world"), 0640))
+
+	lister, err := List(context.Background(), "s3://bucket/logs/", &ListOptions{
+		ResourceOptions: ResourceOptions{S3Client: server.S3Client()},
+		Recursive:       true,
+	})
+	require.NoError(t, err)
+	defer lister.Close()
+
+	paths := []string{}
+	for lister.Next() {
+		paths = append(paths, lister.Path())
+	}
+	require.NoError(t, lister.Err())
+	require.Len(t, paths, 1003)
+	assert.Equal(t, "0000.txt", paths[0])
+	assert.Equal(t, "1000.txt", paths[1000])
+	assert.Equal(t, []string{"z", "z/a.txt"}, paths[1001:])
+}
+
+func TestListBucket(t *testing.T) {
+	server := grwtest.NewS3Server()
+	defer server.Close()
+	require.NoError(t, server.CreateBucket("bucket"))
+	require.NoError(t, os.WriteFile(server.Path("bucket/a.txt"), []byte("hello world"), 0640))
+
+	lister, err := List(context.Background(), "s3://bucket", &ListOptions{ResourceOptions: ResourceOptions{S3Client: server.S3Client()}})
+	require.NoError(t, err)
+	defer lister.Close()
+	require.True(t, lister.Next())
+	assert.Equal(t, "s3://bucket/a.txt", lister.Entry().URI())
+	assert.False(t, lister.Next())
+	require.NoError(t, lister.Err())
+}
+
+func TestListContext(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0750))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lister, err := List(ctx, dir, &ListOptions{Recursive: true})
+	require.NoError(t, err)
+	defer lister.Close()
+	require.True(t, lister.Next())
+	assert.Equal(t, "a", lister.Path())
+	cancel()
+	assert.False(t, lister.Next())
+	assert.ErrorIs(t, lister.Err(), context.Canceled)
+}
+
+func TestListUnsupported(t *testing.T) {
+	_, err := List(context.Background(), "https://example.com/a.txt", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be listed")
+
+	_, err = List(context.Background(), "-", nil)
+	require.Error(t, err)
+}
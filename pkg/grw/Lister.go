@@ -0,0 +1,139 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/stat"
+)
+
+// listPager returns the next page of entries in a directory and true if more pages remain.
+type listPager func(ctx context.Context) ([]*stat.ResourceInfo, bool, error)
+
+// listFrame is a directory that is being listed.
+type listFrame struct {
+	uri     string               // uri of the directory
+	path    string               // path of the directory relative to the listed uri, blank for the listed uri
+	depth   int                  // depth of the entries in the directory, where 1 is the entries of the listed directory
+	next    listPager            // returns the next page of entries
+	entries []*stat.ResourceInfo // entries in the current page that have not been returned yet
+	done    bool                 // true if no pages remain
+}
+
+// Lister iterates over the entries returned by List or Glob.
+// Entries are listed lazily, one page at a time, so a listing is not held in memory.
+// When recursive, the entries of a directory or prefix follow the entry for the directory or prefix itself.
+// Call Next to advance to the next entry, and Err to check for an error after Next returns false.
+// Close releases the connections used by the listing.
+//
+//	lister, err := grw.List(ctx, "sftp://example.com/data", &grw.ListOptions{Recursive: true})
+//	if err != nil {
+//		return err
+//	}
+//	defer lister.Close()
+//	for lister.Next() {
+//		fmt.Println(lister.Path(), lister.Entry().Size())
+//	}
+//	if err := lister.Err(); err != nil {
+//		return err
+//	}
+type Lister struct {
+	ctx       context.Context
+	open      func(p string) listPager                  // returns the pager for the directory at the path relative to the listed uri
+	release   func() error                              // releases the connections used by the listing, may be nil
+	match     func(name string, depth int) (bool, bool) // if not nil, returns whether an entry is returned and whether a directory is listed
+	recursive bool
+	maxDepth  int
+	stack     []*listFrame
+	entry     *stat.ResourceInfo
+	path      string
+	err       error
+	closed    bool
+}
+
+// Next advances the lister to the next entry, which is then available through Entry and Path.
+// Returns false when there are no more entries, an error occurs, or the lister is closed.
+func (l *Lister) Next() bool {
+	l.entry, l.path = nil, ""
+	if l.err != nil || l.closed {
+		return false
+	}
+	for len(l.stack) > 0 {
+		frame := l.stack[len(l.stack)-1]
+		if len(frame.entries) == 0 {
+			if frame.done {
+				l.stack = l.stack[:len(l.stack)-1]
+				continue
+			}
+			if err := l.ctx.Err(); err != nil {
+				l.err = err
+				return false
+			}
+			entries, more, err := frame.next(l.ctx)
+			if err != nil {
+				l.err = fmt.Errorf("error listing resource at uri %q: %w", frame.uri, err)
+				return false
+			}
+			frame.entries, frame.done = entries, !more
+			continue
+		}
+		l.entry, frame.entries = frame.entries[0], frame.entries[1:]
+		l.path = path.Join(frame.path, l.entry.Name())
+		yield, descend := true, l.recursive && l.entry.IsDir() && (l.maxDepth <= 0 || frame.depth < l.maxDepth)
+		if l.match != nil {
+			matched, matchedDescend := l.match(l.entry.Name(), frame.depth)
+			yield, descend = matched, descend && matchedDescend
+		}
+		if descend {
+			l.stack = append(l.stack, &listFrame{
+				uri:   l.entry.URI(),
+				path:  l.path,
+				depth: frame.depth + 1,
+				next:  l.open(l.path),
+			})
+		}
+		if yield {
+			return true
+		}
+	}
+	l.entry, l.path = nil, ""
+	return false
+}
+
+// Entry returns the current entry, or nil if Next has not been called or returned false.
+func (l *Lister) Entry() *stat.ResourceInfo {
+	return l.entry
+}
+
+// Path returns the slash-separated path of the current entry relative to the listed uri, such as "a/b.txt".
+// If the listed uri is not a directory or prefix, then the path is the name of the resource.
+func (l *Lister) Path() string {
+	return l.path
+}
+
+// Err returns the first error that occurred while listing, if any.
+func (l *Lister) Err() error {
+	return l.err
+}
+
+// Close stops the listing and releases the connections used by the listing, such as to a SFTP or FTP server.
+// Close can be called more than once.
+func (l *Lister) Close() error {
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	l.stack = nil
+	if l.release != nil {
+		return l.release()
+	}
+	return nil
+}
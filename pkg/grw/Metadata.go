@@ -8,16 +8,35 @@
 package grw
 
 import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+
+	pkgalg "github.com/spatialcurrent/go-reader-writer/pkg/alg"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
 )
 
 type Metadata struct {
-	ContentType   string
-	LastModified  *time.Time
-	ContentLength int64
-	Header        map[string][]string
+	ContentType          string
+	ContentEncoding      string
+	CacheControl         string
+	ETag                 string
+	VersionID            string
+	LastModified         *time.Time
+	ContentLength        int64
+	StorageClass         string
+	ServerSideEncryption string
+	SSEKMSKeyID          string
+	SSECustomerAlgorithm string
+	UserMetadata         map[string]string
+	Header               map[string][]string
+	Uncompressed         bool // the body was transparently decompressed by the HTTP client
 }
 
 func NewMetadataFromHeader(header map[string][]string) *Metadata {
@@ -27,9 +46,122 @@ func NewMetadataFromHeader(header map[string][]string) *Metadata {
 		contentType = contentTypes[0]
 	}
 
-	return &Metadata{ContentType: contentType, Header: header}
+	m := &Metadata{ContentType: contentType, Header: header}
+
+	if contentEncodings, ok := header["Content-Encoding"]; ok && len(contentEncodings) > 0 {
+		m.ContentEncoding = contentEncodings[0]
+	}
+
+	if cacheControls, ok := header["Cache-Control"]; ok && len(cacheControls) > 0 {
+		m.CacheControl = cacheControls[0]
+	}
+
+	if etags, ok := header["Etag"]; ok && len(etags) > 0 {
+		m.ETag = etags[0]
+	}
+
+	if lastModified, ok := header["Last-Modified"]; ok && len(lastModified) > 0 {
+		if t, err := http.ParseTime(lastModified[0]); err == nil {
+			m.LastModified = &t
+		}
+	}
+
+	if contentLength, ok := header["Content-Length"]; ok && len(contentLength) > 0 {
+		if n, err := strconv.ParseInt(contentLength[0], 10, 64); err == nil {
+			m.ContentLength = n
+		}
+	}
+
+	return m
 }
 
 func NewMetadataFromS3(output *s3.GetObjectOutput) *Metadata {
-	return &Metadata{ContentType: *output.ContentType, LastModified: output.LastModified, ContentLength: *output.ContentLength}
+	m := &Metadata{
+		ContentType:          aws.StringValue(output.ContentType),
+		ContentEncoding:      aws.StringValue(output.ContentEncoding),
+		CacheControl:         aws.StringValue(output.CacheControl),
+		ETag:                 aws.StringValue(output.ETag),
+		LastModified:         output.LastModified,
+		ContentLength:        aws.Int64Value(output.ContentLength),
+		StorageClass:         aws.StringValue(output.StorageClass),
+		ServerSideEncryption: aws.StringValue(output.ServerSideEncryption),
+		SSEKMSKeyID:          aws.StringValue(output.SSEKMSKeyId),
+		SSECustomerAlgorithm: aws.StringValue(output.SSECustomerAlgorithm),
+	}
+	// objects in buckets that have never been versioned have the version "null".
+	if versionID := aws.StringValue(output.VersionId); versionID != "null" {
+		m.VersionID = versionID
+	}
+	if len(output.Metadata) > 0 {
+		m.UserMetadata = aws.StringValueMap(output.Metadata)
+	}
+	return m
+}
+
+func NewMetadataFromGCS(attrs *gcs.ObjectAttrs) *Metadata {
+	m := &Metadata{
+		ContentType:     attrs.ContentType,
+		ContentEncoding: attrs.ContentEncoding,
+		CacheControl:    attrs.CacheControl,
+		ETag:            attrs.ETag,
+		ContentLength:   attrs.Size,
+		StorageClass:    attrs.StorageClass,
+		UserMetadata:    attrs.Metadata,
+	}
+	if !attrs.Updated.IsZero() {
+		lastModified := attrs.Updated
+		m.LastModified = &lastModified
+	}
+	return m
+}
+
+func NewMetadataFromAzureBlob(props *azblob.BlobProperties) *Metadata {
+	m := &Metadata{
+		ContentType:     props.ContentType,
+		ContentEncoding: props.ContentEncoding,
+		CacheControl:    props.CacheControl,
+		ETag:            props.ETag,
+		ContentLength:   props.Size,
+		StorageClass:    props.AccessTier,
+		UserMetadata:    props.Metadata,
+	}
+	if !props.LastModified.IsZero() {
+		lastModified := props.LastModified
+		m.LastModified = &lastModified
+	}
+	return m
+}
+
+// Algorithm returns the algorithm used to decode the resource, chosen from the content encoding or the content type.
+// If the content encoding is set, then the content type is ignored, so the resource is decoded only once.
+// If the body was already decompressed by the HTTP client, then returns AlgorithmNone.
+// Returns an error if the content encoding is not supported.
+func (m *Metadata) Algorithm() (string, error) {
+	if m == nil || m.Uncompressed {
+		return pkgalg.AlgorithmNone, nil
+	}
+	alg, ok := pkgalg.FromContentEncoding(m.ContentEncoding)
+	if !ok {
+		return "", fmt.Errorf("content encoding %q is not supported", m.ContentEncoding)
+	}
+	if alg != pkgalg.AlgorithmNone {
+		return alg, nil
+	}
+	return pkgalg.FromContentType(m.ContentType), nil
+}
+
+// Validator returns a validator for conditional requests that resume reading the resource.
+// Validator returns the ETag, if it is a strong validator, or the last modified time as a HTTP date.
+// If neither is known, then returns a blank string.
+func (m *Metadata) Validator() string {
+	if m == nil {
+		return ""
+	}
+	if len(m.ETag) > 0 && !strings.HasPrefix(m.ETag, "W/") {
+		return m.ETag
+	}
+	if m.LastModified != nil {
+		return m.LastModified.UTC().Format(http.TimeFormat)
+	}
+	return ""
 }
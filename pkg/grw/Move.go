@@ -0,0 +1,463 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	stdos "os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	homedir "github.com/mitchellh/go-homedir"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+	"github.com/spatialcurrent/go-reader-writer/pkg/stat"
+)
+
+// Move moves the resource at the source uri to the destination uri, using the clients and credentials in the options, if any.
+// The options may be nil.
+//
+// If the destination ends with a slash or is an existing directory or prefix, then the resource is moved into it with the same name.
+// If the destination already exists and the options do not overwrite, then the error wraps os.ErrExist.
+//
+// Local files are moved with os.Rename, and files on the same SFTP server are renamed with the posix-rename extension, if supported.
+// Objects are moved within AWS S3 with CopyObject, or a multipart copy if larger than 5 GB, followed by removing the source object.
+// Otherwise, the resource is streamed from the source to the destination, including between local filesystems,
+// and the source is only removed once the destination is verified to have the same size.
+// Directories can only be moved by renaming them.  Resources that cannot be removed by Remove cannot be moved.
+// Resources cannot be moved between different SSH servers, since the options only contain the credentials for one server.
+func Move(ctx context.Context, source string, destination string, options *MoveOptions) error {
+	if options == nil {
+		options = &MoveOptions{}
+	}
+
+	for _, uri := range []string{source, destination} {
+		if uri == "-" || uri == "stdin" || uri == "stdout" {
+			return fmt.Errorf("error moving resource: %q cannot be moved", uri)
+		}
+	}
+
+	sourceScheme, sourcePath := splitter.SplitURI(source)
+	if _, ok := azblob.ParseBlobURL(source); ok {
+		sourceScheme = schemes.SchemeAzureBlob
+	}
+
+	if err := checkRemove(source, sourceScheme); err != nil {
+		return err
+	}
+
+	// the source and destination share the credentials and host key callback in the options, which are for a single SSH server
+	if destinationScheme, destinationPath := splitter.SplitURI(destination); isSSHScheme(sourceScheme) && isSSHScheme(destinationScheme) && !sameAuthority(sourcePath, destinationPath) {
+		return fmt.Errorf("error moving resource at uri %q to uri %q: resources cannot be moved between different SSH servers", source, destination)
+	}
+
+	info, err := Stat(ctx, source, &options.ResourceOptions)
+	if err != nil {
+		return err
+	}
+
+	destination, exists, err := moveDestination(ctx, destination, info, options)
+	if err != nil {
+		return fmt.Errorf("error moving resource at uri %q: %w", source, err)
+	}
+
+	destinationScheme, destinationPath := splitter.SplitURI(destination)
+	if _, ok := azblob.ParseBlobURL(destination); ok {
+		destinationScheme = schemes.SchemeAzureBlob
+	}
+
+	if destination == source {
+		return fmt.Errorf("error moving resource at uri %q: the source and destination are the same", source)
+	}
+
+	switch {
+	case isLocalScheme(sourceScheme) && isLocalScheme(destinationScheme):
+		err = moveFile(ctx, source, sourcePath, destination, destinationPath, info, options)
+	case sourceScheme == schemes.SchemeSFTP && destinationScheme == schemes.SchemeSFTP && sameAuthority(sourcePath, destinationPath):
+		err = moveSFTPFile(ctx, source, sourcePath, destinationPath, exists, options)
+	case info.IsDir():
+		err = errors.New("directories and prefixes can only be moved within a local filesystem or SFTP server")
+	case sourceScheme == schemes.SchemeS3 && destinationScheme == schemes.SchemeS3:
+		err = moveS3Object(ctx, source, sourcePath, destinationPath, info, options)
+	default:
+		err = copyResource(ctx, source, destination, info, options)
+		if err == nil {
+			err = Remove(ctx, source, &RemoveOptions{ResourceOptions: options.ResourceOptions})
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error moving resource at uri %q to uri %q: %w", source, destination, err)
+	}
+	return nil
+}
+
+// moveDestination returns the uri the resource is moved to and true if a resource already exists at the uri.
+// If the destination ends with a slash or is an existing directory or prefix,
+// then the uri is the destination joined with the name of the resource.
+func moveDestination(ctx context.Context, destination string, info *stat.ResourceInfo, options *MoveOptions) (string, bool, error) {
+	if strings.HasSuffix(destination, "/") {
+		destination = joinURI(destination, info.Name())
+	}
+	destinationInfo, err := Stat(ctx, destination, &options.ResourceOptions)
+	if err == nil && destinationInfo.IsDir() {
+		destination = joinURI(destination, info.Name())
+		destinationInfo, err = Stat(ctx, destination, &options.ResourceOptions)
+	}
+	if err != nil {
+		if errors.Is(err, stdos.ErrNotExist) {
+			return destination, false, nil
+		}
+		return "", false, err
+	}
+	if destinationInfo.IsDir() {
+		return "", false, fmt.Errorf("resource at uri %q is a directory", destination)
+	}
+	if !options.Overwrite {
+		return "", false, fmt.Errorf("resource at uri %q already exists: %w", destination, stdos.ErrExist)
+	}
+	return destination, true, nil
+}
+
+// isLocalScheme returns true if the scheme is for local files.
+func isLocalScheme(scheme string) bool {
+	return scheme == schemes.SchemeFile || scheme == ""
+}
+
+// isSSHScheme returns true if the scheme is for resources on a SSH server.
+func isSSHScheme(scheme string) bool {
+	return scheme == schemes.SchemeSFTP || scheme == schemes.SchemeSSH || scheme == schemes.SchemeSSHCommand
+}
+
+// sameAuthority returns true if the paths of two uris start with the same authority, such as user@host:port.
+func sameAuthority(a string, b string) bool {
+	return strings.SplitN(a, "/", 2)[0] == strings.SplitN(b, "/", 2)[0]
+}
+
+// moveFile renames a local file or directory.
+// If the destination is on a different filesystem, then a file is copied and removed instead.
+func moveFile(ctx context.Context, source string, sourcePath string, destination string, destinationPath string, info *stat.ResourceInfo, options *MoveOptions) error {
+	src, err := homedir.Expand(sourcePath)
+	if err != nil {
+		return fmt.Errorf("error expanding file path %q: %w", sourcePath, err)
+	}
+	dst, err := homedir.Expand(destinationPath)
+	if err != nil {
+		return fmt.Errorf("error expanding file path %q: %w", destinationPath, err)
+	}
+	if options.Parents {
+		err = stdos.MkdirAll(filepath.Dir(dst), stdos.FileMode(DefaultDirMode))
+		if err != nil {
+			return fmt.Errorf("error creating parent directories for %q: %w", dst, err)
+		}
+	}
+	err = stdos.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("directories cannot be moved between filesystems: %w", err)
+	}
+	err = copyResource(ctx, source, destination, info, options)
+	if err != nil {
+		return err
+	}
+	return stdos.Remove(src)
+}
+
+// moveSFTPFile renames a file or directory on a SFTP server.
+func moveSFTPFile(ctx context.Context, source string, sourcePath string, destinationPath string, exists bool, options *MoveOptions) error {
+	client, release, err := options.sftpClient(ctx, source)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = release() }()
+	src, dst := remotePath(sourcePath), remotePath(destinationPath)
+	if options.Parents {
+		err = sftp2.MkdirAll(client, path.Dir(dst), stdos.FileMode(DefaultDirMode))
+		if err != nil {
+			return fmt.Errorf("error creating parent directories for %q: %w", dst, err)
+		}
+	}
+	if _, ok := client.HasExtension(sftp2.ExtensionPosixRename); ok {
+		return client.PosixRename(src, dst)
+	}
+	if exists {
+		// the rename request of the SFTP protocol fails if the destination exists
+		err = client.Remove(dst)
+		if err != nil {
+			return fmt.Errorf("error removing existing file %q: %w", dst, err)
+		}
+	}
+	return client.Rename(src, dst)
+}
+
+var (
+	// maxCopyObjectSize is the size of the largest object that can be copied on AWS S3 by a single CopyObject request.
+	maxCopyObjectSize = int64(5 * 1024 * 1024 * 1024)
+	// copyPartSize is the size of the parts used to copy larger objects, unless more than maxCopyParts parts would be needed.
+	copyPartSize = int64(512 * 1024 * 1024)
+)
+
+// maxCopyParts is the maximum number of parts in a multipart upload on AWS S3.
+const maxCopyParts = 10000
+
+// moveS3Object copies an object to another key on AWS S3, checks the size of the copy, and then removes the source object.
+func moveS3Object(ctx context.Context, source string, sourcePath string, destinationPath string, info *stat.ResourceInfo, options *MoveOptions) error {
+	sourceBucket, sourceKey, versionID, err := splitS3Path(sourcePath)
+	if err != nil {
+		return err
+	}
+	destinationBucket, destinationKey, _, err := splitS3Path(destinationPath)
+	if err != nil {
+		return err
+	}
+
+	copySource := (&url.URL{Path: sourceBucket + "/" + sourceKey}).EscapedPath()
+	if len(versionID) > 0 {
+		copySource += "?versionId=" + url.QueryEscape(versionID)
+	}
+
+	var requestPayer *string
+	if options.RequestPayer {
+		requestPayer = aws.String(s3.RequestPayerRequester)
+	}
+
+	headObjectInput := &s3.HeadObjectInput{
+		Bucket:       aws.String(sourceBucket),
+		Key:          aws.String(sourceKey),
+		RequestPayer: requestPayer,
+	}
+	if len(versionID) > 0 {
+		headObjectInput.VersionId = aws.String(versionID)
+	}
+	sourceHead, err := options.S3Client.HeadObjectWithContext(ctx, headObjectInput)
+	if err != nil {
+		return fmt.Errorf("error heading source object: %w", err)
+	}
+
+	if info.Size() > maxCopyObjectSize {
+		err = copyS3ObjectParts(ctx, sourceHead, copySource, destinationBucket, destinationKey, info.Size(), options)
+	} else {
+		// the storage class and server-side encryption are not copied from the source object unless set in the request.
+		_, err = options.S3Client.CopyObjectWithContext(ctx, &s3.CopyObjectInput{
+			Bucket:               aws.String(destinationBucket),
+			Key:                  aws.String(destinationKey),
+			CopySource:           aws.String(copySource),
+			ServerSideEncryption: sourceHead.ServerSideEncryption,
+			SSEKMSKeyId:          sourceHead.SSEKMSKeyId,
+			StorageClass:         sourceHead.StorageClass,
+			RequestPayer:         requestPayer,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("error copying object: %w", err)
+	}
+
+	headObjectOutput, err := options.S3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(destinationBucket),
+		Key:          aws.String(destinationKey),
+		RequestPayer: requestPayer,
+	})
+	if err != nil {
+		return fmt.Errorf("error verifying copied object: %w", err)
+	}
+	if size := aws.Int64Value(headObjectOutput.ContentLength); size != info.Size() {
+		return fmt.Errorf("error verifying copied object: copy has %d bytes, but the source has %d bytes", size, info.Size())
+	}
+
+	return Remove(ctx, source, &RemoveOptions{ResourceOptions: options.ResourceOptions})
+}
+
+// copyS3ObjectParts copies an object on AWS S3 with a multipart upload, where each part is copied from a range of the source object.
+// The content type, user metadata, and other headers of the source object, given by its head, are kept.
+func copyS3ObjectParts(ctx context.Context, headObjectOutput *s3.HeadObjectOutput, copySource string, bucket string, key string, size int64, options *MoveOptions) error {
+	var requestPayer *string
+	if options.RequestPayer {
+		requestPayer = aws.String(s3.RequestPayerRequester)
+	}
+
+	createMultipartUploadOutput, err := options.S3Client.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(key),
+		CacheControl:         headObjectOutput.CacheControl,
+		ContentDisposition:   headObjectOutput.ContentDisposition,
+		ContentEncoding:      headObjectOutput.ContentEncoding,
+		ContentLanguage:      headObjectOutput.ContentLanguage,
+		ContentType:          headObjectOutput.ContentType,
+		Metadata:             headObjectOutput.Metadata,
+		ServerSideEncryption: headObjectOutput.ServerSideEncryption,
+		SSEKMSKeyId:          headObjectOutput.SSEKMSKeyId,
+		StorageClass:         headObjectOutput.StorageClass,
+		RequestPayer:         requestPayer,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating multipart upload: %w", err)
+	}
+	uploadID := createMultipartUploadOutput.UploadId
+
+	partSize := copyPartSize
+	if size > partSize*maxCopyParts {
+		partSize = (size + maxCopyParts - 1) / maxCopyParts
+	}
+
+	parts := make([]*s3.CompletedPart, 0, (size+partSize-1)/partSize)
+	for offset, partNumber := int64(0), int64(1); offset < size; offset, partNumber = offset+partSize, partNumber+1 {
+		end := offset + partSize
+		if end > size {
+			end = size
+		}
+		uploadPartCopyOutput, errCopy := options.S3Client.UploadPartCopyWithContext(ctx, &s3.UploadPartCopyInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(key),
+			CopySource:      aws.String(copySource),
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", offset, end-1)),
+			PartNumber:      aws.Int64(partNumber),
+			UploadId:        uploadID,
+			RequestPayer:    requestPayer,
+		})
+		if errCopy != nil {
+			_, _ = options.S3Client.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:       aws.String(bucket),
+				Key:          aws.String(key),
+				UploadId:     uploadID,
+				RequestPayer: requestPayer,
+			})
+			return fmt.Errorf("error copying part %d: %w", partNumber, errCopy)
+		}
+		parts = append(parts, &s3.CompletedPart{
+			ETag:       uploadPartCopyOutput.CopyPartResult.ETag,
+			PartNumber: aws.Int64(partNumber),
+		})
+	}
+
+	_, err = options.S3Client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		UploadId:        uploadID,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+		RequestPayer:    requestPayer,
+	})
+	if err != nil {
+		return fmt.Errorf("error completing multipart upload: %w", err)
+	}
+	return nil
+}
+
+// copyResource streams the resource at the source uri to the destination uri without decompressing it,
+// and then checks that the destination has the same size as the source.
+// The content type, content encoding, cache control, and user metadata of a remote resource are kept.
+func copyResource(ctx context.Context, source string, destination string, info *stat.ResourceInfo, options *MoveOptions) error {
+	r, metadata, err := openMoveSource(ctx, source, options)
+	if err != nil {
+		return err
+	}
+
+	writeToResourceInput := options.writeToResourceInput(destination)
+	writeToResourceInput.Parents = options.Parents
+	if m := metadata; m != nil && !m.Uncompressed {
+		writeToResourceInput.ContentType = m.ContentType
+		writeToResourceInput.ContentEncoding = m.ContentEncoding
+		writeToResourceInput.CacheControl = m.CacheControl
+		writeToResourceInput.Metadata = m.UserMetadata
+	}
+	writeToResourceOutput, err := WriteToResource(writeToResourceInput)
+	if err != nil {
+		_ = r.Close()
+		return err
+	}
+	w := writeToResourceOutput.Writer
+
+	n, err := io.Copy(w, r)
+	if err != nil {
+		_ = r.Close()
+		_ = w.Close()
+		return fmt.Errorf("error copying resource: %w", err)
+	}
+	err = r.Close()
+	if err != nil {
+		_ = w.Close()
+		return fmt.Errorf("error closing resource at uri %q: %w", source, err)
+	}
+	if flusher, ok := w.(interface{ Flush() error }); ok {
+		err = flusher.Flush()
+		if err != nil {
+			_ = w.Close()
+			return fmt.Errorf("error flushing to resource at uri %q: %w", destination, err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("error closing resource at uri %q: %w", destination, err)
+	}
+
+	// the size of a HTTP resource is zero if unknown
+	if info.Size() > 0 && n != info.Size() {
+		return fmt.Errorf("error verifying copied resource: copied %d bytes, but the source has %d bytes", n, info.Size())
+	}
+	destinationInfo, err := Stat(ctx, destination, &options.ResourceOptions)
+	if err != nil {
+		return fmt.Errorf("error verifying copied resource: %w", err)
+	}
+	if destinationInfo.Size() != n {
+		return fmt.Errorf("error verifying copied resource: copy has %d bytes, but %d bytes were copied", destinationInfo.Size(), n)
+	}
+	return nil
+}
+
+// openMoveSource opens the resource at the source uri for copying without decompressing it.
+// ReadFromResource closes the SSH and SFTP clients it is given, so a SFTP file is read using a client that is only released when the reader is closed,
+// since the clients in the options are used again to verify the copy and remove the source.
+func openMoveSource(ctx context.Context, source string, options *MoveOptions) (io.ReadCloser, *Metadata, error) {
+	if scheme, fullpath := splitter.SplitURI(source); scheme == schemes.SchemeSFTP {
+		client, release, err := options.sftpClient(ctx, source)
+		if err != nil {
+			return nil, nil, err
+		}
+		f, err := client.Open(remotePath(fullpath))
+		if err != nil {
+			_ = release()
+			return nil, nil, fmt.Errorf("error opening file: %w", err)
+		}
+		return &releaseReader{ReadCloser: f, release: release}, nil, nil
+	}
+	readFromResourceOutput, err := ReadFromResource(options.readFromResourceInput(source))
+	if err != nil {
+		return nil, nil, err
+	}
+	return readFromResourceOutput.Reader, readFromResourceOutput.Metadata, nil
+}
+
+// releaseReader is a reader that releases the client used to read the resource when closed.
+type releaseReader struct {
+	io.ReadCloser
+	release func() error
+}
+
+// Close closes the underlying reader and then releases the client.
+func (r *releaseReader) Close() error {
+	err := r.ReadCloser.Close()
+	errRelease := r.release()
+	if err != nil {
+		return fmt.Errorf("error closing reader: %w", err)
+	}
+	if errRelease != nil {
+		return fmt.Errorf("error releasing client: %w", errRelease)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+// MoveOptions contains the options for Move, including the clients and credentials used to access the resources.
+type MoveOptions struct {
+	ResourceOptions      // clients and credentials used to access the source and destination
+	Overwrite       bool // replace the destination if it already exists
+	Parents         bool // automatically create the parent directories of the destination as necessary
+}
@@ -0,0 +1,178 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/grwtest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+func TestMoveS3Parts(t *testing.T) {
+	server := grwtest.NewS3Server()
+	defer server.Close()
+	require.NoError(t, server.CreateBucket("bucket"))
+	client := server.S3Client()
+
+	data := bytes.Repeat([]byte("0123456789"), 10)
+	_, err := client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String("bucket"),
+		Key:         aws.String("incoming/a.csv"),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("text/csv"),
+	})
+	require.NoError(t, err)
+
+	// copy objects larger than 64 bytes in parts of 32 bytes
+	defer func(size int64, partSize int64) {
+		maxCopyObjectSize, copyPartSize = size, partSize
+	}(maxCopyObjectSize, copyPartSize)
+	maxCopyObjectSize, copyPartSize = 64, 32
+
+	operations := []string{}
+	client.Handlers.Send.PushFront(func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+	})
+
+	err = Move(context.Background(), "s3://bucket/incoming/a.csv", "s3://bucket/processed/", &MoveOptions{
+		ResourceOptions: ResourceOptions{S3Client: client},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, operations, "UploadPartCopy")
+	assert.NotContains(t, operations, "GetObject")
+
+	b, err := os.ReadFile(server.Path("bucket/processed/a.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, data, b)
+	assert.Equal(t, "text/csv", server.Header("bucket/processed/a.csv").Get("Content-Type"))
+	_, err = os.Stat(server.Path("bucket/incoming/a.csv"))
+	assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+}
+
+func TestMoveS3StorageClassAndEncryption(t *testing.T) {
+	server := grwtest.NewS3Server()
+	defer server.Close()
+	require.NoError(t, server.CreateBucket("bucket"))
+	client := server.S3Client()
+
+	// copy objects larger than 64 bytes in parts of 32 bytes
+	defer func(size int64, partSize int64) {
+		maxCopyObjectSize, copyPartSize = size, partSize
+	}(maxCopyObjectSize, copyPartSize)
+	maxCopyObjectSize, copyPartSize = 64, 32
+
+	for _, size := range []int{10, 100} {
+		key := fmt.Sprintf("incoming/%d.csv", size)
+		_, err := client.PutObject(&s3.PutObjectInput{
+			Bucket:               aws.String("bucket"),
+			Key:                  aws.String(key),
+			Body:                 bytes.NewReader(bytes.Repeat([]byte("a"), size)),
+			StorageClass:         aws.String(s3.StorageClassStandardIa),
+			ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+			SSEKMSKeyId:          aws.String("alias/grw"),
+		})
+		require.NoError(t, err)
+
+		err = Move(context.Background(), "s3://bucket/"+key, "s3://bucket/processed/", &MoveOptions{
+			ResourceOptions: ResourceOptions{S3Client: client},
+		})
+		require.NoError(t, err)
+
+		header := server.Header(fmt.Sprintf("bucket/processed/%d.csv", size))
+		assert.Equal(t, s3.StorageClassStandardIa, header.Get("X-Amz-Storage-Class"), size)
+		assert.Equal(t, s3.ServerSideEncryptionAwsKms, header.Get("X-Amz-Server-Side-Encryption"), size)
+		assert.Equal(t, "alias/grw", header.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"), size)
+	}
+}
+
+func TestMoveSFTPClients(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	sshClient, err := ssh.Dial("tcp", server.Addr(), &ssh.ClientConfig{
+		User:            server.User,
+		Auth:            []ssh.AuthMethod{ssh.Password(server.Password)},
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	defer sshClient.Close()
+	sftpClient, err := sftp.NewClient(sshClient)
+	require.NoError(t, err)
+	defer sftpClient.Close()
+
+	dir := t.TempDir()
+	for i, options := range []ResourceOptions{{SSHClient: sshClient}, {SFTPClient: sftpClient}} {
+		source := filepath.Join(dir, fmt.Sprintf("a-%d.txt", i))
+		require.NoError(t, os.WriteFile(source, []byte("hello world"), 0640))
+		destination := filepath.Join(dir, "b", fmt.Sprintf("a-%d.txt", i))
+
+		// the source is copied to a local file and then removed using the same clients
+		err = Move(context.Background(), server.URI("sftp", source), destination, &MoveOptions{
+			ResourceOptions: options,
+			Parents:         true,
+		})
+		require.NoError(t, err)
+		b, errRead := os.ReadFile(destination)
+		require.NoError(t, errRead)
+		assert.Equal(t, "hello world", string(b))
+		_, err = os.Stat(source)
+		assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+	}
+
+	// the clients provided as options are still open
+	_, err = sftpClient.Getwd()
+	assert.NoError(t, err)
+}
+
+func TestMoveDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "c.txt"), []byte("hello world"), 0640))
+
+	err := Move(context.Background(), filepath.Join(dir, "a"), filepath.Join(dir, "d"), nil)
+	require.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join(dir, "d", "b", "c.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+
+	server := grwtest.NewS3Server()
+	defer server.Close()
+	require.NoError(t, server.CreateBucket("bucket"))
+	err = Move(context.Background(), filepath.Join(dir, "d"), "s3://bucket/d", &MoveOptions{
+		ResourceOptions: ResourceOptions{S3Client: server.S3Client()},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can only be moved")
+}
+
+func TestMoveUnsupported(t *testing.T) {
+	err := Move(context.Background(), "gs://bucket/a.txt", "gs://bucket/b.txt", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be removed")
+
+	err = Move(context.Background(), "-", "a.txt", nil)
+	require.Error(t, err)
+
+	err = Move(context.Background(), "sftp://a@example.com//tmp/a.txt", "sftp://b@example.com//tmp/a.txt", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "different SSH servers")
+}
@@ -0,0 +1,31 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
+)
+
+// NewAWSRetryer returns a retryer for the AWS SDK that uses the number of attempts and delays of the given policy.
+// The AWS SDK classifies which errors are retryable.
+// If the policy is nil, then the AWS SDK does not retry requests.
+func NewAWSRetryer(policy *retry.Policy) request.Retryer {
+	if policy == nil || policy.Attempts < 1 {
+		return client.DefaultRetryer{NumMaxRetries: 0}
+	}
+	return client.DefaultRetryer{
+		NumMaxRetries:    policy.Attempts - 1,
+		MinRetryDelay:    policy.BaseDelay,
+		MaxRetryDelay:    policy.MaxDelay,
+		MinThrottleDelay: policy.BaseDelay,
+		MaxThrottleDelay: policy.MaxDelay,
+	}
+}
@@ -0,0 +1,112 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
+)
+
+const (
+	// DefaultS3CompatibleRegion is the region used for S3-compatible services when no region is set.
+	DefaultS3CompatibleRegion = "us-east-1"
+)
+
+// NewS3ClientInput contains the input parameters for NewS3Client.
+type NewS3ClientInput struct {
+	Profile          string                 // named profile in the shared config and credentials files, defaults to AWS_PROFILE or "default"
+	Region           string                 // AWS region, defaults to the region from the environment or the shared config
+	AccessKeyID      string                 // AWS access key ID, defaults to credentials from the environment
+	SecretAccessKey  string                 // AWS secret access key
+	SessionToken     string                 // AWS session token
+	RoleARN          string                 // ARN of a role to assume
+	RoleSessionName  string                 // session name used when assuming the role
+	ExternalID       string                 // external ID used when assuming the role
+	MFASerial        string                 // serial number or ARN of the MFA device used when assuming the role
+	MFATokenProvider func() (string, error) // returns a MFA token code when assuming a role that requires MFA, including roles in the shared config
+	Endpoint         string                 // endpoint url of a S3-compatible service, such as MinIO, Ceph RGW, or LocalStack
+	UsePathStyle     bool                   // address buckets in the path of the url, rather than in the host name
+	DisableSSL       bool                   // use http, rather than https, when the endpoint does not include a scheme
+	Retry            *retry.Policy          // policy for retrying failed requests
+}
+
+// NewS3Client returns a new AWS S3 client and the session used to create it.
+//
+// The shared config and credentials files are loaded, so named profiles may assume roles
+// with role_arn and source_profile or use AWS SSO.
+// If the access key ID and secret access key are set, then they are used instead of the credentials in the profile.
+// If the role ARN is set, then the role is assumed using the credentials of the session.
+//
+// If an endpoint is set and no region is found, then the region defaults to "us-east-1",
+// since S3-compatible services usually ignore the region.
+func NewS3Client(input *NewS3ClientInput) (*s3.S3, *session.Session, error) {
+	config := request.WithRetryer(&aws.Config{
+		S3ForcePathStyle: aws.Bool(input.UsePathStyle),
+		DisableSSL:       aws.Bool(input.DisableSSL),
+	}, NewAWSRetryer(input.Retry))
+
+	if len(input.Region) > 0 {
+		config.Region = aws.String(input.Region)
+	}
+
+	if len(input.Endpoint) > 0 {
+		config.Endpoint = aws.String(input.Endpoint)
+	}
+
+	if len(input.AccessKeyID) > 0 && len(input.SecretAccessKey) > 0 {
+		config.Credentials = credentials.NewStaticCredentials(
+			input.AccessKeyID,
+			input.SecretAccessKey,
+			input.SessionToken)
+	}
+
+	s, err := session.NewSessionWithOptions(session.Options{
+		Config:                  *config,
+		Profile:                 input.Profile,
+		SharedConfigState:       session.SharedConfigEnable,
+		AssumeRoleTokenProvider: input.MFATokenProvider,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating new AWS session: %w", err)
+	}
+
+	if len(aws.StringValue(s.Config.Region)) == 0 && len(input.Endpoint) > 0 {
+		s = s.Copy(&aws.Config{Region: aws.String(DefaultS3CompatibleRegion)})
+	}
+
+	if len(input.RoleARN) > 0 {
+		if len(input.MFASerial) > 0 && input.MFATokenProvider == nil {
+			return nil, nil, errors.New("error creating new AWS session: assuming a role with MFA requires a MFA token provider")
+		}
+		s = s.Copy(&aws.Config{
+			Credentials: stscreds.NewCredentials(s, input.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+				if len(input.RoleSessionName) > 0 {
+					p.RoleSessionName = input.RoleSessionName
+				}
+				if len(input.ExternalID) > 0 {
+					p.ExternalID = aws.String(input.ExternalID)
+				}
+				if len(input.MFASerial) > 0 {
+					p.SerialNumber = aws.String(input.MFASerial)
+					p.TokenProvider = input.MFATokenProvider
+				}
+			}),
+		})
+	}
+
+	return s3.New(s), s, nil
+}
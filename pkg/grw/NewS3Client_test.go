@@ -0,0 +1,214 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolateAWSEnvironment clears the AWS environment variables and
+// replaces the shared config and credentials files with the given contents.
+func isolateAWSEnvironment(t *testing.T, config string, credentials string) {
+	for _, name := range []string{
+		"AWS_PROFILE",
+		"AWS_DEFAULT_PROFILE",
+		"AWS_REGION",
+		"AWS_DEFAULT_REGION",
+		"AWS_ACCESS_KEY_ID",
+		"AWS_SECRET_ACCESS_KEY",
+		"AWS_SESSION_TOKEN",
+		"AWS_ROLE_ARN",
+		"AWS_WEB_IDENTITY_TOKEN_FILE",
+	} {
+		t.Setenv(name, "")
+	}
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0600))
+	credentialsFile := filepath.Join(dir, "credentials")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte(credentials), 0600))
+	t.Setenv("AWS_CONFIG_FILE", configFile)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsFile)
+}
+
+func testS3ObjectURL(t *testing.T, input *NewS3ClientInput) string {
+	client, _, err := NewS3Client(input)
+	require.NoError(t, err)
+	req, _ := client.GetObjectRequest(&s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("a/b.txt")})
+	require.NoError(t, req.Build())
+	return req.HTTPRequest.URL.String()
+}
+
+func TestNewS3Client(t *testing.T) {
+	isolateAWSEnvironment(t, "", "")
+
+	assert.Equal(t, "https://bucket.s3.us-west-2.amazonaws.com/a/b.txt", testS3ObjectURL(t, &NewS3ClientInput{
+		Region:          "us-west-2",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+	}))
+	assert.Equal(t, "http://127.0.0.1:9000/bucket/a/b.txt", testS3ObjectURL(t, &NewS3ClientInput{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		Endpoint:        "http://127.0.0.1:9000",
+		UsePathStyle:    true,
+	}))
+	assert.Equal(t, "http://minio.local:9000/bucket/a/b.txt", testS3ObjectURL(t, &NewS3ClientInput{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		Endpoint:        "minio.local:9000",
+		UsePathStyle:    true,
+		DisableSSL:      true,
+	}))
+
+	client, _, err := NewS3Client(&NewS3ClientInput{Endpoint: "http://127.0.0.1:9000"})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultS3CompatibleRegion, aws.StringValue(client.Config.Region))
+}
+
+func TestNewS3ClientProfile(t *testing.T) {
+	isolateAWSEnvironment(t,
+		"[profile dev]\nregion = eu-west-1\n",
+		"[dev]\naws_access_key_id = DEVKEY\naws_secret_access_key = DEVSECRET\n",
+	)
+
+	client, _, err := NewS3Client(&NewS3ClientInput{Profile: "dev", Endpoint: "http://127.0.0.1:9000"})
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", aws.StringValue(client.Config.Region))
+	value, err := client.Config.Credentials.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "DEVKEY", value.AccessKeyID)
+
+	t.Setenv("AWS_PROFILE", "dev")
+	client, _, err = NewS3Client(&NewS3ClientInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", aws.StringValue(client.Config.Region))
+
+	// the AWS SDK ignores missing profiles when creating the session, but the credentials cannot be loaded.
+	client, _, err = NewS3Client(&NewS3ClientInput{Profile: "missing"})
+	require.NoError(t, err)
+	_, err = client.Config.Credentials.Get()
+	assert.Error(t, err)
+}
+
+// newFakeSTSServer returns a server that responds to AssumeRole requests,
+// if the request has the expected parameters.
+func newFakeSTSServer(t *testing.T, expected map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for k, v := range expected {
+			if r.PostForm.Get(k) != v {
+				t.Errorf("invalid parameter %q: expected %q, got %q", k, v, r.PostForm.Get(k))
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = fmt.Fprint(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>ASSUMEDKEY</AccessKeyId>
+      <SecretAccessKey>ASSUMEDSECRET</SecretAccessKey>
+      <SessionToken>ASSUMEDTOKEN</SessionToken>
+      <Expiration>2100-01-01T00:00:00Z</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::123456789012:assumed-role/reader/grw</Arn>
+      <AssumedRoleId>AROA:grw</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+  <ResponseMetadata><RequestId>1</RequestId></ResponseMetadata>
+</AssumeRoleResponse>`)
+	}))
+}
+
+func TestNewS3ClientAssumeRole(t *testing.T) {
+	isolateAWSEnvironment(t, "", "")
+
+	server := newFakeSTSServer(t, map[string]string{
+		"Action":          "AssumeRole",
+		"RoleArn":         "arn:aws:iam::123456789012:role/reader",
+		"RoleSessionName": "grw",
+		"ExternalId":      "external",
+		"SerialNumber":    "arn:aws:iam::123456789012:mfa/user",
+		"TokenCode":       "123456",
+	})
+	defer server.Close()
+
+	tokens := 0
+	client, _, err := NewS3Client(&NewS3ClientInput{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		RoleARN:         "arn:aws:iam::123456789012:role/reader",
+		RoleSessionName: "grw",
+		ExternalID:      "external",
+		MFASerial:       "arn:aws:iam::123456789012:mfa/user",
+		MFATokenProvider: func() (string, error) {
+			tokens++
+			return "123456", nil
+		},
+		Endpoint: server.URL,
+	})
+	require.NoError(t, err)
+	value, err := client.Config.Credentials.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ASSUMEDKEY", value.AccessKeyID)
+	assert.Equal(t, "ASSUMEDTOKEN", value.SessionToken)
+	assert.Equal(t, 1, tokens)
+
+	_, _, err = NewS3Client(&NewS3ClientInput{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		RoleARN:         "arn:aws:iam::123456789012:role/reader",
+		MFASerial:       "arn:aws:iam::123456789012:mfa/user",
+	})
+	assert.Error(t, err)
+}
+
+func TestNewS3ClientAssumeRoleProfile(t *testing.T) {
+	server := newFakeSTSServer(t, map[string]string{
+		"Action":       "AssumeRole",
+		"RoleArn":      "arn:aws:iam::123456789012:role/reader",
+		"ExternalId":   "external",
+		"SerialNumber": "arn:aws:iam::123456789012:mfa/user",
+		"TokenCode":    "654321",
+	})
+	defer server.Close()
+
+	isolateAWSEnvironment(t,
+		"[profile reader]\nrole_arn = arn:aws:iam::123456789012:role/reader\nsource_profile = dev\nexternal_id = external\nmfa_serial = arn:aws:iam::123456789012:mfa/user\nregion = us-west-2\n",
+		"[dev]\naws_access_key_id = DEVKEY\naws_secret_access_key = DEVSECRET\n",
+	)
+
+	_, _, err := NewS3Client(&NewS3ClientInput{Profile: "reader", Endpoint: server.URL})
+	assert.Error(t, err, "a profile with a MFA device requires a token provider")
+
+	client, _, err := NewS3Client(&NewS3ClientInput{
+		Profile:          "reader",
+		MFATokenProvider: func() (string, error) { return "654321", nil },
+		Endpoint:         server.URL,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "us-west-2", aws.StringValue(client.Config.Region))
+	value, err := client.Config.Credentials.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ASSUMEDKEY", value.AccessKeyID)
+}
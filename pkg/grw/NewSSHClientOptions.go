@@ -0,0 +1,88 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+)
+
+// NewSSHClientOptionsInput contains the input parameters for NewSSHClientOptions.
+type NewSSHClientOptionsInput struct {
+	Password             string              // password
+	PrivateKey           []byte              // private key
+	PrivateKeyPassphrase []byte              // passphrase of an encrypted private key
+	SSHAgentSocket       string              // path to the socket of a SSH agent used for authentication, such as the value of SSH_AUTH_SOCK
+	SSHConfig            *ssh2.Config        // if not nil, then the host in the uri is resolved using the ssh config
+	HostKeyCallback      ssh.HostKeyCallback // callback for verifying the host key of the SSH server, defaults to the known_hosts file of the user
+	JumpHosts            []string            // jump hosts used to reach the SSH server, each as a ssh uri or [user@]host[:port], overrides the ProxyJump in the ssh config
+	JumpHostKeyCallback  ssh.HostKeyCallback // callback for verifying the host keys of jump hosts, defaults to the known_hosts files for the jump host
+}
+
+// NewSSHClientOptions returns the options for dialing a SSH server.
+// The private key, the keys in the SSH agent, and the password are tried in that order.
+// The jump hosts are authenticated with the private key and the keys in the SSH agent,
+// or the password in the uri of the jump host, but not the password of the server.
+func NewSSHClientOptions(input *NewSSHClientOptionsInput) ([]ssh2.ClientOption, error) {
+	authOption, err := ssh2.NewAuthOption(&ssh2.NewAuthOptionInput{
+		Password:    input.Password,
+		PrivateKey:  input.PrivateKey,
+		Passphrase:  input.PrivateKeyPassphrase,
+		AgentSocket: input.SSHAgentSocket,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSH authentication methods: %w", err)
+	}
+	jumpAuthOption, err := ssh2.NewAuthOption(&ssh2.NewAuthOptionInput{
+		PrivateKey:  input.PrivateKey,
+		Passphrase:  input.PrivateKeyPassphrase,
+		AgentSocket: input.SSHAgentSocket,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSH authentication methods for jump hosts: %w", err)
+	}
+	jumpOptions := []ssh2.ClientOption{jumpAuthOption}
+	if input.JumpHostKeyCallback != nil {
+		jumpOptions = append(jumpOptions, func(config *ssh2.ClientConfig) error {
+			config.HostKeyCallback = input.JumpHostKeyCallback
+			return nil
+		})
+	}
+	options := []ssh2.ClientOption{
+		authOption,
+		func(config *ssh2.ClientConfig) error {
+			config.SSHConfig = input.SSHConfig
+			config.JumpHosts = input.JumpHosts
+			config.JumpOptions = jumpOptions
+			return nil
+		},
+	}
+	if input.HostKeyCallback != nil {
+		options = append(options, func(config *ssh2.ClientConfig) error {
+			config.HostKeyCallback = input.HostKeyCallback
+			return nil
+		})
+	}
+	return options, nil
+}
+
+// dialSSH returns a SSH client for the SSH server at the uri using the options from the input.
+func dialSSH(uri string, input *NewSSHClientOptionsInput) (*ssh.Client, error) {
+	options, err := NewSSHClientOptions(input)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ssh2.Dial(uri, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSH client: %w", err)
+	}
+	return c.Client, nil
+}
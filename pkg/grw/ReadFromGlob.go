@@ -0,0 +1,49 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+)
+
+// ReadFromGlob returns an iterator of readers for the resources that match the pattern in the uri of the input,
+// such as "s3://bucket/logs/2026-10-*/part-*.gz", in the order returned by Glob.
+// Each matching resource is opened with ReadFromResource, using the rest of the input.
+// If the pattern is a SFTP uri and the input has no SSH client, SFTP client, or SFTP pool,
+// then the matching files are read through a pool of SFTP sessions, which is closed along with the iterator.
+// The input cannot have an offset.  The caller must close the iterator when finished.
+func ReadFromGlob(ctx context.Context, input *ReadFromResourceInput) (*GlobReader, error) {
+	if input.Offset > 0 {
+		return nil, errors.New("cannot read resources that match a pattern starting at an offset")
+	}
+
+	template := *input
+
+	var pool *sftp2.Pool
+	if scheme, _ := splitter.SplitURI(input.URI); scheme == schemes.SchemeSFTP {
+		if input.SSHClient == nil && input.SFTPClient == nil && input.SFTPPool == nil {
+			pool = sftp2.NewPool(&sftp2.NewPoolInput{})
+			template.SFTPPool = pool
+		}
+	}
+
+	lister, err := Glob(ctx, input.URI, template.resourceOptions())
+	if err != nil {
+		if pool != nil {
+			_ = pool.Close()
+		}
+		return nil, err
+	}
+
+	return &GlobReader{input: &template, lister: lister, pool: pool}, nil
+}
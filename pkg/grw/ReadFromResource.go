@@ -8,12 +8,18 @@
 package grw
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	stdio "io"
+	stdhttp "net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/sftp"
@@ -22,25 +28,44 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	"github.com/spatialcurrent/go-reader-writer/pkg/io"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
 	"github.com/spatialcurrent/go-reader-writer/pkg/net/ftp"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
 	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
 	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
 	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/webdav"
 	"github.com/spatialcurrent/go-reader-writer/pkg/os"
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
 	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
 	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
 )
 
 type ReadFromResourceInput struct {
-	URI        string       // uri to read from
-	Alg        string       // compression algorithm
-	Dict       []byte       // compression dictionary
-	BufferSize int          // input reader buffer size
-	S3Client   *s3.S3       // AWS S3 Client
-	SSHClient  *ssh.Client  // SSH Client
-	SFTPClient *sftp.Client // SFTP Client
-	Password   string       // password
-	PrivateKey []byte       // private key
+	URI                  string              // uri to read from
+	Alg                  string              // compression algorithm
+	Dict                 []byte              // compression dictionary
+	BufferSize           int                 // input reader buffer size
+	DetectAlg            bool                // if the algorithm is not set, then choose the algorithm from the content encoding or content type of a remote resource
+	Offset               int64               // byte offset of the (compressed) resource to start reading from
+	IfRange              string              // ETag or Last-Modified date the resource must match when reading from an offset
+	AzureBlobClient      *azblob.Client      // Azure Blob Storage Client, defaults to a client using credentials from the environment
+	GCSClient            *gcs.Client         // Google Cloud Storage Client, defaults to a client using credentials from the environment
+	S3Client             *s3.S3              // AWS S3 Client
+	RequestPayer         bool                // confirm that the requester pays for reading from a requester pays bucket on AWS S3
+	SSHClient            *ssh.Client         // SSH Client
+	SFTPClient           *sftp.Client        // SFTP Client
+	SFTPPool             *sftp2.Pool         // if not nil and no SSH or SFTP client is set, then SFTP clients are taken from the pool
+	Password             string              // password
+	PrivateKey           []byte              // private key
+	PrivateKeyPassphrase []byte              // passphrase of an encrypted private key
+	SSHAgentSocket       string              // path to the socket of a SSH agent used for authentication, such as the value of SSH_AUTH_SOCK
+	SSHConfig            *ssh2.Config        // if not nil, then the host in a SFTP uri is resolved using the ssh config
+	HostKeyCallback      ssh.HostKeyCallback // callback for verifying the host key of a SSH server, defaults to the known_hosts file of the user
+	JumpHosts            []string            // jump hosts used to reach a SSH server, each as a ssh uri or [user@]host[:port], overrides the ProxyJump in the ssh config
+	JumpHostKeyCallback  ssh.HostKeyCallback // callback for verifying the host keys of jump hosts, defaults to the known_hosts files for the jump host
+	Retry                *retry.Policy       // policy for retrying failed reads from remote resources
+	Context              context.Context     // context for requests to remote resources and waits between retries, defaults to context.Background()
 }
 
 type ReadFromResourceOutput struct {
@@ -48,60 +73,468 @@ type ReadFromResourceOutput struct {
 	Metadata *Metadata
 }
 
-func fetchRemoteFile(uri string, password string, privateKeyBytes []byte, sshClient *ssh.Client, sftpClient *sftp.Client) (io.ReadCloser, error) {
+// ctx returns the context of the input, or the background context if not set.
+func (input *ReadFromResourceInput) ctx() context.Context {
+	if input.Context == nil {
+		return context.Background()
+	}
+	return input.Context
+}
+
+// sshClientOptionsInput returns the input for the options used to connect to a SSH server.
+func (input *ReadFromResourceInput) sshClientOptionsInput() *NewSSHClientOptionsInput {
+	return &NewSSHClientOptionsInput{
+		Password:             input.Password,
+		PrivateKey:           input.PrivateKey,
+		PrivateKeyPassphrase: input.PrivateKeyPassphrase,
+		SSHAgentSocket:       input.SSHAgentSocket,
+		SSHConfig:            input.SSHConfig,
+		HostKeyCallback:      input.HostKeyCallback,
+		JumpHosts:            input.JumpHosts,
+		JumpHostKeyCallback:  input.JumpHostKeyCallback,
+	}
+}
+
+// resourceOptions returns the clients and credentials of the input as resource options, such as for Glob.
+func (input *ReadFromResourceInput) resourceOptions() *ResourceOptions {
+	return &ResourceOptions{
+		AzureBlobClient:      input.AzureBlobClient,
+		GCSClient:            input.GCSClient,
+		S3Client:             input.S3Client,
+		RequestPayer:         input.RequestPayer,
+		SSHClient:            input.SSHClient,
+		SFTPClient:           input.SFTPClient,
+		SFTPPool:             input.SFTPPool,
+		Password:             input.Password,
+		PrivateKey:           input.PrivateKey,
+		PrivateKeyPassphrase: input.PrivateKeyPassphrase,
+		SSHAgentSocket:       input.SSHAgentSocket,
+		SSHConfig:            input.SSHConfig,
+		HostKeyCallback:      input.HostKeyCallback,
+		JumpHosts:            input.JumpHosts,
+		JumpHostKeyCallback:  input.JumpHostKeyCallback,
+		Retry:                input.Retry,
+	}
+}
+
+// fetchRemoteFile opens the remote file at the offset.
+// If ifRange is set, then a HTTP or WebDAV resource is only read from the offset if the resource still matches the validator.
+func fetchRemoteFile(input *ReadFromResourceInput, offset int64, ifRange string, sshClient *ssh.Client, sftpClient *sftp.Client) (io.ReadCloser, *Metadata, error) {
+	uri := input.URI
 	switch scheme, fullpath := splitter.SplitURI(uri); scheme {
 	case schemes.SchemeFTP:
-		return ftp.Fetch(uri)
+		r, err := ftp.FetchFrom(uri, offset)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, nil, nil
 	case schemes.SchemeSFTP:
+		if sshClient == nil && sftpClient == nil && input.SFTPPool != nil {
+			return openPooledSFTPFile(input, offset)
+		}
 		if sshClient == nil {
-			options := []ssh2.ClientOption{}
-			if len(privateKeyBytes) > 0 {
-				privateKey, err := ssh.ParsePrivateKey(privateKeyBytes)
-				if err != nil {
-					return nil, fmt.Errorf("error parsing private SSH key: %w", err)
-				}
-				options = append(options, func(config *ssh2.ClientConfig) error {
-					config.Auth = []ssh.AuthMethod{
-						ssh.PublicKeys(privateKey),
-					}
-					return nil
-				})
-			} else if len(password) > 0 {
-				options = append(options, func(config *ssh2.ClientConfig) error {
-					config.Auth = []ssh.AuthMethod{
-						ssh.Password(password),
-					}
-					return nil
-				})
-			}
-			c, err := ssh2.Dial(uri, options...)
+			c, err := dialSSH(uri, input.sshClientOptionsInput())
 			if err != nil {
-				return nil, fmt.Errorf("error creating SSH client: %w", err)
+				return nil, nil, err
 			}
-			sshClient = c.Client
+			sshClient = c
 		}
 		if sftpClient == nil {
 			c, err := sftp.NewClient(sshClient)
 			if err != nil {
-				return nil, fmt.Errorf("error creating SFTP client: %w", err)
+				_ = sshClient.Close() // attempt to close the underlying SSH connection
+				return nil, nil, fmt.Errorf("error creating SFTP client: %w", err)
 			}
 			sftpClient = c
 		}
 		parts := strings.SplitN(fullpath, "/", 2)
 		f, err := sftpClient.Open(parts[1])
 		if err != nil {
-			return nil, fmt.Errorf("error opening file: %w", err)
+			_ = sftpClient.Close() // attempt to close the underlying SFTP connection
+			_ = sshClient.Close()  // attempt to close the underlying SSH connection
+			return nil, nil, fmt.Errorf("error opening file: %w", err)
+		}
+		if offset > 0 {
+			_, err = f.Seek(offset, stdio.SeekStart)
+			if err != nil {
+				_ = f.Close()
+				_ = sftpClient.Close() // attempt to close the underlying SFTP connection
+				_ = sshClient.Close()  // attempt to close the underlying SSH connection
+				return nil, nil, fmt.Errorf("error seeking to offset %d: %w", offset, err)
+			}
+		}
+		return sftp2.NewReader(f, sftpClient, sshClient), nil, nil
+	case schemes.SchemeSSH, schemes.SchemeSSHCommand:
+		if sshClient == nil {
+			c, err := dialSSH(uri, input.sshClientOptionsInput())
+			if err != nil {
+				return nil, nil, err
+			}
+			sshClient = c
+		}
+		r, err := openSSHReader(uri, sshClient, offset)
+		if err != nil {
+			_ = sshClient.Close() // attempt to close the underlying SSH connection
+			return nil, nil, err
 		}
-		return sftp2.NewReader(f, sftpClient, sshClient), nil
+		return r, nil, nil
 	case schemes.SchemeHTTP, schemes.SchemeHTTPS:
-		return http.Fetch(uri)
+		response, err := http.Get(&http.GetInput{URI: uri, Offset: offset, IfRange: ifRange, Context: input.ctx()})
+		if err != nil {
+			if errors.Is(err, http.ErrResourceChanged) {
+				return nil, nil, fmt.Errorf("error fetching file at uri %q: %w", uri, ErrResourceChanged)
+			}
+			return nil, nil, err
+		}
+		metadata := NewMetadataFromHeader(response.Header)
+		metadata.Uncompressed = response.Uncompressed
+		return response.Body, metadata, nil
+	case schemes.SchemeWebDAV, schemes.SchemeWebDAVS:
+		response, err := webdav.Get(&http.GetInput{URI: uri, Offset: offset, IfRange: ifRange, Context: input.ctx()})
+		if err != nil {
+			if errors.Is(err, http.ErrResourceChanged) {
+				return nil, nil, fmt.Errorf("error fetching file at uri %q: %w", uri, ErrResourceChanged)
+			}
+			return nil, nil, err
+		}
+		metadata := NewMetadataFromHeader(response.Header)
+		metadata.Uncompressed = response.Uncompressed
+		return response.Body, metadata, nil
 	}
-	return nil, nil
+	return nil, nil, nil
+}
+
+// openPooledSFTPFile opens the file at the uri from the offset using a SFTP client from the pool in the input.
+// The client is released to the pool when the reader is closed.
+func openPooledSFTPFile(input *ReadFromResourceInput, offset int64) (io.ReadCloser, *Metadata, error) {
+	options, err := NewSSHClientOptions(input.sshClientOptionsInput())
+	if err != nil {
+		return nil, nil, err
+	}
+	sftpClient, err := input.SFTPPool.Get(input.URI, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, fullpath := splitter.SplitURI(input.URI)
+	f, err := sftpClient.Open(strings.SplitN(fullpath, "/", 2)[1])
+	if err != nil {
+		_ = input.SFTPPool.Release(sftpClient)
+		return nil, nil, fmt.Errorf("error opening file: %w", err)
+	}
+	if offset > 0 {
+		_, err = f.Seek(offset, stdio.SeekStart)
+		if err != nil {
+			_ = f.Close()
+			_ = input.SFTPPool.Release(sftpClient)
+			return nil, nil, fmt.Errorf("error seeking to offset %d: %w", offset, err)
+		}
+	}
+	return sftp2.NewPooledReader(f, input.SFTPPool, sftpClient), nil, nil
+}
+
+// openSSHReader starts the command that reads the file at the uri from the offset,
+// or for a SSH command uri, the command in the uri.
+// The output of a command cannot be read from an offset.
+func openSSHReader(uri string, sshClient *ssh.Client, offset int64) (*ssh2.Reader, error) {
+	scheme, fullpath := splitter.SplitURI(uri)
+	if scheme == schemes.SchemeSSHCommand {
+		if offset > 0 {
+			return nil, fmt.Errorf("error reading output of command at uri %q: cannot read from offset %d", uri, offset)
+		}
+		command, err := ssh2.ParseCommand(uri)
+		if err != nil {
+			return nil, err
+		}
+		return ssh2.NewReader(sshClient, command)
+	}
+	parts := strings.SplitN(fullpath, "/", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return nil, fmt.Errorf("error reading file at uri %q: missing path", uri)
+	}
+	return ssh2.NewFileReader(sshClient, parts[1], offset)
+}
+
+// openRemoteFile opens the remote file at the offset given as input, retrying as set by the policy.
+// The SSH and SFTP clients provided as input are only used for the first attempt,
+// since the clients are closed when the returned reader is closed.
+// When a HTTP or WebDAV resource is reopened, the validator of the first response is sent as If-Range,
+// so the resource cannot change while reading.  The metadata is from the first response.
+func openRemoteFile(input *ReadFromResourceInput) (io.ReadCloser, *Metadata, error) {
+	sshClient, sftpClient := input.SSHClient, input.SFTPClient
+	var metadata *Metadata
+	opened := false
+	validator := input.IfRange
+	open := func(offset int64) (stdio.ReadCloser, error) {
+		var r io.ReadCloser
+		err := input.Retry.Do(input.ctx(), func() error {
+			rc, m, err := fetchRemoteFile(input, offset, validator, sshClient, sftpClient)
+			// do not reuse the clients provided as input
+			sshClient, sftpClient = nil, nil
+			if err != nil {
+				return err
+			}
+			if !opened {
+				opened, metadata = true, m
+				if v := m.Validator(); len(v) > 0 {
+					validator = v
+				}
+			}
+			r = rc
+			return nil
+		})
+		return r, err
+	}
+	r, err := open(input.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if input.Retry == nil {
+		return r, metadata, nil
+	}
+	return retry.NewReader(input.ctx(), r, input.Offset, open, input.Retry), metadata, nil
+}
+
+// resolveAlg returns the algorithm used to decode the resource.
+// If the algorithm is not set and DetectAlg is true, then the algorithm is chosen from the metadata of the resource.
+func resolveAlg(input *ReadFromResourceInput, metadata *Metadata) (string, error) {
+	if len(input.Alg) > 0 || !input.DetectAlg {
+		return input.Alg, nil
+	}
+	return metadata.Algorithm()
+}
+
+// splitS3Path splits the path of a s3:// uri into a bucket, key, and version ID.
+// A specific version of the object is read using the "versionId" query parameter, e.g., s3://bucket/key?versionId=abc.
+func splitS3Path(p string) (string, string, string, error) {
+	i := strings.Index(p, "/")
+	if i == -1 {
+		return "", "", "", errors.New("path missing bucket")
+	}
+	bucket, key := p[0:i], p[i+1:]
+	if j := strings.LastIndex(key, "?"); j != -1 {
+		if query, err := url.ParseQuery(key[j+1:]); err == nil {
+			if versionID := query.Get("versionId"); len(versionID) > 0 {
+				return bucket, key[0:j], versionID, nil
+			}
+		}
+	}
+	return bucket, key, "", nil
+}
+
+// getS3Object returns the object on AWS S3 starting at the given offset.
+// If the offset is greater than zero and the validator is set,
+// then the object must still match the ETag or Last-Modified date given by the validator.
+func getS3Object(ctx context.Context, client *s3.S3, bucket string, key string, versionID string, requestPayer bool, offset int64, validator string) (*s3.GetObjectOutput, error) {
+	getObjectInput := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	if len(versionID) > 0 {
+		getObjectInput.VersionId = aws.String(versionID)
+	}
+	if requestPayer {
+		getObjectInput.RequestPayer = aws.String(s3.RequestPayerRequester)
+	}
+	if offset > 0 {
+		getObjectInput.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+		if len(validator) > 0 {
+			if t, err := stdhttp.ParseTime(validator); err == nil {
+				getObjectInput.IfUnmodifiedSince = aws.Time(t)
+			} else {
+				getObjectInput.IfMatch = aws.String(validator)
+			}
+		}
+	}
+	// request the stored bytes, so the body is not transparently decompressed when the object has a content encoding.
+	output, err := client.GetObjectWithContext(ctx, getObjectInput, request.WithSetRequestHeaders(map[string]string{
+		"Accept-Encoding": "identity",
+	}))
+	if err != nil {
+		var requestFailure awserr.RequestFailure
+		if errors.As(err, &requestFailure) {
+			switch requestFailure.StatusCode() {
+			case stdhttp.StatusPreconditionFailed:
+				return nil, ErrResourceChanged
+			case stdhttp.StatusRequestedRangeNotSatisfiable:
+				if offset > 0 {
+					// the offset is at or beyond the end of the object
+					return &s3.GetObjectOutput{Body: stdhttp.NoBody, ContentLength: aws.Int64(0)}, nil
+				}
+			}
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
+// getGCSObject returns the object on Google Cloud Storage and its metadata.
+// The generation of the object is pinned, so that retries read the same object.
+func getGCSObject(input *ReadFromResourceInput, p string) (io.ReadCloser, *Metadata, error) {
+	bucket, object, err := gcs.SplitPath(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	client := input.GCSClient
+	if client == nil {
+		client, err = gcs.NewClient(&gcs.NewClientInput{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("error creating Google Cloud Storage client: %w", err)
+		}
+	}
+	var attrs *gcs.ObjectAttrs
+	err = input.Retry.Do(input.ctx(), func() error {
+		exists, a, errStat := gcs.Stat(input.ctx(), client, bucket, object)
+		if errStat != nil {
+			return errStat
+		}
+		if !exists {
+			return fmt.Errorf("object %q in bucket %q does not exist", object, bucket)
+		}
+		attrs = a
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata := NewMetadataFromGCS(attrs)
+	if input.Offset > 0 && len(input.IfRange) > 0 && input.IfRange != metadata.ETag && input.IfRange != metadata.Validator() {
+		return nil, nil, ErrResourceChanged
+	}
+	open := func(offset int64) (stdio.ReadCloser, error) {
+		var r stdio.ReadCloser
+		errOpen := input.Retry.Do(input.ctx(), func() error {
+			rc, errGetObject := gcs.GetObject(&gcs.GetObjectInput{
+				Client:     client,
+				Bucket:     bucket,
+				Object:     object,
+				Offset:     offset,
+				Generation: attrs.Generation,
+				Context:    input.ctx(),
+			})
+			if errGetObject != nil {
+				if errors.Is(errGetObject, http.ErrResourceChanged) {
+					return ErrResourceChanged
+				}
+				return errGetObject
+			}
+			r = rc
+			return nil
+		})
+		return r, errOpen
+	}
+	r, err := open(input.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if input.Retry == nil {
+		return r, metadata, nil
+	}
+	return retry.NewReader(input.ctx(), r, input.Offset, open, input.Retry), metadata, nil
+}
+
+// getAzureBlob returns the blob on Azure Blob Storage and its metadata.
+// The ETag of the blob is pinned, so that retries read the same blob.
+func getAzureBlob(input *ReadFromResourceInput, client *azblob.Client, container string, blob string) (io.ReadCloser, *Metadata, error) {
+	var props *azblob.BlobProperties
+	err := input.Retry.Do(input.ctx(), func() error {
+		exists, p, errStat := azblob.Stat(input.ctx(), client, container, blob)
+		if errStat != nil {
+			return errStat
+		}
+		if !exists {
+			return fmt.Errorf("blob %q in container %q does not exist", blob, container)
+		}
+		props = p
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata := NewMetadataFromAzureBlob(props)
+	if input.Offset > 0 && len(input.IfRange) > 0 && input.IfRange != metadata.ETag && input.IfRange != metadata.Validator() {
+		return nil, nil, ErrResourceChanged
+	}
+	open := func(offset int64) (stdio.ReadCloser, error) {
+		var r stdio.ReadCloser
+		errOpen := input.Retry.Do(input.ctx(), func() error {
+			rc, errGetBlob := azblob.GetBlob(&azblob.GetBlobInput{
+				Client:    client,
+				Container: container,
+				Blob:      blob,
+				Offset:    offset,
+				ETag:      props.ETag,
+				Context:   input.ctx(),
+			})
+			if errGetBlob != nil {
+				if errors.Is(errGetBlob, http.ErrResourceChanged) {
+					return ErrResourceChanged
+				}
+				return errGetBlob
+			}
+			r = rc
+			return nil
+		})
+		return r, errOpen
+	}
+	r, err := open(input.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if input.Retry == nil {
+		return r, metadata, nil
+	}
+	return retry.NewReader(input.ctx(), r, input.Offset, open, input.Retry), metadata, nil
+}
+
+// azureBlobClient returns the client and the container and blob name for the azblob:// uri
+// or https url of a blob on Azure Blob Storage.
+// If the client is nil, then a new client is created using credentials from the environment.
+// For https urls, the endpoint of the url is used along with the shared access signature in the url, if any.
+func azureBlobClient(client *azblob.Client, uri string) (*azblob.Client, string, string, error) {
+	client, container, blob, err := azureBlobContainerClient(client, uri)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if len(blob) == 0 {
+		return nil, "", "", fmt.Errorf("error parsing uri %q: path is missing blob", uri)
+	}
+	return client, container, blob, nil
+}
+
+// azureBlobContainerClient returns the client and the container and blob name for the uri, like azureBlobClient,
+// but the blob name is blank if the uri only includes the container.
+func azureBlobContainerClient(client *azblob.Client, uri string) (*azblob.Client, string, string, error) {
+	if u, ok := azblob.ParseBlobURL(uri); ok {
+		if client == nil || client.Endpoint != u.Endpoint || len(u.SASToken) > 0 {
+			c, err := azblob.NewClient(&azblob.NewClientInput{Endpoint: u.Endpoint, SASToken: u.SASToken})
+			if err != nil {
+				return nil, "", "", fmt.Errorf("error creating Azure Blob Storage client: %w", err)
+			}
+			client = c
+		}
+		return client, u.Container, u.Blob, nil
+	}
+	_, p := splitter.SplitURI(uri)
+	container, blob, err := azblob.SplitPath(p)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if client == nil {
+		c, errClient := azblob.NewClient(&azblob.NewClientInput{})
+		if errClient != nil {
+			return nil, "", "", fmt.Errorf("error creating Azure Blob Storage client: %w", errClient)
+		}
+		client = c
+	}
+	return client, container, blob, nil
 }
 
 func ReadFromResource(input *ReadFromResourceInput) (*ReadFromResourceOutput, error) {
 
 	if input.URI == "-" {
+		if input.Offset > 0 {
+			return nil, errors.New("cannot read from stdin starting at an offset")
+		}
 		wr, err := WrapReader(os.Stdin, input.Alg, input.Dict, input.BufferSize)
 		if err != nil {
 			return nil, fmt.Errorf("error wrapping reader for stdin: %w", err)
@@ -111,7 +544,30 @@ func ReadFromResource(input *ReadFromResourceInput) (*ReadFromResourceOutput, er
 
 	scheme, path := splitter.SplitURI(input.URI)
 
+	if _, ok := azblob.ParseBlobURL(input.URI); ok {
+		scheme = schemes.SchemeAzureBlob
+	}
+
 	switch scheme {
+	case schemes.SchemeAzureBlob:
+		client, container, blob, err := azureBlobClient(input.AzureBlobClient, input.URI)
+		if err != nil {
+			return nil, err
+		}
+		r, metadata, err := getAzureBlob(input, client, container, blob)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching file on Azure Blob Storage at uri %q: %w", input.URI, err)
+		}
+		alg, err := resolveAlg(input, metadata)
+		if err != nil {
+			_ = r.Close()
+			return nil, fmt.Errorf("error reading file at uri %q: %w", input.URI, err)
+		}
+		wr, err := WrapReader(r, alg, input.Dict, input.BufferSize)
+		if err != nil {
+			return nil, fmt.Errorf("error wrapping reader for file at uri %q: %w", input.URI, err)
+		}
+		return &ReadFromResourceOutput{Reader: wr, Metadata: metadata}, nil
 	case schemes.SchemeFile, "":
 		pathExpanded, err := homedir.Expand(path)
 		if err != nil {
@@ -122,38 +578,91 @@ func ReadFromResource(input *ReadFromResourceInput) (*ReadFromResourceOutput, er
 		if err != nil {
 			return nil, fmt.Errorf("error opening regular file: %w", err)
 		}
+		if input.Offset > 0 {
+			_, err = f.Seek(input.Offset, stdio.SeekStart)
+			if err != nil {
+				_ = f.Close()
+				return nil, fmt.Errorf("error seeking to offset %d in file at uri %q: %w", input.Offset, input.URI, err)
+			}
+		}
 		wr, err := WrapReader(f, input.Alg, input.Dict, input.BufferSize)
 		if err != nil {
 			return nil, fmt.Errorf("error wrapping reader for file at uri %q: %w", input.URI, err)
 		}
 		return &ReadFromResourceOutput{Reader: wr, Metadata: nil}, nil
-	case schemes.SchemeFTP, schemes.SchemeSFTP, schemes.SchemeHTTP, schemes.SchemeHTTPS:
-		r, err := fetchRemoteFile(input.URI, input.Password, input.PrivateKey, input.SSHClient, input.SFTPClient)
+	case schemes.SchemeFTP, schemes.SchemeSFTP, schemes.SchemeSSH, schemes.SchemeSSHCommand, schemes.SchemeHTTP, schemes.SchemeHTTPS, schemes.SchemeWebDAV, schemes.SchemeWebDAVS:
+		r, metadata, err := openRemoteFile(input)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching remote file at uri %q: %w", input.URI, err)
 		}
-		wr, err := WrapReader(r, input.Alg, input.Dict, input.BufferSize)
+		alg, err := resolveAlg(input, metadata)
+		if err != nil {
+			_ = r.Close()
+			return nil, fmt.Errorf("error reading file at uri %q: %w", input.URI, err)
+		}
+		wr, err := WrapReader(r, alg, input.Dict, input.BufferSize)
 		if err != nil {
 			return nil, fmt.Errorf("error wrapping reader for file at uri %q: %w", input.URI, err)
 		}
-		return &ReadFromResourceOutput{Reader: wr, Metadata: nil}, nil
+		return &ReadFromResourceOutput{Reader: wr, Metadata: metadata}, nil
+	case schemes.SchemeGCS:
+		r, metadata, err := getGCSObject(input, path)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching file on Google Cloud Storage at uri %q: %w", input.URI, err)
+		}
+		alg, err := resolveAlg(input, metadata)
+		if err != nil {
+			_ = r.Close()
+			return nil, fmt.Errorf("error reading file at uri %q: %w", input.URI, err)
+		}
+		wr, err := WrapReader(r, alg, input.Dict, input.BufferSize)
+		if err != nil {
+			return nil, fmt.Errorf("error wrapping reader for file at uri %q: %w", input.URI, err)
+		}
+		return &ReadFromResourceOutput{Reader: wr, Metadata: metadata}, nil
 	case schemes.SchemeS3:
-		i := strings.Index(path, "/")
-		if i == -1 {
-			return nil, errors.New("path missing bucket")
+		bucket, key, versionID, err := splitS3Path(path)
+		if err != nil {
+			return nil, err
 		}
-		r, err := input.S3Client.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(path[0:i]),
-			Key:    aws.String(path[i+1:]),
-		})
+		if input.S3Client == nil {
+			return nil, fmt.Errorf("error fetching file on AWS S3 at uri %q: missing AWS S3 client", input.URI)
+		}
+		r, err := getS3Object(input.ctx(), input.S3Client, bucket, key, versionID, input.RequestPayer, input.Offset, input.IfRange)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching file on AWS S3 at uri %q: %w", input.URI, err)
 		}
-		wr, err := WrapReader(r.Body, input.Alg, input.Dict, input.BufferSize)
+		body := r.Body
+		if input.Retry != nil {
+			// the AWS SDK retries requests, so only reopen the object when a read fails mid-stream.
+			// the ETag of the object is used as the validator, so the object cannot change while reading.
+			// if the bucket is versioned, then the version read first is pinned as well.
+			validator := input.IfRange
+			if r.ETag != nil {
+				validator = *r.ETag
+			}
+			if r.VersionId != nil && *r.VersionId != "null" {
+				versionID = *r.VersionId
+			}
+			body = retry.NewReader(input.ctx(), r.Body, input.Offset, func(offset int64) (stdio.ReadCloser, error) {
+				output, errGetObject := getS3Object(input.ctx(), input.S3Client, bucket, key, versionID, input.RequestPayer, offset, validator)
+				if errGetObject != nil {
+					return nil, errGetObject
+				}
+				return output.Body, nil
+			}, input.Retry)
+		}
+		metadata := NewMetadataFromS3(r)
+		alg, err := resolveAlg(input, metadata)
+		if err != nil {
+			_ = body.Close()
+			return nil, fmt.Errorf("error reading file at uri %q: %w", input.URI, err)
+		}
+		wr, err := WrapReader(body, alg, input.Dict, input.BufferSize)
 		if err != nil {
 			return nil, fmt.Errorf("error wrapping reader for file at uri %q: %w", input.URI, err)
 		}
-		return &ReadFromResourceOutput{Reader: wr, Metadata: NewMetadataFromS3(r)}, nil
+		return &ReadFromResourceOutput{Reader: wr, Metadata: metadata}, nil
 	}
 
 	return nil, &schemes.ErrUnknownScheme{Scheme: scheme}
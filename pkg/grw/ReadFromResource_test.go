@@ -8,12 +8,32 @@
 package grw
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 
 	pkgalg "github.com/spatialcurrent/go-reader-writer/pkg/alg"
 	"github.com/spatialcurrent/go-reader-writer/pkg/io"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
 )
 
 func TestReadFromResourceDocTxt(t *testing.T) {
@@ -100,3 +120,417 @@ func TestReadFromResourceDocTxtZip(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, BytesHelloWorld, got)
 }
+
+func TestReadFromResourceHTTPRetry(t *testing.T) {
+	data := bytes.Repeat(BytesHelloWorld, 100)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		offset := 0
+		if rng := r.Header.Get("Range"); len(rng) > 0 {
+			offset, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(data)-1, len(data)))
+		}
+		// declare the full length, but only send part of the remaining bytes to fail the read mid-stream.
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)-offset))
+		if offset > 0 {
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		end := offset + 300
+		if end > len(data) {
+			end = len(data)
+		}
+		_, _ = w.Write(data[offset:end])
+	}))
+	defer server.Close()
+
+	output, err := ReadFromResource(&ReadFromResourceInput{
+		URI:        server.URL + "/doc.txt",
+		Alg:        pkgalg.AlgorithmNone,
+		BufferSize: 4096,
+		Retry:      &retry.Policy{Attempts: 3, BaseDelay: time.Millisecond},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, output.Reader)
+
+	got, err := io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+	assert.Equal(t, 5, requests)
+}
+
+func TestReadFromResourceHTTPChanged(t *testing.T) {
+	data := bytes.Repeat(BytesHelloWorld, 100)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// the resource changes after the first response
+		w.Header().Set("ETag", fmt.Sprintf("\"v%d\"", requests))
+		if rng := r.Header.Get("Range"); len(rng) > 0 {
+			if r.Header.Get("If-Range") != "\"v1\"" {
+				t.Errorf("unexpected If-Range header %q", r.Header.Get("If-Range"))
+			}
+			// the validator does not match, so the entire resource is sent
+			_, _ = w.Write(data)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		_, _ = w.Write(data[:300])
+	}))
+	defer server.Close()
+
+	output, err := ReadFromResource(&ReadFromResourceInput{
+		URI:   server.URL + "/doc.txt",
+		Alg:   pkgalg.AlgorithmNone,
+		Retry: &retry.Policy{Attempts: 3, BaseDelay: time.Millisecond},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "\"v1\"", output.Metadata.ETag)
+
+	_, err = io.ReadAllAndClose(output.Reader)
+	assert.ErrorIs(t, err, ErrResourceChanged)
+	assert.Equal(t, 2, requests)
+}
+
+func TestReadFromResourceHTTPCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// the read is canceled while the server is unavailable
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	_, err := ReadFromResource(&ReadFromResourceInput{
+		URI:     server.URL + "/doc.txt",
+		Alg:     pkgalg.AlgorithmNone,
+		Retry:   &retry.Policy{Attempts: 3, BaseDelay: time.Hour},
+		Context: ctx,
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, requests)
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestReadFromResourceOffset(t *testing.T) {
+	output, err := ReadFromResource(&ReadFromResourceInput{
+		URI:        "file://../../testdata/doc.txt",
+		Alg:        pkgalg.AlgorithmNone,
+		Dict:       NoDict,
+		BufferSize: 4096,
+		Offset:     6,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, output.Reader)
+
+	got, err := io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("world"), got)
+}
+
+func TestReadFromResourceHTTPOffset(t *testing.T) {
+	lastModified := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "doc.txt", lastModified, bytes.NewReader(BytesHelloWorld))
+	}))
+	defer server.Close()
+
+	output, err := ReadFromResource(&ReadFromResourceInput{
+		URI:        server.URL + "/doc.txt",
+		Alg:        pkgalg.AlgorithmNone,
+		BufferSize: 4096,
+		Offset:     6,
+		IfRange:    `"v1"`,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, output.Reader)
+	require.NotNil(t, output.Metadata)
+	assert.Equal(t, `"v1"`, output.Metadata.ETag)
+	assert.Equal(t, `"v1"`, output.Metadata.Validator())
+	assert.Equal(t, lastModified, *output.Metadata.LastModified)
+
+	got, err := io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("world"), got)
+
+	// the resource has changed, since the ETag does not match.
+	output, err = ReadFromResource(&ReadFromResourceInput{
+		URI:        server.URL + "/doc.txt",
+		Alg:        pkgalg.AlgorithmNone,
+		BufferSize: 4096,
+		Offset:     6,
+		IfRange:    `"v0"`,
+	})
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, ErrResourceChanged)
+
+	// the offset is at the end of the resource.
+	output, err = ReadFromResource(&ReadFromResourceInput{
+		URI:        server.URL + "/doc.txt",
+		Alg:        pkgalg.AlgorithmNone,
+		BufferSize: 4096,
+		Offset:     int64(len(BytesHelloWorld)),
+		IfRange:    `"v1"`,
+	})
+	require.NoError(t, err)
+	got, err = io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestReadFromResourceS3(t *testing.T) {
+	isolateAWSEnvironment(t, "", "")
+
+	versions := map[string][]byte{
+		"v1": []byte("hello"),
+		"v2": BytesHelloWorld,
+	}
+	requests := []*http.Request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if r.URL.Path != "/bucket/a/b.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		versionID := r.URL.Query().Get("versionId")
+		if len(versionID) == 0 {
+			versionID = "v2"
+		}
+		body, ok := versions[versionID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", fmt.Sprintf("%q", versionID))
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("x-amz-version-id", versionID)
+		w.Header().Set("x-amz-storage-class", "STANDARD_IA")
+		w.Header().Set("x-amz-server-side-encryption", "aws:kms")
+		w.Header().Set("x-amz-server-side-encryption-aws-kms-key-id", "alias/example")
+		w.Header().Set("x-amz-meta-source", "grw")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client, _, err := NewS3Client(&NewS3ClientInput{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		Endpoint:        server.URL,
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+
+	output, err := ReadFromResource(&ReadFromResourceInput{
+		URI:      "s3://bucket/a/b.txt",
+		Alg:      pkgalg.AlgorithmNone,
+		S3Client: client,
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+	assert.Equal(t, `"v2"`, output.Metadata.ETag)
+	assert.Equal(t, "v2", output.Metadata.VersionID)
+	assert.Equal(t, "text/plain", output.Metadata.ContentType)
+	assert.Equal(t, "no-cache", output.Metadata.CacheControl)
+	assert.Equal(t, "STANDARD_IA", output.Metadata.StorageClass)
+	assert.Equal(t, "aws:kms", output.Metadata.ServerSideEncryption)
+	assert.Equal(t, "alias/example", output.Metadata.SSEKMSKeyID)
+	assert.Equal(t, map[string]string{"Source": "grw"}, output.Metadata.UserMetadata)
+	assert.Empty(t, requests[len(requests)-1].Header.Get("x-amz-request-payer"))
+
+	output, err = ReadFromResource(&ReadFromResourceInput{
+		URI:          "s3://bucket/a/b.txt?versionId=v1",
+		Alg:          pkgalg.AlgorithmNone,
+		RequestPayer: true,
+		Retry:        &retry.Policy{Attempts: 2},
+		S3Client:     client,
+	})
+	require.NoError(t, err)
+	got, err = io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), got)
+	assert.Equal(t, "v1", output.Metadata.VersionID)
+	assert.Equal(t, "requester", requests[len(requests)-1].Header.Get("x-amz-request-payer"))
+
+	_, err = ReadFromResource(&ReadFromResourceInput{
+		URI:      "s3://bucket/a/b.txt?versionId=v3",
+		Alg:      pkgalg.AlgorithmNone,
+		S3Client: client,
+	})
+	assert.Error(t, err)
+}
+
+func TestNewMetadataFromS3(t *testing.T) {
+	m := NewMetadataFromS3(&s3.GetObjectOutput{VersionId: aws.String("null")})
+	assert.Equal(t, &Metadata{}, m)
+}
+
+func TestReadFromResourceDetectAlg(t *testing.T) {
+	compressed := &bytes.Buffer{}
+	gw := gzip.NewWriter(compressed)
+	_, err := gw.Write(BytesHelloWorld)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/content-type":
+			w.Header().Set("Content-Type", "application/gzip")
+		case "/content-encoding":
+			// the client requests the identity encoding, so the body is still compressed.
+			w.Header().Set("Content-Type", "application/gzip")
+			w.Header().Set("Content-Encoding", "gzip")
+		case "/brotli":
+			w.Header().Set("Content-Encoding", "br")
+		}
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	for _, p := range []string{"/content-type", "/content-encoding"} {
+		output, errOpen := ReadFromResource(&ReadFromResourceInput{
+			URI:       server.URL + p,
+			DetectAlg: true,
+		})
+		require.NoError(t, errOpen, p)
+		got, errRead := io.ReadAllAndClose(output.Reader)
+		assert.NoError(t, errRead, p)
+		assert.Equal(t, BytesHelloWorld, got, p)
+	}
+
+	output, err := ReadFromResource(&ReadFromResourceInput{
+		URI:       server.URL + "/content-type",
+		Alg:       pkgalg.AlgorithmNone,
+		DetectAlg: true,
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, compressed.Bytes(), got)
+
+	_, err = ReadFromResource(&ReadFromResourceInput{
+		URI:       server.URL + "/brotli",
+		DetectAlg: true,
+	})
+	assert.Error(t, err)
+}
+
+func TestReadFromResourceSSH(t *testing.T) {
+	server := ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.DisableSFTP = true
+	})
+	defer server.Close()
+
+	p := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(p, BytesHelloWorld, 0600))
+
+	output, err := ReadFromResource(&ReadFromResourceInput{
+		URI:             server.URI("ssh", p),
+		Alg:             pkgalg.AlgorithmNone,
+		Offset:          6,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld[6:], got)
+
+	// read the standard output of a command
+	output, err = ReadFromResource(&ReadFromResourceInput{
+		URI:             fmt.Sprintf("ssh+cmd://%s@%s?cmd=%s", server.User, server.Addr(), url.QueryEscape("tr a-z A-Z < "+p)),
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	got, err = io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.ToUpper(string(BytesHelloWorld)), string(got))
+
+	output, err = ReadFromResource(&ReadFromResourceInput{
+		URI:             server.URI("ssh", p+".missing"),
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	_, err = io.ReadAllAndClose(output.Reader)
+	assert.Error(t, err)
+}
+
+func TestReadFromResourceSFTP(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	p := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(p, BytesHelloWorld, 0600))
+
+	output, err := ReadFromResource(&ReadFromResourceInput{
+		URI:             server.URI("sftp", p),
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+
+	otherServer := ssh2test.NewServer()
+	defer otherServer.Close()
+	_, err = ReadFromResource(&ReadFromResourceInput{
+		URI:             server.URI("sftp", p),
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(otherServer.HostKey.PublicKey()),
+	})
+	assert.Error(t, err)
+
+	// resolve the host alias using a ssh config file
+	host, port, err := net.SplitHostPort(server.Addr())
+	require.NoError(t, err)
+	configPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf("Host myalias\n  HostName %s\n  Port %s\n  User %s\n", host, port, server.User)), 0600))
+	sshConfig, err := ssh2.LoadConfig(configPath)
+	require.NoError(t, err)
+	output, err = ReadFromResource(&ReadFromResourceInput{
+		URI:             "sftp://myalias/" + p,
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+		SSHConfig:       sshConfig,
+	})
+	require.NoError(t, err)
+	got, err = io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+
+	// connect through a jump host
+	bastion := ssh2test.NewServer()
+	defer bastion.Close()
+	output, err = ReadFromResource(&ReadFromResourceInput{
+		URI:                 server.URI("sftp", p),
+		Alg:                 pkgalg.AlgorithmNone,
+		Password:            server.Password,
+		HostKeyCallback:     ssh.FixedHostKey(server.HostKey.PublicKey()),
+		JumpHosts:           []string{fmt.Sprintf("%s:%s@%s", bastion.User, bastion.Password, bastion.Addr())},
+		JumpHostKeyCallback: ssh.FixedHostKey(bastion.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	got, err = io.ReadAllAndClose(output.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+	assert.Equal(t, 1, bastion.Handshakes())
+}
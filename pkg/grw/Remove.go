@@ -0,0 +1,326 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdos "os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	homedir "github.com/mitchellh/go-homedir"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ftp"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/webdav"
+	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+	"github.com/spatialcurrent/go-reader-writer/pkg/stat"
+)
+
+// removeEntry is a resource under a directory that is removed recursively.
+type removeEntry struct {
+	uri  string // uri of the resource
+	path string // path of the resource relative to the directory
+	dir  bool   // true if the resource is a directory
+}
+
+// Remove removes the resource at the uri, using the clients and credentials in the options, if any.
+// The options may be nil.
+//
+// Local files are removed with os.Remove, files on SFTP servers with the SFTP remove request,
+// and files on FTP servers with DELE.  HTTP and WebDAV resources are removed with a DELETE request.
+// Objects in AWS S3 are removed with DeleteObject, including a version of an object if the uri has a versionId.
+//
+// Directories and prefixes are only removed if the options are recursive.
+// The contents of a directory are removed before the directory itself, which is listed with List.
+// The objects under a prefix on AWS S3 are listed a page at a time and removed in batches with DeleteObjects,
+// including any objects that mark folders.  The bucket itself is never removed.
+//
+// If the options are a dry run, then nothing is removed, but OnRemove is still called for each resource.
+// Resources in Google Cloud Storage and Azure Blob Storage, and files on SSH servers, cannot be removed.
+// If the resource does not exist, then the error wraps os.ErrNotExist.
+func Remove(ctx context.Context, uri string, options *RemoveOptions) error {
+	if options == nil {
+		options = &RemoveOptions{}
+	}
+
+	if uri == "-" || uri == "stdin" {
+		return errors.New("error removing stdin: stdin cannot be removed")
+	}
+
+	scheme, fullpath := splitter.SplitURI(uri)
+
+	if _, ok := azblob.ParseBlobURL(uri); ok {
+		scheme = schemes.SchemeAzureBlob
+	}
+
+	if err := checkRemove(uri, scheme); err != nil {
+		return err
+	}
+
+	info, err := Stat(ctx, uri, &options.ResourceOptions)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() && !options.Recursive {
+		return fmt.Errorf("error removing resource at uri %q: resource is a directory and the removal is not recursive", uri)
+	}
+
+	switch scheme {
+	case schemes.SchemeFile, "":
+		err = removeFiles(ctx, uri, fullpath, info, options)
+	case schemes.SchemeSFTP:
+		err = removeSFTPFiles(ctx, uri, fullpath, info, options)
+	case schemes.SchemeFTP:
+		err = removeFTPFiles(ctx, uri, info, options)
+	case schemes.SchemeHTTP, schemes.SchemeHTTPS:
+		err = options.remove(uri, func() error {
+			return options.Retry.Do(ctx, func() error {
+				return http.Delete(ctx, uri)
+			})
+		})
+	case schemes.SchemeWebDAV, schemes.SchemeWebDAVS:
+		err = removeWebDAVResource(ctx, uri, info, options)
+	case schemes.SchemeS3:
+		err = removeS3Objects(ctx, uri, fullpath, info, options)
+	}
+	if err != nil {
+		return fmt.Errorf("error removing resource at uri %q: %w", uri, err)
+	}
+	return nil
+}
+
+// checkRemove returns an error if resources with the scheme cannot be removed.
+func checkRemove(uri string, scheme string) error {
+	switch scheme {
+	case schemes.SchemeFile, "", schemes.SchemeSFTP, schemes.SchemeFTP, schemes.SchemeHTTP, schemes.SchemeHTTPS,
+		schemes.SchemeWebDAV, schemes.SchemeWebDAVS, schemes.SchemeS3:
+		return nil
+	case schemes.SchemeGCS, schemes.SchemeAzureBlob, schemes.SchemeSSH, schemes.SchemeSSHCommand:
+		return fmt.Errorf("error removing resource at uri %q: resources with scheme %q cannot be removed", uri, scheme)
+	}
+	return &schemes.ErrUnknownScheme{Scheme: scheme}
+}
+
+// listRemoveEntries returns the resources under the directory at the uri, in the order they are listed.
+// If the resource at the uri is not a directory, then returns no resources.
+func listRemoveEntries(ctx context.Context, uri string, info *stat.ResourceInfo, options *ResourceOptions) ([]removeEntry, error) {
+	if !info.IsDir() {
+		return nil, nil
+	}
+	lister, err := List(ctx, uri, &ListOptions{ResourceOptions: *options, Recursive: true})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]removeEntry, 0)
+	for lister.Next() {
+		entries = append(entries, removeEntry{uri: lister.Entry().URI(), path: lister.Path(), dir: lister.Entry().IsDir()})
+	}
+	err = lister.Err()
+	errClose := lister.Close()
+	if err != nil {
+		return nil, err
+	}
+	if errClose != nil {
+		return nil, fmt.Errorf("error closing lister: %w", errClose)
+	}
+	return entries, nil
+}
+
+// removeEntries removes the resources under a directory, in reverse order so that the contents of each directory
+// are removed before the directory, and then removes the directory itself.
+// The function removes the resource at the path relative to the directory, where "" is the directory itself.
+func removeEntries(entries []removeEntry, options *RemoveOptions, f func(p string, dir bool) error) error {
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		err := options.remove(entry.uri, func() error {
+			return f(entry.path, entry.dir)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFiles removes a local file, or a local directory and its contents.
+func removeFiles(ctx context.Context, uri string, p string, info *stat.ResourceInfo, options *RemoveOptions) error {
+	root, err := homedir.Expand(p)
+	if err != nil {
+		return fmt.Errorf("error expanding file path %q: %w", p, err)
+	}
+	if fi, errLstat := stdos.Lstat(root); errLstat == nil && fi.Mode()&stdos.ModeSymlink != 0 {
+		// remove a symbolic link to a directory without removing the contents of the directory
+		return options.remove(uri, func() error {
+			return stdos.Remove(root)
+		})
+	}
+	entries, err := listRemoveEntries(ctx, uri, info, &options.ResourceOptions)
+	if err != nil {
+		return err
+	}
+	entries = append([]removeEntry{{uri: uri, dir: info.IsDir()}}, entries...)
+	return removeEntries(entries, options, func(p string, dir bool) error {
+		return stdos.Remove(filepath.Join(root, filepath.FromSlash(p)))
+	})
+}
+
+// removeSFTPFiles removes a file, or a directory and its contents, on a SFTP server.
+func removeSFTPFiles(ctx context.Context, uri string, fullpath string, info *stat.ResourceInfo, options *RemoveOptions) error {
+	client, release, err := options.sftpClient(ctx, uri)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = release() }()
+	root := remotePath(fullpath)
+	if fi, errLstat := client.Lstat(root); errLstat == nil && fi.Mode()&stdos.ModeSymlink != 0 {
+		// remove a symbolic link to a directory without removing the contents of the directory
+		return options.remove(uri, func() error {
+			return client.Remove(root)
+		})
+	}
+	resourceOptions := options.ResourceOptions
+	resourceOptions.SFTPClient = client // list the directory with the same client
+	entries, err := listRemoveEntries(ctx, uri, info, &resourceOptions)
+	if err != nil {
+		return err
+	}
+	entries = append([]removeEntry{{uri: uri, dir: info.IsDir()}}, entries...)
+	return removeEntries(entries, options, func(p string, dir bool) error {
+		if dir {
+			return client.RemoveDirectory(path.Join(root, p))
+		}
+		return client.Remove(path.Join(root, p))
+	})
+}
+
+// removeFTPFiles removes a file, or a directory and its contents, on a FTP server.
+func removeFTPFiles(ctx context.Context, uri string, info *stat.ResourceInfo, options *RemoveOptions) error {
+	entries, err := listRemoveEntries(ctx, uri, info, &options.ResourceOptions)
+	if err != nil {
+		return err
+	}
+	conn, root, err := ftp.Dial(ctx, uri)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Quit() }()
+	entries = append([]removeEntry{{uri: uri, dir: info.IsDir()}}, entries...)
+	return removeEntries(entries, options, func(p string, dir bool) error {
+		if dir {
+			return conn.RemoveDir(path.Join(root, p))
+		}
+		return conn.Delete(path.Join(root, p))
+	})
+}
+
+// removeWebDAVResource removes a WebDAV resource, or a collection and its members with a single DELETE request.
+func removeWebDAVResource(ctx context.Context, uri string, info *stat.ResourceInfo, options *RemoveOptions) error {
+	if options.OnRemove != nil {
+		entries, err := listRemoveEntries(ctx, uri, info, &options.ResourceOptions)
+		if err != nil {
+			return err
+		}
+		for i := len(entries) - 1; i >= 0; i-- {
+			options.OnRemove(entries[i].uri)
+		}
+	}
+	return options.remove(uri, func() error {
+		return options.Retry.Do(ctx, func() error {
+			return webdav.Remove(ctx, uri)
+		})
+	})
+}
+
+// maxDeleteObjects is the maximum number of objects that can be removed by a single DeleteObjects request.
+const maxDeleteObjects = 1000
+
+// removeS3Objects removes an object on AWS S3, or all the objects under a prefix.
+func removeS3Objects(ctx context.Context, uri string, fullpath string, info *stat.ResourceInfo, options *RemoveOptions) error {
+	bucket, key, versionID := fullpath, "", ""
+	if strings.Contains(fullpath, "/") {
+		b, k, v, err := splitS3Path(fullpath)
+		if err != nil {
+			return err
+		}
+		bucket, key, versionID = b, k, v
+	}
+
+	if !info.IsDir() {
+		return options.remove(uri, func() error {
+			input := &s3.DeleteObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			}
+			if len(versionID) > 0 {
+				input.VersionId = aws.String(versionID)
+			}
+			if options.RequestPayer {
+				input.RequestPayer = aws.String(s3.RequestPayerRequester)
+			}
+			_, err := options.S3Client.DeleteObjectWithContext(ctx, input)
+			return err
+		})
+	}
+
+	prefix := listPrefix(key, "")
+	var continuationToken *string
+	for {
+		listObjectsInput := &s3.ListObjectsV2Input{
+			Bucket:            aws.String(bucket),
+			Prefix:            aws.String(prefix),
+			MaxKeys:           aws.Int64(maxDeleteObjects),
+			ContinuationToken: continuationToken,
+		}
+		if options.RequestPayer {
+			listObjectsInput.RequestPayer = aws.String(s3.RequestPayerRequester)
+		}
+		listObjectsOutput, err := options.S3Client.ListObjectsV2WithContext(ctx, listObjectsInput)
+		if err != nil {
+			return fmt.Errorf("error listing objects with prefix %q: %w", prefix, err)
+		}
+		objects := make([]*s3.ObjectIdentifier, 0, len(listObjectsOutput.Contents))
+		for _, object := range listObjectsOutput.Contents {
+			if options.OnRemove != nil {
+				options.OnRemove(objectURI(uri, schemes.SchemeS3, bucket, aws.StringValue(object.Key)))
+			}
+			objects = append(objects, &s3.ObjectIdentifier{Key: object.Key})
+		}
+		if len(objects) > 0 && !options.DryRun {
+			deleteObjectsInput := &s3.DeleteObjectsInput{
+				Bucket: aws.String(bucket),
+				Delete: &s3.Delete{Objects: objects, Quiet: aws.Bool(true)},
+			}
+			if options.RequestPayer {
+				deleteObjectsInput.RequestPayer = aws.String(s3.RequestPayerRequester)
+			}
+			deleteObjectsOutput, errDelete := options.S3Client.DeleteObjectsWithContext(ctx, deleteObjectsInput)
+			if errDelete != nil {
+				return fmt.Errorf("error deleting objects with prefix %q: %w", prefix, errDelete)
+			}
+			if len(deleteObjectsOutput.Errors) > 0 {
+				e := deleteObjectsOutput.Errors[0]
+				return fmt.Errorf(
+					"error deleting %d objects with prefix %q, including object %q: %s: %s",
+					len(deleteObjectsOutput.Errors), prefix, aws.StringValue(e.Key), aws.StringValue(e.Code), aws.StringValue(e.Message))
+			}
+		}
+		if !aws.BoolValue(listObjectsOutput.IsTruncated) || listObjectsOutput.NextContinuationToken == nil {
+			return nil
+		}
+		continuationToken = listObjectsOutput.NextContinuationToken
+	}
+}
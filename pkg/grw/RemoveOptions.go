@@ -0,0 +1,27 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+// RemoveOptions contains the options for Remove, including the clients and credentials used to access the resources.
+type RemoveOptions struct {
+	ResourceOptions                  // clients and credentials used to access the resources
+	Recursive       bool             // remove directories and prefixes along with their contents
+	DryRun          bool             // do not remove any resources, but call OnRemove for each resource that would be removed
+	OnRemove        func(uri string) // if not nil, called with the uri of each resource before it is removed
+}
+
+// remove calls OnRemove with the uri, and then calls the function that removes the resource at the uri, unless this is a dry run.
+func (options *RemoveOptions) remove(uri string, f func() error) error {
+	if options.OnRemove != nil {
+		options.OnRemove(uri)
+	}
+	if options.DryRun {
+		return nil
+	}
+	return f()
+}
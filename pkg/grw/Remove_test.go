@@ -0,0 +1,74 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/grwtest"
+)
+
+func TestRemoveS3Prefix(t *testing.T) {
+	server := grwtest.NewS3Server()
+	defer server.Close()
+	require.NoError(t, server.CreateBucket("bucket"))
+
+	// more objects than fit in a single DeleteObjects request
+	require.NoError(t, os.MkdirAll(server.Path("bucket/logs/z"), 0750))
+	for i := 0; i < 1001; i++ {
+		require.NoError(t, os.WriteFile(server.Path(fmt.Sprintf("bucket/logs/%04d.txt", i)), []byte("hello world"), 0640))
+	}
+	require.NoError(t, os.WriteFile(server.Path("bucket/logs/z/a.txt"), []byte("hello world"), 0640))
+	require.NoError(t, os.WriteFile(server.Path("bucket/other.txt"), []byte("hello world"), 0640))
+
+	count := 0
+	err := Remove(context.Background(), "s3://bucket/logs", &RemoveOptions{
+		ResourceOptions: ResourceOptions{S3Client: server.S3Client()},
+		Recursive:       true,
+		OnRemove:        func(uri string) { count++ },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1002, count)
+
+	_, err = os.Stat(server.Path("bucket/logs/0000.txt"))
+	assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+	_, err = os.Stat(server.Path("bucket/logs/z/a.txt"))
+	assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+	_, err = os.Stat(server.Path("bucket/other.txt"))
+	require.NoError(t, err)
+}
+
+func TestRemoveSymlink(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b.txt"), []byte("hello world"), 0640))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "a"), filepath.Join(dir, "link")))
+
+	err := Remove(context.Background(), filepath.Join(dir, "link"), &RemoveOptions{Recursive: true})
+	require.NoError(t, err)
+	_, err = os.Lstat(filepath.Join(dir, "link"))
+	assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+	_, err = os.Stat(filepath.Join(dir, "a", "b.txt"))
+	require.NoError(t, err)
+}
+
+func TestRemoveUnsupported(t *testing.T) {
+	err := Remove(context.Background(), "gs://bucket/a.txt", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be removed")
+
+	err = Remove(context.Background(), "-", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,164 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	pkgalg "github.com/spatialcurrent/go-reader-writer/pkg/alg"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
+)
+
+// ResourceOptions contains the clients and credentials used to access resources by uri, such as by Stat.
+// If a client for a scheme is not set, then a new client is created for each call and closed before it returns.
+type ResourceOptions struct {
+	AzureBlobClient      *azblob.Client      // Azure Blob Storage Client, defaults to a client using credentials from the environment
+	GCSClient            *gcs.Client         // Google Cloud Storage Client, defaults to a client using credentials from the environment
+	S3Client             *s3.S3              // AWS S3 Client
+	RequestPayer         bool                // confirm that the requester pays for requests to a requester pays bucket on AWS S3
+	SSHClient            *ssh.Client         // SSH Client
+	SFTPClient           *sftp.Client        // SFTP Client
+	SFTPPool             *sftp2.Pool         // if not nil and no SFTP client is set, then SFTP clients are taken from the pool
+	Password             string              // password
+	PrivateKey           []byte              // private key
+	PrivateKeyPassphrase []byte              // passphrase of an encrypted private key
+	SSHAgentSocket       string              // path to the socket of a SSH agent used for authentication, such as the value of SSH_AUTH_SOCK
+	SSHConfig            *ssh2.Config        // if not nil, then the host in a SFTP or SSH uri is resolved using the ssh config
+	HostKeyCallback      ssh.HostKeyCallback // callback for verifying the host key of a SSH server, defaults to the known_hosts file of the user
+	JumpHosts            []string            // jump hosts used to reach a SSH server, each as a ssh uri or [user@]host[:port], overrides the ProxyJump in the ssh config
+	JumpHostKeyCallback  ssh.HostKeyCallback // callback for verifying the host keys of jump hosts, defaults to the known_hosts files for the jump host
+	Retry                *retry.Policy       // policy for retrying failed requests to remote resources
+}
+
+// sshClientOptionsInput returns the input for the options used to connect to a SSH server.
+func (options *ResourceOptions) sshClientOptionsInput() *NewSSHClientOptionsInput {
+	return &NewSSHClientOptionsInput{
+		Password:             options.Password,
+		PrivateKey:           options.PrivateKey,
+		PrivateKeyPassphrase: options.PrivateKeyPassphrase,
+		SSHAgentSocket:       options.SSHAgentSocket,
+		SSHConfig:            options.SSHConfig,
+		HostKeyCallback:      options.HostKeyCallback,
+		JumpHosts:            options.JumpHosts,
+		JumpHostKeyCallback:  options.JumpHostKeyCallback,
+	}
+}
+
+// readFromResourceInput returns the input for reading the resource at the uri without decompressing it.
+func (options *ResourceOptions) readFromResourceInput(uri string) *ReadFromResourceInput {
+	return &ReadFromResourceInput{
+		URI:                  uri,
+		Alg:                  pkgalg.AlgorithmNone,
+		AzureBlobClient:      options.AzureBlobClient,
+		GCSClient:            options.GCSClient,
+		S3Client:             options.S3Client,
+		RequestPayer:         options.RequestPayer,
+		SSHClient:            options.SSHClient,
+		SFTPClient:           options.SFTPClient,
+		SFTPPool:             options.SFTPPool,
+		Password:             options.Password,
+		PrivateKey:           options.PrivateKey,
+		PrivateKeyPassphrase: options.PrivateKeyPassphrase,
+		SSHAgentSocket:       options.SSHAgentSocket,
+		SSHConfig:            options.SSHConfig,
+		HostKeyCallback:      options.HostKeyCallback,
+		JumpHosts:            options.JumpHosts,
+		JumpHostKeyCallback:  options.JumpHostKeyCallback,
+		Retry:                options.Retry,
+	}
+}
+
+// writeToResourceInput returns the input for writing the resource at the uri without compressing it.
+func (options *ResourceOptions) writeToResourceInput(uri string) *WriteToResourceInput {
+	return &WriteToResourceInput{
+		URI:                  uri,
+		Alg:                  pkgalg.AlgorithmNone,
+		AzureBlobClient:      options.AzureBlobClient,
+		GCSClient:            options.GCSClient,
+		S3Client:             options.S3Client,
+		SSHClient:            options.SSHClient,
+		SFTPClient:           options.SFTPClient,
+		SFTPPool:             options.SFTPPool,
+		Password:             options.Password,
+		PrivateKey:           options.PrivateKey,
+		PrivateKeyPassphrase: options.PrivateKeyPassphrase,
+		SSHAgentSocket:       options.SSHAgentSocket,
+		SSHConfig:            options.SSHConfig,
+		HostKeyCallback:      options.HostKeyCallback,
+		JumpHosts:            options.JumpHosts,
+		JumpHostKeyCallback:  options.JumpHostKeyCallback,
+		Retry:                options.Retry,
+	}
+}
+
+// sshClient returns a SSH client for the SSH server at the uri and a function that releases the client when finished.
+// If the options do not include a SSH client, then a new client is dialed and closed when released.
+func (options *ResourceOptions) sshClient(ctx context.Context, uri string) (*ssh.Client, func() error, error) {
+	if options.SSHClient != nil {
+		return options.SSHClient, func() error { return nil }, nil
+	}
+	var client *ssh.Client
+	err := options.Retry.Do(ctx, func() error {
+		c, err := dialSSH(uri, options.sshClientOptionsInput())
+		if err != nil {
+			return err
+		}
+		client = c
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, client.Close, nil
+}
+
+// sftpClient returns a SFTP client for the SFTP server at the uri and a function that releases the client when finished.
+// The client is the SFTP client in the options, a client from the pool in the options,
+// or a new client that is closed along with its SSH connection when released.
+func (options *ResourceOptions) sftpClient(ctx context.Context, uri string) (*sftp.Client, func() error, error) {
+	if options.SFTPClient != nil {
+		return options.SFTPClient, func() error { return nil }, nil
+	}
+	if options.SFTPPool != nil {
+		sshClientOptions, err := NewSSHClientOptions(options.sshClientOptionsInput())
+		if err != nil {
+			return nil, nil, err
+		}
+		client, err := options.SFTPPool.Get(uri, sshClientOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, func() error { return options.SFTPPool.Release(client) }, nil
+	}
+	sshClient, closeSSHClient, err := options.sshClient(ctx, uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		_ = closeSSHClient() // attempt to close the underlying SSH connection
+		return nil, nil, fmt.Errorf("error creating SFTP client: %w", err)
+	}
+	return client, func() error {
+		errClose := client.Close()
+		errCloseSSHClient := closeSSHClient()
+		if errClose != nil {
+			return fmt.Errorf("error closing SFTP client: %w", errClose)
+		}
+		return errCloseSSHClient
+	}, nil
+}
@@ -0,0 +1,452 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdhttp "net/http"
+	stdos "os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	homedir "github.com/mitchellh/go-homedir"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ftp"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/webdav"
+	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+	"github.com/spatialcurrent/go-reader-writer/pkg/stat"
+)
+
+// Stat returns information about the resource at the uri, using the clients and credentials in the options, if any.
+// The options may be nil.
+//
+// Local files and stdin ("-") are described with os.Stat.
+// Files on SFTP servers are described with the SFTP stat request, and files on SSH servers with the stat command.
+// Files on FTP servers are described by listing the parent directory, using SIZE and CWD as a fallback.
+// HTTP resources are described with a HEAD request, and WebDAV resources with a PROPFIND request.
+// Objects in AWS S3, Google Cloud Storage, and Azure Blob Storage are described by their metadata.
+// If no object exists at the uri, but objects exist under the uri followed by "/",
+// then the uri is described as a prefix, which is also a directory.
+// A uri that ends with "/" is always described as a prefix, if any objects exist under it.
+// A uri with only a bucket or container is described as a prefix.
+//
+// If the resource does not exist, then the error wraps os.ErrNotExist.
+func Stat(ctx context.Context, uri string, options *ResourceOptions) (*stat.ResourceInfo, error) {
+	if options == nil {
+		options = &ResourceOptions{}
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if uri == "-" || uri == "stdin" {
+		fi, errStat := stdos.Stdin.Stat()
+		if errStat != nil {
+			return nil, fmt.Errorf("error stating stdin: %w", errStat)
+		}
+		return stat.NewResourceInfoFromFileInfo(uri, fi), nil
+	}
+
+	scheme, fullpath := splitter.SplitURI(uri)
+
+	if _, ok := azblob.ParseBlobURL(uri); ok {
+		scheme = schemes.SchemeAzureBlob
+	}
+
+	var info *stat.ResourceInfo
+	switch scheme {
+	case schemes.SchemeFile, "":
+		info, err = statFile(uri, fullpath)
+	case schemes.SchemeSFTP:
+		err = options.Retry.Do(ctx, func() error {
+			i, errStat := statSFTPFile(ctx, uri, fullpath, options)
+			info = i
+			return errStat
+		})
+	case schemes.SchemeSSH:
+		err = options.Retry.Do(ctx, func() error {
+			i, errStat := statSSHFile(ctx, uri, fullpath, options)
+			info = i
+			return errStat
+		})
+	case schemes.SchemeSSHCommand:
+		return nil, fmt.Errorf("error stating resource at uri %q: cannot stat the output of a command", uri)
+	case schemes.SchemeFTP:
+		err = options.Retry.Do(ctx, func() error {
+			i, errStat := statFTPFile(ctx, uri)
+			info = i
+			return errStat
+		})
+	case schemes.SchemeHTTP, schemes.SchemeHTTPS:
+		err = options.Retry.Do(ctx, func() error {
+			i, errStat := statHTTPResource(ctx, uri)
+			info = i
+			return errStat
+		})
+	case schemes.SchemeWebDAV, schemes.SchemeWebDAVS:
+		err = options.Retry.Do(ctx, func() error {
+			i, errStat := statWebDAVResource(ctx, uri)
+			info = i
+			return errStat
+		})
+	case schemes.SchemeS3:
+		info, err = statS3Object(ctx, uri, fullpath, options)
+	case schemes.SchemeGCS:
+		err = options.Retry.Do(ctx, func() error {
+			i, errStat := statGCSObject(ctx, uri, fullpath, options)
+			info = i
+			return errStat
+		})
+	case schemes.SchemeAzureBlob:
+		err = options.Retry.Do(ctx, func() error {
+			i, errStat := statAzureBlob(ctx, uri, options)
+			info = i
+			return errStat
+		})
+	default:
+		return nil, &schemes.ErrUnknownScheme{Scheme: scheme}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error stating resource at uri %q: %w", uri, err)
+	}
+	if info == nil {
+		return nil, fmt.Errorf("resource at uri %q does not exist: %w", uri, stdos.ErrNotExist)
+	}
+	return info, nil
+}
+
+// remotePath returns the path after the authority of the full path of a remote uri, or "." if the path is blank.
+func remotePath(fullpath string) string {
+	if parts := strings.SplitN(fullpath, "/", 2); len(parts) == 2 && len(parts[1]) > 0 {
+		return parts[1]
+	}
+	return "."
+}
+
+// baseName returns the last element of the path, ignoring any trailing slash.
+func baseName(p string) string {
+	if trimmed := strings.TrimRight(p, "/"); len(trimmed) > 0 {
+		return path.Base(trimmed)
+	}
+	return p
+}
+
+// statFile returns the info for a local file, or nil if the file does not exist.
+func statFile(uri string, p string) (*stat.ResourceInfo, error) {
+	pathExpanded, err := homedir.Expand(p)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding file path %q: %w", p, err)
+	}
+	fi, err := stdos.Stat(filepath.Clean(pathExpanded))
+	if err != nil {
+		if errors.Is(err, stdos.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return stat.NewResourceInfoFromFileInfo(uri, fi), nil
+}
+
+// statSFTPFile returns the info for a file on a SFTP server, or nil if the file does not exist.
+func statSFTPFile(ctx context.Context, uri string, fullpath string, options *ResourceOptions) (*stat.ResourceInfo, error) {
+	client, release, err := options.sftpClient(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = release() }()
+	fi, err := client.Stat(remotePath(fullpath))
+	if err != nil {
+		if errors.Is(err, stdos.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return stat.NewResourceInfoFromFileInfo(uri, fi), nil
+}
+
+// statSSHFile returns the info for a file on a SSH server, or nil if the file does not exist.
+func statSSHFile(ctx context.Context, uri string, fullpath string, options *ResourceOptions) (*stat.ResourceInfo, error) {
+	client, release, err := options.sshClient(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = release() }()
+	exists, fi, err := ssh2.Stat(client, remotePath(fullpath))
+	if err != nil || !exists {
+		return nil, err
+	}
+	return stat.NewResourceInfoFromFileInfo(uri, fi), nil
+}
+
+// statFTPFile returns the info for a file on a FTP server, or nil if the file does not exist.
+func statFTPFile(ctx context.Context, uri string) (*stat.ResourceInfo, error) {
+	conn, p, err := ftp.Dial(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Quit() }()
+	exists, fi, err := ftp.Stat(conn, p)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return stat.NewResourceInfoFromFileInfo(uri, fi), nil
+}
+
+// statHTTPResource returns the info for a HTTP resource from the headers of the response to a HEAD request,
+// or nil if the server responds with 404 Not Found or 410 Gone.
+func statHTTPResource(ctx context.Context, uri string) (*stat.ResourceInfo, error) {
+	response, err := http.Head(ctx, uri)
+	if err != nil {
+		var errUnexpectedStatus *http.ErrUnexpectedStatus
+		if errors.As(err, &errUnexpectedStatus) {
+			if errUnexpectedStatus.StatusCode == stdhttp.StatusNotFound || errUnexpectedStatus.StatusCode == stdhttp.StatusGone {
+				return nil, nil
+			}
+		}
+		return nil, err
+	}
+	input := &stat.NewResourceInfoInput{
+		URI:         uri,
+		Name:        baseName(response.Request.URL.Path),
+		ContentType: response.Header.Get("Content-Type"),
+		ETag:        response.Header.Get("ETag"),
+	}
+	if response.ContentLength > 0 {
+		input.Size = response.ContentLength
+	}
+	if t, errParseTime := stdhttp.ParseTime(response.Header.Get("Last-Modified")); errParseTime == nil {
+		input.ModTime = t
+	}
+	return stat.NewResourceInfo(input), nil
+}
+
+// statWebDAVResource returns the info for a WebDAV resource, or nil if the resource does not exist.
+func statWebDAVResource(ctx context.Context, uri string) (*stat.ResourceInfo, error) {
+	exists, fi, err := webdav.Stat(ctx, uri)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return stat.NewResourceInfo(&stat.NewResourceInfoInput{
+		URI:         uri,
+		Name:        fi.Name(),
+		Size:        fi.Size(),
+		ModTime:     fi.ModTime(),
+		Mode:        fi.Mode(),
+		ContentType: fi.ContentType(),
+		ETag:        fi.ETag(),
+		Dir:         fi.IsDir(),
+	}), nil
+}
+
+// isS3NotFound returns true if the error is a response from AWS S3 that the bucket or object does not exist.
+func isS3NotFound(err error) bool {
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) && requestFailure.StatusCode() == stdhttp.StatusNotFound {
+		return true
+	}
+	var awsError awserr.Error
+	if errors.As(err, &awsError) {
+		switch awsError.Code() {
+		case s3.ErrCodeNoSuchBucket, s3.ErrCodeNoSuchKey, "NotFound":
+			return true
+		}
+	}
+	return false
+}
+
+// statS3Object returns the info for an object on AWS S3, a prefix shared by objects, or a bucket.
+// Returns nil if neither the object nor the prefix exist.
+func statS3Object(ctx context.Context, uri string, fullpath string, options *ResourceOptions) (*stat.ResourceInfo, error) {
+	if options.S3Client == nil {
+		return nil, errors.New("missing AWS S3 client")
+	}
+	bucket, key, versionID := fullpath, "", ""
+	if strings.Contains(fullpath, "/") {
+		b, k, v, err := splitS3Path(fullpath)
+		if err != nil {
+			return nil, err
+		}
+		bucket, key, versionID = b, k, v
+	}
+	if len(bucket) == 0 {
+		return nil, errors.New("path missing bucket")
+	}
+
+	if len(key) == 0 {
+		_, err := options.S3Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)})
+		if err != nil {
+			if isS3NotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return stat.NewResourceInfo(&stat.NewResourceInfoInput{URI: uri, Name: bucket, Prefix: true}), nil
+	}
+
+	// a key that ends with a slash is described as a prefix, even if an object marks the folder
+	if !strings.HasSuffix(key, "/") {
+		headObjectInput := &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		}
+		if len(versionID) > 0 {
+			headObjectInput.VersionId = aws.String(versionID)
+		}
+		if options.RequestPayer {
+			headObjectInput.RequestPayer = aws.String(s3.RequestPayerRequester)
+		}
+		headObjectOutput, err := options.S3Client.HeadObjectWithContext(ctx, headObjectInput)
+		if err == nil {
+			return stat.NewResourceInfo(&stat.NewResourceInfoInput{
+				URI:         uri,
+				Name:        baseName(key),
+				Size:        aws.Int64Value(headObjectOutput.ContentLength),
+				ModTime:     aws.TimeValue(headObjectOutput.LastModified),
+				ContentType: aws.StringValue(headObjectOutput.ContentType),
+				ETag:        aws.StringValue(headObjectOutput.ETag),
+			}), nil
+		}
+		if !isS3NotFound(err) {
+			return nil, err
+		}
+		if len(versionID) > 0 {
+			// a version of an object is never a prefix
+			return nil, nil
+		}
+	}
+
+	listObjectsInput := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		Prefix:  aws.String(strings.TrimSuffix(key, "/") + "/"),
+		MaxKeys: aws.Int64(1),
+	}
+	if options.RequestPayer {
+		listObjectsInput.RequestPayer = aws.String(s3.RequestPayerRequester)
+	}
+	listObjectsOutput, err := options.S3Client.ListObjectsV2WithContext(ctx, listObjectsInput)
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(listObjectsOutput.Contents) == 0 && len(listObjectsOutput.CommonPrefixes) == 0 {
+		return nil, nil
+	}
+	return stat.NewResourceInfo(&stat.NewResourceInfoInput{URI: uri, Name: baseName(key), Prefix: true}), nil
+}
+
+// statGCSObject returns the info for an object on Google Cloud Storage, a prefix shared by objects, or a bucket.
+// Returns nil if neither the object nor the prefix exist.
+func statGCSObject(ctx context.Context, uri string, fullpath string, options *ResourceOptions) (*stat.ResourceInfo, error) {
+	bucket, object, err := gcs.SplitPath(fullpath)
+	if err != nil {
+		return nil, err
+	}
+	client := options.GCSClient
+	if client == nil {
+		client, err = gcs.NewClient(&gcs.NewClientInput{})
+		if err != nil {
+			return nil, fmt.Errorf("error creating Google Cloud Storage client: %w", err)
+		}
+	}
+	if len(object) > 0 && !strings.HasSuffix(object, "/") {
+		exists, attrs, errStat := gcs.Stat(ctx, client, bucket, object)
+		if errStat != nil {
+			return nil, errStat
+		}
+		if exists {
+			return stat.NewResourceInfo(&stat.NewResourceInfoInput{
+				URI:         uri,
+				Name:        baseName(object),
+				Size:        attrs.Size,
+				ModTime:     attrs.Updated,
+				ContentType: attrs.ContentType,
+				ETag:        attrs.ETag,
+			}), nil
+		}
+	}
+	prefix := ""
+	if len(object) > 0 {
+		prefix = strings.TrimSuffix(object, "/") + "/"
+	}
+	output, err := gcs.List(ctx, &gcs.ListInput{Client: client, Bucket: bucket, Prefix: prefix, MaxResults: 1})
+	if err != nil {
+		if isStatusNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(object) > 0 && len(output.Objects) == 0 && len(output.Prefixes) == 0 {
+		return nil, nil
+	}
+	return stat.NewResourceInfo(&stat.NewResourceInfoInput{URI: uri, Name: baseName(fullpath), Prefix: true}), nil
+}
+
+// statAzureBlob returns the info for a blob on Azure Blob Storage, a prefix shared by blobs, or a container.
+// Returns nil if neither the blob nor the prefix exist.
+func statAzureBlob(ctx context.Context, uri string, options *ResourceOptions) (*stat.ResourceInfo, error) {
+	client, container, blob, err := azureBlobContainerClient(options.AzureBlobClient, uri)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) > 0 && !strings.HasSuffix(blob, "/") {
+		exists, properties, errStat := azblob.Stat(ctx, client, container, blob)
+		if errStat != nil {
+			return nil, errStat
+		}
+		if exists {
+			return stat.NewResourceInfo(&stat.NewResourceInfoInput{
+				URI:         uri,
+				Name:        baseName(blob),
+				Size:        properties.Size,
+				ModTime:     properties.LastModified,
+				ContentType: properties.ContentType,
+				ETag:        properties.ETag,
+			}), nil
+		}
+	}
+	prefix := ""
+	if len(blob) > 0 {
+		prefix = strings.TrimSuffix(blob, "/") + "/"
+	}
+	output, err := azblob.List(ctx, &azblob.ListInput{Client: client, Container: container, Prefix: prefix, MaxResults: 1})
+	if err != nil {
+		if isStatusNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(blob) > 0 && len(output.Blobs) == 0 && len(output.Prefixes) == 0 {
+		return nil, nil
+	}
+	name := container
+	if len(blob) > 0 {
+		name = baseName(blob)
+	}
+	return stat.NewResourceInfo(&stat.NewResourceInfoInput{URI: uri, Name: name, Prefix: true}), nil
+}
+
+// isStatusNotFound returns true if the error is an unexpected 404 Not Found response from a HTTP server.
+func isStatusNotFound(err error) bool {
+	var errUnexpectedStatus *http.ErrUnexpectedStatus
+	return errors.As(err, &errUnexpectedStatus) && errUnexpectedStatus.StatusCode == stdhttp.StatusNotFound
+}
@@ -0,0 +1,131 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/grwtest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
+)
+
+func TestStatS3(t *testing.T) {
+	server := grwtest.NewS3Server()
+	defer server.Close()
+	require.NoError(t, server.CreateBucket("bucket"))
+
+	client := server.S3Client()
+	_, err := client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String("bucket"),
+		Key:         aws.String("a/b/c.txt"),
+		Body:        bytes.NewReader([]byte("hello world")),
+		ContentType: aws.String("text/plain"),
+	})
+	require.NoError(t, err)
+
+	options := &ResourceOptions{S3Client: client}
+
+	info, err := Stat(context.Background(), "s3://bucket/a/b/c.txt", options)
+	require.NoError(t, err)
+	assert.Equal(t, "c.txt", info.Name())
+	assert.Equal(t, "text/plain", info.ContentType())
+	assert.NotEmpty(t, info.ETag())
+
+	info, err = Stat(context.Background(), "s3://bucket/a/", options)
+	require.NoError(t, err)
+	assert.Equal(t, "a", info.Name())
+	assert.True(t, info.IsPrefix())
+
+	info, err = Stat(context.Background(), "s3://bucket", options)
+	require.NoError(t, err)
+	assert.Equal(t, "bucket", info.Name())
+	assert.True(t, info.IsPrefix())
+
+	_, err = Stat(context.Background(), "s3://missing", options)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "expected not exist, but got %v", err)
+
+	_, err = Stat(context.Background(), "s3://missing/a.txt", options)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "expected not exist, but got %v", err)
+
+	_, err = Stat(context.Background(), "s3://bucket/a.txt", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing AWS S3 client")
+}
+
+func TestStatSSH(t *testing.T) {
+	server := grwtest.NewSFTPServer()
+	defer server.Close()
+
+	require.NoError(t, os.WriteFile(server.Path("a.txt"), []byte("hello world"), 0640))
+
+	options := &ResourceOptions{
+		Password:        server.Password,
+		HostKeyCallback: server.HostKeyCallback(),
+	}
+
+	info, err := Stat(context.Background(), server.Server.URI("ssh", server.Path("a.txt")), options)
+	require.NoError(t, err)
+	assert.Equal(t, "a.txt", info.Name())
+	assert.Equal(t, int64(11), info.Size())
+	assert.Equal(t, os.FileMode(0640), info.Perm())
+
+	_, err = Stat(context.Background(), server.Server.URI("ssh", filepath.Join(server.Dir, "missing.txt")), options)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "expected not exist, but got %v", err)
+
+	_, err = Stat(context.Background(), server.Server.URI("ssh+cmd", "")+"?cmd=ls", options)
+	require.Error(t, err)
+}
+
+func TestStatContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Stat(ctx, "file://"+t.TempDir(), nil)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestStatContextRequests(t *testing.T) {
+	// the server does not respond until the request is canceled or the test is finished
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	options := &ResourceOptions{
+		GCSClient:       &gcs.Client{Endpoint: server.URL},
+		AzureBlobClient: &azblob.Client{Endpoint: server.URL},
+	}
+	for _, uri := range []string{
+		"webdav://" + server.Listener.Addr().String() + "/a.txt",
+		"gs://bucket/a.txt",
+		"azblob://container/a.txt",
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		_, err := Stat(ctx, uri, options)
+		cancel()
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline exceeded for uri %q, but got %v", uri, err)
+	}
+}
@@ -8,9 +8,11 @@
 package grw
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -18,11 +20,21 @@ import (
 )
 
 type UploadS3ObjectInput struct {
-	ACL    string
-	Bucket string
-	Key    string
-	Object io.Reader
-	Client *s3.S3
+	ACL                  string
+	Bucket               string
+	Key                  string
+	Object               io.Reader
+	Client               *s3.S3
+	ContentType          string            // content type of the object
+	ContentEncoding      string            // content encoding of the object, such as gzip
+	CacheControl         string            // cache control of the object
+	Metadata             map[string]string // user metadata of the object
+	Tags                 map[string]string // tags of the object
+	StorageClass         string            // storage class of the object, such as STANDARD_IA or GLACIER
+	ServerSideEncryption string            // server-side encryption with keys managed by AWS, either "AES256" (SSE-S3) or "aws:kms" (SSE-KMS)
+	SSEKMSKeyID          string            // ID or ARN of the KMS key used with SSE-KMS, defaults to the AWS managed key
+	SSECustomerKey       string            // 256-bit key used with server-side encryption with customer-provided keys (SSE-C)
+	Context              context.Context   // context for the upload, defaults to context.Background()
 }
 
 // UploadS3Object uploads an object to S3.
@@ -66,7 +78,62 @@ func UploadS3Object(input *UploadS3ObjectInput) error {
 		uploadInput.ACL = aws.String(input.ACL)
 	}
 
-	_, err := uploader.Upload(uploadInput)
+	if len(input.ContentType) > 0 {
+		uploadInput.ContentType = aws.String(input.ContentType)
+	}
+
+	if len(input.ContentEncoding) > 0 {
+		uploadInput.ContentEncoding = aws.String(input.ContentEncoding)
+	}
+
+	if len(input.CacheControl) > 0 {
+		uploadInput.CacheControl = aws.String(input.CacheControl)
+	}
+
+	if len(input.Metadata) > 0 {
+		uploadInput.Metadata = aws.StringMap(input.Metadata)
+	}
+
+	if len(input.Tags) > 0 {
+		tags := url.Values{}
+		for k, v := range input.Tags {
+			tags.Set(k, v)
+		}
+		uploadInput.Tagging = aws.String(tags.Encode())
+	}
+
+	if len(input.StorageClass) > 0 {
+		uploadInput.StorageClass = aws.String(input.StorageClass)
+	}
+
+	if len(input.ServerSideEncryption) > 0 {
+		uploadInput.ServerSideEncryption = aws.String(input.ServerSideEncryption)
+	}
+
+	if len(input.SSEKMSKeyID) > 0 {
+		if input.ServerSideEncryption != s3.ServerSideEncryptionAwsKms {
+			return fmt.Errorf("invalid input: a KMS key ID requires server-side encryption %q", s3.ServerSideEncryptionAwsKms)
+		}
+		uploadInput.SSEKMSKeyId = aws.String(input.SSEKMSKeyID)
+	}
+
+	if len(input.SSECustomerKey) > 0 {
+		if len(input.ServerSideEncryption) > 0 {
+			return errors.New("invalid input: a customer-provided key cannot be used with server-side encryption managed by AWS")
+		}
+		if len(input.SSECustomerKey) != 32 {
+			return fmt.Errorf("invalid input: customer-provided key is %d bytes, but must be 32 bytes", len(input.SSECustomerKey))
+		}
+		uploadInput.SSECustomerAlgorithm = aws.String(s3.ServerSideEncryptionAes256)
+		uploadInput.SSECustomerKey = aws.String(input.SSECustomerKey)
+	}
+
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, err := uploader.UploadWithContext(ctx, uploadInput)
 	if err != nil {
 		return fmt.Errorf("error uploading data to AWS S3: %w", err)
 	}
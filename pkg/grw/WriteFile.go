@@ -20,6 +20,7 @@ import (
 type WriteFileInput struct {
 	Path       string // required field
 	BufferSize int    // if zero, then no buffer is used.
+	Flag       int    // defaults to os.O_CREATE|os.O_WRONLY
 	Mode       uint32 // defaults to 0600
 }
 
@@ -40,7 +41,11 @@ func WriteFile(input *WriteFileInput) (io.WriteCloser, error) {
 	if bufferSize < 0 {
 		return nil, fmt.Errorf("error creating writer for file at path %q: invalid buffer size %d", path, bufferSize)
 	}
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, os.FileMode(mode))
+	flag := input.Flag
+	if flag == 0 {
+		flag = os.O_CREATE | os.O_WRONLY
+	}
+	f, err := os.OpenFile(path, flag, os.FileMode(mode))
 	if err != nil {
 		return nil, fmt.Errorf("error opening file at path %q for writing: %w", path, err)
 	}
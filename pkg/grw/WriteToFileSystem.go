@@ -74,6 +74,7 @@ func WriteToFileSystem(input *WriteToFileSystemInput) (io.WriteCloser, error) {
 		return WriteFile(&WriteFileInput{
 			Path:       input.Path,
 			BufferSize: input.BufferSize,
+			Flag:       input.Flag,
 			Mode:       input.Mode,
 		})
 	}
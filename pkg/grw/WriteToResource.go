@@ -8,9 +8,13 @@
 package grw
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	stdos "os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -20,77 +24,233 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	pkgalg "github.com/spatialcurrent/go-reader-writer/pkg/alg"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
 	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/webdav"
 	"github.com/spatialcurrent/go-reader-writer/pkg/os"
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
 	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
 	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
 )
 
 type WriteToResourceInput struct {
-	ACL        string       // ACL for objects written to AWS s3
-	Alg        string       // compression algorithm
-	Append     bool         // append to output resource
-	BufferSize int          // buffer size
-	Dict       []byte       // compression dictionary
-	Mode       uint32       // mode of the output file
-	Parents    bool         // automatically create parent directories as necessary
-	Password   string       // password
-	PrivateKey []byte       // private key
-	S3Client   *s3.S3       // AWS S3 Client
-	SSHClient  *ssh.Client  // SSH Client
-	SFTPClient *sftp.Client // SFTP Client
-	URI        string       // uri to write to
+	ACL                  string              // ACL for objects written to AWS s3
+	Alg                  string              // compression algorithm
+	Append               bool                // append to output resource
+	Atomic               bool                // write to a temporary file and rename it to the output file when closed, only supported for SFTP
+	AzureBlobClient      *azblob.Client      // Azure Blob Storage Client, defaults to a client using credentials from the environment
+	BufferSize           int                 // buffer size
+	CacheControl         string              // cache control of objects written to object storage
+	ContentEncoding      string              // content encoding of objects written to object storage, for AWS S3 defaults to the encoding of the compression algorithm
+	ContentType          string              // content type of objects written to object storage
+	Context              context.Context     // context for requests to remote resources and waits between retries, defaults to context.Background()
+	Dict                 []byte              // compression dictionary
+	DirMode              uint32              // mode of the parent directories created for local and SFTP outputs, defaults to DefaultDirMode
+	GCSClient            *gcs.Client         // Google Cloud Storage Client, defaults to a client using credentials from the environment
+	Metadata             map[string]string   // user metadata of objects written to object storage
+	Mode                 uint32              // mode of the output file
+	Parents              bool                // automatically create parent directories as necessary
+	Password             string              // password
+	PrivateKey           []byte              // private key
+	PrivateKeyPassphrase []byte              // passphrase of an encrypted private key
+	SSHAgentSocket       string              // path to the socket of a SSH agent used for authentication, such as the value of SSH_AUTH_SOCK
+	SSHConfig            *ssh2.Config        // if not nil, then the host in a SFTP uri is resolved using the ssh config
+	HostKeyCallback      ssh.HostKeyCallback // callback for verifying the host key of a SSH server, defaults to the known_hosts file of the user
+	JumpHosts            []string            // jump hosts used to reach a SSH server, each as a ssh uri or [user@]host[:port], overrides the ProxyJump in the ssh config
+	JumpHostKeyCallback  ssh.HostKeyCallback // callback for verifying the host keys of jump hosts, defaults to the known_hosts files for the jump host
+	Retry                *retry.Policy       // policy for retrying failed connections to remote resources
+	S3Client             *s3.S3              // AWS S3 Client
+	ServerSideEncryption string              // server-side encryption of objects written to AWS S3, either "AES256" (SSE-S3) or "aws:kms" (SSE-KMS)
+	SSECustomerKey       string              // 256-bit key used to encrypt objects written to AWS S3 with a customer-provided key (SSE-C)
+	SSEKMSKeyID          string              // ID or ARN of the KMS key used to encrypt objects written to AWS S3 with SSE-KMS
+	SSHClient            *ssh.Client         // SSH Client
+	SFTPClient           *sftp.Client        // SFTP Client
+	SFTPPool             *sftp2.Pool         // if not nil and no SSH or SFTP client is set, then SFTP clients are taken from the pool
+	StorageClass         string              // storage class of objects written to AWS S3
+	Tags                 map[string]string   // tags of objects written to AWS S3
+	URI                  string              // uri to write to
 }
 
 type WriteToResourceOutput struct {
 	Writer io.WriteCloser
 }
 
-func writeToSFTP(input *WriteToResourceInput) (*WriteToResourceOutput, error) {
-	sshClient := input.SSHClient
-	if sshClient == nil {
-		options := []ssh2.ClientOption{}
-		if input.PrivateKey != nil && len(input.PrivateKey) > 0 {
-			privateKey, err := ssh.ParsePrivateKey(input.PrivateKey)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing private SSH key: %w", err)
-			}
-			options = append(options, func(config *ssh2.ClientConfig) error {
-				config.Auth = []ssh.AuthMethod{
-					ssh.PublicKeys(privateKey),
-				}
-				return nil
-			})
-		} else if len(input.Password) > 0 {
-			options = append(options, func(config *ssh2.ClientConfig) error {
-				config.Auth = []ssh.AuthMethod{
-					ssh.Password(input.Password),
-				}
-				return nil
-			})
+// sshClientOptionsInput returns the input for the options used to connect to a SSH server.
+func (input *WriteToResourceInput) sshClientOptionsInput() *NewSSHClientOptionsInput {
+	return &NewSSHClientOptionsInput{
+		Password:             input.Password,
+		PrivateKey:           input.PrivateKey,
+		PrivateKeyPassphrase: input.PrivateKeyPassphrase,
+		SSHAgentSocket:       input.SSHAgentSocket,
+		SSHConfig:            input.SSHConfig,
+		HostKeyCallback:      input.HostKeyCallback,
+		JumpHosts:            input.JumpHosts,
+		JumpHostKeyCallback:  input.JumpHostKeyCallback,
+	}
+}
+
+// ctx returns the context of the input, or the background context if not set.
+func (input *WriteToResourceInput) ctx() context.Context {
+	if input.Context == nil {
+		return context.Background()
+	}
+	return input.Context
+}
+
+// dirMode returns the mode of the parent directories created for the output.
+func (input *WriteToResourceInput) dirMode() uint32 {
+	if input.DirMode == 0 {
+		return DefaultDirMode
+	}
+	return input.DirMode
+}
+
+// sftpTempPath returns a unique path for a temporary file in the same directory as the file at the path,
+// so the temporary file can be renamed to the path.
+func sftpTempPath(p string) (string, error) {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("error generating name of temporary file: %w", err)
+	}
+	dir, name := path.Split(p)
+	return dir + "." + name + "." + hex.EncodeToString(b) + ".tmp", nil
+}
+
+// createSFTPFile opens the file at the path for writing,
+// or if writing atomically, a new temporary file in the same directory.
+// If parents is set, then the missing parent directories are created first.
+func createSFTPFile(input *WriteToResourceInput, sftpClient *sftp.Client, p string) (*sftp.File, error) {
+	if input.Parents {
+		err := sftp2.MkdirAll(sftpClient, path.Dir(p), stdos.FileMode(input.dirMode()))
+		if err != nil {
+			return nil, fmt.Errorf("error creating parent directories: %w", err)
+		}
+	}
+	if input.Atomic {
+		if _, ok := sftpClient.HasExtension(sftp2.ExtensionPosixRename); !ok {
+			return nil, fmt.Errorf("error writing atomically: server does not support the %s extension", sftp2.ExtensionPosixRename)
+		}
+		tempPath, err := sftpTempPath(p)
+		if err != nil {
+			return nil, err
+		}
+		file, err := sftpClient.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL)
+		if err != nil {
+			return nil, fmt.Errorf("error opening temporary file %q: %w", tempPath, err)
 		}
-		c, err := ssh2.Dial(input.URI, options...)
+		return file, nil
+	}
+	if input.Append {
+		file, err := sftpClient.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_APPEND)
 		if err != nil {
-			return nil, fmt.Errorf("error creating SSH client: %w", err)
+			return nil, fmt.Errorf("error opening file: %w", err)
 		}
-		sshClient = c.Client
+		// some servers write at the offset of each request even when appending, so start at the end of the file.
+		_, err = file.Seek(0, io.SeekEnd)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("error seeking to the end of file %q: %w", p, err)
+		}
+		return file, nil
+	}
+	file, err := sftpClient.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
 	}
-	sftpClient := input.SFTPClient
+	return file, nil
+}
+
+// openSFTPFile opens the file at the uri for writing.
+// If pooled is true, then the SFTP client is taken from the pool in the input and released if the file cannot be opened.
+func openSFTPFile(input *WriteToResourceInput, sshClient *ssh.Client, sftpClient *sftp.Client, pooled bool) (*sftp.Client, *sftp.File, error) {
+	_, fullpath := splitter.SplitURI(input.URI)
+	p := strings.SplitN(fullpath, "/", 2)[1]
+	if pooled {
+		options, err := NewSSHClientOptions(input.sshClientOptionsInput())
+		if err != nil {
+			return nil, nil, err
+		}
+		c, err := input.SFTPPool.Get(input.URI, options...)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, err := createSFTPFile(input, c, p)
+		if err != nil {
+			_ = input.SFTPPool.Release(c)
+			return nil, nil, err
+		}
+		return c, file, nil
+	}
+	// close the clients dialed by this function if the file cannot be opened
+	closeClients := func() {}
 	if sftpClient == nil {
-		c, err := sftp.NewClient(sshClient)
+		if sshClient == nil {
+			c, err := dialSSH(input.URI, input.sshClientOptionsInput())
+			if err != nil {
+				return nil, nil, err
+			}
+			sshClient = c
+			closeClients = func() { _ = c.Close() }
+		}
+		c, err := sftp.NewClient(sshClient, sftp.UseConcurrentWrites(true))
 		if err != nil {
-			return nil, fmt.Errorf("error creating SFTP client: %w", err)
+			closeClients()
+			return nil, nil, fmt.Errorf("error creating SFTP client: %w", err)
 		}
 		sftpClient = c
+		closeSSHClient := closeClients
+		closeClients = func() {
+			_ = c.Close()
+			closeSSHClient()
+		}
 	}
-	_, fullpath := splitter.SplitURI(input.URI)
-	file, err := sftpClient.OpenFile(strings.SplitN(fullpath, "/", 2)[1], os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	file, err := createSFTPFile(input, sftpClient, p)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file: %w", err)
+		closeClients()
+		return nil, nil, err
+	}
+	return sftpClient, file, nil
+}
+
+// writeToSFTP writes to a file on a SFTP server.
+// If writing atomically, then the file is written to a temporary file that is renamed to the file when the writer is closed,
+// so readers never see a partially written file.
+func writeToSFTP(input *WriteToResourceInput) (*WriteToResourceOutput, error) {
+	if input.Atomic && input.Append {
+		return nil, fmt.Errorf("error writing to resource at %q: cannot append to a file when writing atomically", input.URI)
+	}
+	sshClient, sftpClient := input.SSHClient, input.SFTPClient
+	var file *sftp.File
+	pooled := false
+	err := input.Retry.Do(input.ctx(), func() error {
+		pooled = sshClient == nil && sftpClient == nil && input.SFTPPool != nil
+		c, f, err := openSFTPFile(input, sshClient, sftpClient, pooled)
+		if err != nil {
+			if input.Retry.IsRetryable(err) {
+				// the clients provided as input may be broken, so dial new clients on the next attempt.
+				sshClient, sftpClient = nil, nil
+			}
+			return err
+		}
+		sftpClient, file = c, f
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	// Do not use a SFTP writer, so that the SFTP and SSH connections stay open.
 	ww, err := WrapWriter(file, input.Alg, input.Dict, 0)
 	if err != nil {
+		_ = file.Close()
+		if input.Atomic {
+			_ = sftpClient.Remove(file.Name()) // attempt to remove the temporary file
+		}
+		if pooled {
+			_ = input.SFTPPool.Release(sftpClient)
+		}
 		return nil, fmt.Errorf("error wrapping writer for resource at %q: %w", input.URI, err)
 	}
 	return &WriteToResourceOutput{
@@ -103,12 +263,273 @@ func writeToSFTP(input *WriteToResourceInput) (*WriteToResourceOutput, error) {
 					// attempt to change file mode to the desired mode, if error than just continue
 					_ = sftpClient.Chmod(file.Name(), stdos.FileMode(input.Mode))
 				}
+				if input.Atomic {
+					if err == nil {
+						_, fullpath := splitter.SplitURI(input.URI)
+						err = sftpClient.PosixRename(file.Name(), strings.SplitN(fullpath, "/", 2)[1])
+						if err != nil {
+							err = fmt.Errorf("error renaming temporary file %q: %w", file.Name(), err)
+						}
+					}
+					if err != nil {
+						_ = sftpClient.Remove(file.Name()) // attempt to remove the temporary file
+					}
+				}
+				if pooled {
+					if errRelease := input.SFTPPool.Release(sftpClient); errRelease != nil && err == nil {
+						err = fmt.Errorf("error releasing SFTP client: %w", errRelease)
+					}
+				}
 				return err
 			},
 		},
 	}, nil
 }
 
+// openSSHWriter starts the command that writes to the file at the uri,
+// or for a SSH command uri, the command in the uri.
+func openSSHWriter(input *WriteToResourceInput, sshClient *ssh.Client) (*ssh.Client, *ssh2.Writer, error) {
+	if sshClient == nil {
+		c, err := dialSSH(input.URI, input.sshClientOptionsInput())
+		if err != nil {
+			return nil, nil, err
+		}
+		sshClient = c
+	}
+	scheme, fullpath := splitter.SplitURI(input.URI)
+	if scheme == schemes.SchemeSSHCommand {
+		command, err := ssh2.ParseCommand(input.URI)
+		if err != nil {
+			return sshClient, nil, err
+		}
+		w, err := ssh2.NewWriter(sshClient, command)
+		return sshClient, w, err
+	}
+	parts := strings.SplitN(fullpath, "/", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return sshClient, nil, fmt.Errorf("error writing file at uri %q: missing path", input.URI)
+	}
+	w, err := ssh2.NewFileWriter(sshClient, parts[1], input.Append, input.Parents, input.Mode)
+	return sshClient, w, err
+}
+
+// writeToSSH writes to a file on a SSH server using cat, or to the standard input of the command in a SSH command uri.
+// If the writer dials a new SSH client, then the client is closed when the writer is closed.
+func writeToSSH(input *WriteToResourceInput) (*WriteToResourceOutput, error) {
+	sshClient := input.SSHClient
+	var w *ssh2.Writer
+	err := input.Retry.Do(input.ctx(), func() error {
+		c, writer, err := openSSHWriter(input, sshClient)
+		if err != nil {
+			if c != nil && c != input.SSHClient {
+				_ = c.Close() // attempt to close the underlying SSH connection
+			}
+			if input.Retry.IsRetryable(err) {
+				// the client provided as input may be broken, so dial a new client on the next attempt.
+				sshClient = nil
+			}
+			return err
+		}
+		sshClient, w = c, writer
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	closeClient := func() {
+		if sshClient != input.SSHClient {
+			_ = sshClient.Close()
+		}
+	}
+	ww, err := WrapWriter(w, input.Alg, input.Dict, input.BufferSize)
+	if err != nil {
+		_ = w.Close()
+		closeClient()
+		return nil, fmt.Errorf("error wrapping writer for resource at %q: %w", input.URI, err)
+	}
+	return &WriteToResourceOutput{
+		Writer: &FunctionWriteCloser{
+			Writer: func(p []byte) (n int, err error) { return ww.Write(p) },
+			Closer: func() error {
+				errClose := ww.Close()
+				closeClient()
+				return errClose
+			},
+		},
+	}, nil
+}
+
+func writeToGCS(input *WriteToResourceInput, p string) (*WriteToResourceOutput, error) {
+	if input.Append {
+		return nil, fmt.Errorf("error writing to resource at %q: Google Cloud Storage does not support appending to objects", input.URI)
+	}
+	bucket, object, err := gcs.SplitPath(p)
+	if err != nil {
+		return nil, err
+	}
+	client := input.GCSClient
+	if client == nil {
+		client, err = gcs.NewClient(&gcs.NewClientInput{})
+		if err != nil {
+			return nil, fmt.Errorf("error creating Google Cloud Storage client: %w", err)
+		}
+	}
+	w, err := gcs.NewWriter(&gcs.NewWriterInput{
+		Client:          client,
+		Bucket:          bucket,
+		Object:          object,
+		ContentType:     input.ContentType,
+		ContentEncoding: input.ContentEncoding,
+		CacheControl:    input.CacheControl,
+		Metadata:        input.Metadata,
+		Retry:           input.Retry,
+		Context:         input.ctx(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating writer for resource at %q: %w", input.URI, err)
+	}
+	ww, err := WrapWriter(w, input.Alg, input.Dict, input.BufferSize)
+	if err != nil {
+		// cancel the resumable upload, which was started by NewWriter
+		_ = w.CloseWithError(err)
+		return nil, fmt.Errorf("error wrapping writer for resource at %q: %w", input.URI, err)
+	}
+	return &WriteToResourceOutput{Writer: ww}, nil
+}
+
+// writeToS3 returns a writer that streams an object to AWS S3 or a S3-compatible service.
+// The object is uploaded in parts as it is written and is completed when the writer is closed.
+func writeToS3(input *WriteToResourceInput, p string) (*WriteToResourceOutput, error) {
+	if input.Append {
+		return nil, fmt.Errorf("error writing to resource at %q: AWS S3 does not support appending to objects", input.URI)
+	}
+	if input.S3Client == nil {
+		return nil, fmt.Errorf("error writing to resource at %q: missing AWS S3 client", input.URI)
+	}
+	i := strings.Index(p, "/")
+	if i == -1 {
+		return nil, fmt.Errorf("error writing to resource at %q: path missing bucket", input.URI)
+	}
+	bucket, key := p[0:i], p[i+1:]
+	contentEncoding := input.ContentEncoding
+	if len(contentEncoding) == 0 {
+		contentEncoding = pkgalg.ContentEncoding(input.Alg)
+	}
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := UploadS3Object(&UploadS3ObjectInput{
+			ACL:                  input.ACL,
+			Bucket:               bucket,
+			Key:                  key,
+			Object:               pr,
+			Client:               input.S3Client,
+			ContentType:          input.ContentType,
+			ContentEncoding:      contentEncoding,
+			CacheControl:         input.CacheControl,
+			Metadata:             input.Metadata,
+			Tags:                 input.Tags,
+			StorageClass:         input.StorageClass,
+			ServerSideEncryption: input.ServerSideEncryption,
+			SSEKMSKeyID:          input.SSEKMSKeyID,
+			SSECustomerKey:       input.SSECustomerKey,
+			Context:              input.ctx(),
+		})
+		// if the upload failed, then return the error from any further writes
+		_ = pr.CloseWithError(err)
+		done <- err
+	}()
+	ww, err := WrapWriter(pw, input.Alg, input.Dict, input.BufferSize)
+	if err != nil {
+		_ = pw.CloseWithError(err)
+		<-done
+		return nil, fmt.Errorf("error wrapping writer for resource at %q: %w", input.URI, err)
+	}
+	return &WriteToResourceOutput{
+		Writer: &FunctionWriteCloser{
+			Writer: func(p []byte) (n int, err error) { return ww.Write(p) },
+			Closer: func() error {
+				// the buffered writer does not flush when closed, so flush any buffered data before completing the upload.
+				var errClose error
+				if f, ok := ww.(interface{ Flush() error }); ok {
+					errClose = f.Flush()
+				}
+				if errClose == nil {
+					errClose = ww.Close()
+				}
+				if errClose != nil {
+					// abort the upload, so that the incomplete object is not created
+					_ = pw.CloseWithError(errClose)
+				}
+				errUpload := <-done
+				if errClose != nil {
+					return fmt.Errorf("error closing writer for resource at %q: %w", input.URI, errClose)
+				}
+				if errUpload != nil {
+					return fmt.Errorf("error uploading resource at %q: %w", input.URI, errUpload)
+				}
+				return nil
+			},
+		},
+	}, nil
+}
+
+func writeToAzureBlob(input *WriteToResourceInput) (*WriteToResourceOutput, error) {
+	if input.Append {
+		return nil, fmt.Errorf("error writing to resource at %q: Azure Blob Storage does not support appending to block blobs", input.URI)
+	}
+	client, container, blob, err := azureBlobClient(input.AzureBlobClient, input.URI)
+	if err != nil {
+		return nil, err
+	}
+	w, err := azblob.NewWriter(&azblob.NewWriterInput{
+		Client:          client,
+		Container:       container,
+		Blob:            blob,
+		ContentType:     input.ContentType,
+		ContentEncoding: input.ContentEncoding,
+		CacheControl:    input.CacheControl,
+		Metadata:        input.Metadata,
+		Retry:           input.Retry,
+		Context:         input.ctx(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating writer for resource at %q: %w", input.URI, err)
+	}
+	ww, err := WrapWriter(w, input.Alg, input.Dict, input.BufferSize)
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping writer for resource at %q: %w", input.URI, err)
+	}
+	return &WriteToResourceOutput{Writer: ww}, nil
+}
+
+func writeToWebDAV(input *WriteToResourceInput) (*WriteToResourceOutput, error) {
+	if input.Append {
+		return nil, fmt.Errorf("error writing to resource at %q: WebDAV does not support appending to resources", input.URI)
+	}
+	if input.Parents {
+		if i := strings.LastIndex(input.URI, "/"); i != -1 {
+			err := input.Retry.Do(input.ctx(), func() error {
+				return webdav.MkdirAll(input.ctx(), input.URI[0:i])
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error creating parent collections: %w", err)
+			}
+		}
+	}
+	w, err := webdav.WriteFile(input.ctx(), input.URI)
+	if err != nil {
+		return nil, fmt.Errorf("error creating writer for resource at %q: %w", input.URI, err)
+	}
+	ww, err := WrapWriter(w, input.Alg, input.Dict, input.BufferSize)
+	if err != nil {
+		// abort the request, which was started by WriteFile
+		_ = w.CloseWithError(err)
+		return nil, fmt.Errorf("error wrapping writer for resource at %q: %w", input.URI, err)
+	}
+	return &WriteToResourceOutput{Writer: ww}, nil
+}
+
 // WriteToResource returns a ByteWriteCloser and error, if any.
 func WriteToResource(input *WriteToResourceInput) (*WriteToResourceOutput, error) {
 
@@ -121,9 +542,22 @@ func WriteToResource(input *WriteToResourceInput) (*WriteToResourceOutput, error
 	}
 
 	scheme, path := splitter.SplitURI(input.URI)
+	if _, ok := azblob.ParseBlobURL(input.URI); ok {
+		scheme = schemes.SchemeAzureBlob
+	}
 	switch scheme {
+	case schemes.SchemeAzureBlob:
+		return writeToAzureBlob(input)
 	case schemes.SchemeSFTP:
 		return writeToSFTP(input)
+	case schemes.SchemeSSH, schemes.SchemeSSHCommand:
+		return writeToSSH(input)
+	case schemes.SchemeGCS:
+		return writeToGCS(input, path)
+	case schemes.SchemeS3:
+		return writeToS3(input, path)
+	case schemes.SchemeWebDAV, schemes.SchemeWebDAVS:
+		return writeToWebDAV(input)
 	case schemes.SchemeFile, "":
 
 		pathExpanded, err := homedir.Expand(path)
@@ -135,11 +569,11 @@ func WriteToResource(input *WriteToResourceInput) (*WriteToResourceOutput, error
 		if input.Append {
 			flag = os.O_APPEND | os.O_CREATE | os.O_WRONLY
 		} else {
-			flag = os.O_CREATE | os.O_WRONLY
+			flag = os.O_CREATE | os.O_TRUNC | os.O_WRONLY
 		}
 
 		if input.Parents {
-			err = os.MkdirAll(filepath.Dir(pathExpanded), 0770)
+			err = os.MkdirAll(filepath.Dir(pathExpanded), stdos.FileMode(input.dirMode()))
 			if err != nil {
 				return nil, fmt.Errorf("error creating parent directories: %w", err)
 			}
@@ -8,11 +8,34 @@
 package grw
 
 import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/webdav"
 
 	pkgalg "github.com/spatialcurrent/go-reader-writer/pkg/alg"
+	"github.com/spatialcurrent/go-reader-writer/pkg/io"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob/azblobtest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs/gcstest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
 )
 
 func TestWriteToStdout(t *testing.T) {
@@ -30,3 +53,694 @@ func TestWriteToStdout(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, len(BytesHelloWorld), n)
 }
+
+func TestWriteToResourceFileOverwrite(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(p, []byte("hello world, hello world"), 0640))
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI: "file://" + p,
+		Alg: pkgalg.AlgorithmNone,
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	require.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+
+	// the existing file is truncated, so no bytes of the longer previous content remain
+	got, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+}
+
+func TestWriteToResourceWebDAV(t *testing.T) {
+	server := httptest.NewServer(&webdav.Handler{
+		FileSystem: webdav.NewMemFS(),
+		LockSystem: webdav.NewMemLS(),
+	})
+	defer server.Close()
+
+	uri := "webdav://" + strings.TrimPrefix(server.URL, "http://") + "/a/b/hello.txt.gz"
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:     uri,
+		Alg:     pkgalg.AlgorithmGzip,
+		Parents: true,
+	})
+	require.NoError(t, err)
+	n, err := output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	assert.Equal(t, len(BytesHelloWorld), n)
+	require.NoError(t, output.Writer.Close())
+
+	input, err := ReadFromResource(&ReadFromResourceInput{
+		URI: uri,
+		Alg: pkgalg.AlgorithmGzip,
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(input.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+	assert.NotEmpty(t, input.Metadata.ETag)
+
+	_, err = WriteToResource(&WriteToResourceInput{
+		URI:    uri,
+		Alg:    pkgalg.AlgorithmNone,
+		Append: true,
+	})
+	assert.Error(t, err)
+}
+
+func TestWriteToResourceWebDAVCanceled(t *testing.T) {
+	fs := webdav.NewMemFS()
+	server := httptest.NewServer(&webdav.Handler{
+		FileSystem: fs,
+		LockSystem: webdav.NewMemLS(),
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := WriteToResource(&WriteToResourceInput{
+		URI:     "webdav://" + strings.TrimPrefix(server.URL, "http://") + "/a/b/hello.txt",
+		Alg:     pkgalg.AlgorithmNone,
+		Parents: true,
+		Retry:   &retry.Policy{Attempts: 3, BaseDelay: time.Hour},
+		Context: ctx,
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// no collections are created
+	_, err = fs.Stat(context.Background(), "/a")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestWriteToResourceWebDAVUnsupported(t *testing.T) {
+	active := int32(0)
+	handler := &webdav.Handler{
+		FileSystem: webdav.NewMemFS(),
+		LockSystem: webdav.NewMemLS(),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	defer server.CloseClientConnections()
+
+	_, err := WriteToResource(&WriteToResourceInput{
+		URI: "webdav://" + strings.TrimPrefix(server.URL, "http://") + "/hello.txt.bz2",
+		Alg: pkgalg.AlgorithmBzip2,
+	})
+	var errWriterNotImplemented *ErrWriterNotImplemented
+	require.True(t, errors.As(err, &errWriterNotImplemented), "expected writer not implemented, but got %v", err)
+
+	// the request started for the writer is aborted
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&active) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWriteToResourceGCSUnsupported(t *testing.T) {
+	server := gcstest.NewServer()
+	defer server.Close()
+	server.CreateBucket("bucket")
+
+	_, err := WriteToResource(&WriteToResourceInput{
+		URI:       "gs://bucket/hello.txt.bz2",
+		Alg:       pkgalg.AlgorithmBzip2,
+		GCSClient: server.GCSClient(),
+	})
+	var errWriterNotImplemented *ErrWriterNotImplemented
+	require.True(t, errors.As(err, &errWriterNotImplemented), "expected writer not implemented, but got %v", err)
+
+	// the upload started for the writer is cancelled
+	assert.Equal(t, 0, server.Uploads())
+	_, ok := server.GetObject("bucket", "hello.txt.bz2")
+	assert.False(t, ok)
+}
+
+func TestWriteToResourceSSH(t *testing.T) {
+	server := ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.DisableSFTP = true
+	})
+	defer server.Close()
+
+	p := filepath.Join(t.TempDir(), "a", "b", "hello.txt.gz")
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:             server.URI("ssh", p),
+		Alg:             pkgalg.AlgorithmGzip,
+		Parents:         true,
+		Mode:            0640,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+
+	input, err := ReadFromResource(&ReadFromResourceInput{
+		URI:             server.URI("ssh", p),
+		Alg:             pkgalg.AlgorithmGzip,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(input.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+
+	fi, err := os.Stat(p)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), fi.Mode().Perm())
+
+	// write to the standard input of a command
+	upper := filepath.Join(t.TempDir(), "upper.txt")
+	output, err = WriteToResource(&WriteToResourceInput{
+		URI:             fmt.Sprintf("ssh+cmd://%s@%s?cmd=%s", server.User, server.Addr(), url.QueryEscape("tr a-z A-Z > "+upper)),
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write([]byte("hello world"))
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+	b, err := os.ReadFile(upper)
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO WORLD", string(b))
+
+	// the command fails
+	output, err = WriteToResource(&WriteToResourceInput{
+		URI:             fmt.Sprintf("ssh+cmd://%s@%s?cmd=%s", server.User, server.Addr(), url.QueryEscape("cat > /dev/null; exit 1")),
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write([]byte("hello world"))
+	assert.NoError(t, err)
+	assert.Error(t, output.Writer.Close())
+}
+
+func TestWriteToResourceSFTPAppend(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	p := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(p, []byte("hello\n"), 0600))
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:             server.URI("sftp", p),
+		Alg:             pkgalg.AlgorithmNone,
+		Append:          true,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write([]byte("world\n"))
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+
+	got, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", string(got))
+}
+
+func TestWriteToResourceSFTPParents(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	p := filepath.Join(dir, "a", "b", "hello.txt")
+
+	_, err := WriteToResource(&WriteToResourceInput{
+		URI:             server.URI("sftp", p),
+		Alg:             pkgalg.AlgorithmNone,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	assert.Error(t, err)
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:             server.URI("sftp", p),
+		Alg:             pkgalg.AlgorithmNone,
+		Parents:         true,
+		DirMode:         0750,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+
+	got, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+	fi, err := os.Stat(filepath.Join(dir, "a"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0750), fi.Mode().Perm())
+}
+
+func TestWriteToResourceSFTPError(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	p := filepath.Join(t.TempDir(), "missing", "hello.txt")
+
+	for i := 0; i < 3; i++ {
+		_, err := WriteToResource(&WriteToResourceInput{
+			URI:             server.URI("sftp", p),
+			Alg:             pkgalg.AlgorithmNone,
+			Password:        server.Password,
+			HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+		})
+		assert.Error(t, err)
+	}
+
+	// the connections dialed for the outputs that could not be opened are closed
+	assert.Equal(t, 3, server.Handshakes())
+	assert.Eventually(t, func() bool {
+		return server.Connections() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWriteToResourceSFTPAtomic(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	p := filepath.Join(dir, "hello.txt")
+	require.NoError(t, os.WriteFile(p, []byte("old"), 0600))
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:             server.URI("sftp", p),
+		Alg:             pkgalg.AlgorithmNone,
+		Atomic:          true,
+		Mode:            0640,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+
+	// the file is unchanged until the writer is closed
+	got, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(got))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	require.NoError(t, output.Writer.Close())
+
+	got, err = os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+	fi, err := os.Stat(p)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), fi.Mode().Perm())
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	_, err = WriteToResource(&WriteToResourceInput{
+		URI:             server.URI("sftp", p),
+		Alg:             pkgalg.AlgorithmNone,
+		Atomic:          true,
+		Append:          true,
+		Password:        server.Password,
+		HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+	})
+	assert.Error(t, err)
+}
+
+func TestWriteToResourceSFTPPool(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	pool := sftp2.NewPool(&sftp2.NewPoolInput{})
+	defer pool.Close()
+
+	dir := t.TempDir()
+	for i := 0; i < 5; i++ {
+		uri := server.URI("sftp", filepath.Join(dir, fmt.Sprintf("hello-%d.txt.gz", i)))
+		output, err := WriteToResource(&WriteToResourceInput{
+			URI:             uri,
+			Alg:             pkgalg.AlgorithmGzip,
+			SFTPPool:        pool,
+			Password:        server.Password,
+			HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+		})
+		require.NoError(t, err)
+		_, err = output.Writer.Write(BytesHelloWorld)
+		assert.NoError(t, err)
+		require.NoError(t, output.Writer.Close())
+
+		input, err := ReadFromResource(&ReadFromResourceInput{
+			URI:             uri,
+			Alg:             pkgalg.AlgorithmGzip,
+			SFTPPool:        pool,
+			Password:        server.Password,
+			HostKeyCallback: ssh.FixedHostKey(server.HostKey.PublicKey()),
+		})
+		require.NoError(t, err)
+		got, err := io.ReadAllAndClose(input.Reader)
+		assert.NoError(t, err)
+		assert.Equal(t, BytesHelloWorld, got)
+	}
+
+	// every file was written and read using the same connection
+	assert.Equal(t, 1, server.Handshakes())
+	assert.Equal(t, 1, pool.Len())
+}
+
+func TestWriteToResourceGCS(t *testing.T) {
+	server := gcstest.NewServer()
+	defer server.Close()
+	server.CreateBucket("bucket")
+	client := server.GCSClient()
+
+	uri := "gs://bucket/a/b/hello.txt.gz"
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:       uri,
+		Alg:       pkgalg.AlgorithmGzip,
+		GCSClient: client,
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+
+	input, err := ReadFromResource(&ReadFromResourceInput{
+		URI:       uri,
+		Alg:       pkgalg.AlgorithmGzip,
+		GCSClient: client,
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(input.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+	require.NotNil(t, input.Metadata)
+	assert.NotEmpty(t, input.Metadata.ETag)
+	assert.NotNil(t, input.Metadata.LastModified)
+	assert.Equal(t, "STANDARD", input.Metadata.StorageClass)
+
+	server.PutObject("bucket", "c.txt", []byte("hello world"), "text/plain")
+
+	input, err = ReadFromResource(&ReadFromResourceInput{
+		URI:       "gs://bucket/c.txt",
+		Alg:       pkgalg.AlgorithmNone,
+		GCSClient: client,
+		Offset:    6,
+	})
+	require.NoError(t, err)
+	got, err = io.ReadAllAndClose(input.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "world", string(got))
+	assert.Equal(t, "text/plain", input.Metadata.ContentType)
+	assert.Equal(t, int64(11), input.Metadata.ContentLength)
+
+	_, err = ReadFromResource(&ReadFromResourceInput{
+		URI:       "gs://bucket/c.txt",
+		Alg:       pkgalg.AlgorithmNone,
+		GCSClient: client,
+		Offset:    6,
+		IfRange:   "\"changed\"",
+	})
+	assert.True(t, errors.Is(err, ErrResourceChanged))
+
+	_, err = ReadFromResource(&ReadFromResourceInput{
+		URI:       "gs://bucket/missing.txt",
+		Alg:       pkgalg.AlgorithmNone,
+		GCSClient: client,
+	})
+	assert.Error(t, err)
+}
+
+func TestWriteToResourceAzureBlob(t *testing.T) {
+	server := azblobtest.NewServer()
+	defer server.Close()
+	server.CreateContainer("container")
+	client := server.AzblobClient()
+
+	uri := "azblob://container/a/b/hello.txt.gz"
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:             uri,
+		Alg:             pkgalg.AlgorithmGzip,
+		AzureBlobClient: client,
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+
+	input, err := ReadFromResource(&ReadFromResourceInput{
+		URI:             uri,
+		Alg:             pkgalg.AlgorithmGzip,
+		AzureBlobClient: client,
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(input.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+	require.NotNil(t, input.Metadata)
+	assert.NotEmpty(t, input.Metadata.ETag)
+	assert.NotNil(t, input.Metadata.LastModified)
+	assert.Equal(t, "Hot", input.Metadata.StorageClass)
+
+	server.PutBlob("container", "c.txt", []byte("hello world"), "text/plain")
+
+	input, err = ReadFromResource(&ReadFromResourceInput{
+		URI:             "azblob://container/c.txt",
+		Alg:             pkgalg.AlgorithmNone,
+		AzureBlobClient: client,
+		Offset:          6,
+	})
+	require.NoError(t, err)
+	got, err = io.ReadAllAndClose(input.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "world", string(got))
+	assert.Equal(t, "text/plain", input.Metadata.ContentType)
+	assert.Equal(t, int64(11), input.Metadata.ContentLength)
+
+	_, err = ReadFromResource(&ReadFromResourceInput{
+		URI:             "azblob://container/c.txt",
+		Alg:             pkgalg.AlgorithmNone,
+		AzureBlobClient: client,
+		Offset:          6,
+		IfRange:         "\"changed\"",
+	})
+	assert.True(t, errors.Is(err, ErrResourceChanged))
+
+	_, err = ReadFromResource(&ReadFromResourceInput{
+		URI:             "azblob://container/missing.txt",
+		Alg:             pkgalg.AlgorithmNone,
+		AzureBlobClient: client,
+	})
+	assert.Error(t, err)
+
+	_, err = WriteToResource(&WriteToResourceInput{
+		URI:             uri,
+		Alg:             pkgalg.AlgorithmNone,
+		Append:          true,
+		AzureBlobClient: client,
+	})
+	assert.Error(t, err)
+}
+
+func TestWriteToResourceS3(t *testing.T) {
+	isolateAWSEnvironment(t, "", "")
+
+	mutex := &sync.Mutex{}
+	objects := map[string][]byte{}
+	headers := map[string]http.Header{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			objects[r.URL.Path] = body
+			headers[r.URL.Path] = r.Header.Clone()
+			w.Header().Set("ETag", fmt.Sprintf("\"%d\"", len(objects)))
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("ETag", "\"1\"")
+			if contentEncoding := headers[r.URL.Path].Get("Content-Encoding"); len(contentEncoding) > 0 && r.Header.Get("Accept-Encoding") == "identity" {
+				w.Header().Set("Content-Encoding", contentEncoding)
+			}
+			_, _ = w.Write(body)
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, _, err := NewS3Client(&NewS3ClientInput{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		Endpoint:        server.URL,
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+
+	uri := "s3://bucket/a/b/hello.txt.gz"
+
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:      uri,
+		Alg:      pkgalg.AlgorithmGzip,
+		S3Client: client,
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+	assert.Contains(t, objects, "/bucket/a/b/hello.txt.gz")
+	assert.Equal(t, "gzip", headers["/bucket/a/b/hello.txt.gz"].Get("Content-Encoding"))
+
+	input, err := ReadFromResource(&ReadFromResourceInput{
+		URI:      uri,
+		Alg:      pkgalg.AlgorithmGzip,
+		S3Client: client,
+	})
+	require.NoError(t, err)
+	got, err := io.ReadAllAndClose(input.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+
+	input, err = ReadFromResource(&ReadFromResourceInput{
+		URI:       uri,
+		DetectAlg: true,
+		S3Client:  client,
+	})
+	require.NoError(t, err)
+	got, err = io.ReadAllAndClose(input.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, BytesHelloWorld, got)
+
+	output, err = WriteToResource(&WriteToResourceInput{
+		URI:        "s3://bucket/c.txt",
+		Alg:        pkgalg.AlgorithmNone,
+		BufferSize: 4096,
+		S3Client:   client,
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+	assert.Equal(t, BytesHelloWorld, objects["/bucket/c.txt"])
+	assert.Empty(t, headers["/bucket/c.txt"].Get("Content-Encoding"))
+
+	output, err = WriteToResource(&WriteToResourceInput{
+		URI:                  "s3://bucket/d.txt",
+		Alg:                  pkgalg.AlgorithmNone,
+		CacheControl:         "no-cache",
+		ContentType:          "text/plain",
+		Metadata:             map[string]string{"source": "grw"},
+		S3Client:             client,
+		ServerSideEncryption: "aws:kms",
+		SSEKMSKeyID:          "alias/example",
+		StorageClass:         "STANDARD_IA",
+		Tags:                 map[string]string{"project": "a b", "team": "data"},
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+	assert.Equal(t, "no-cache", headers["/bucket/d.txt"].Get("Cache-Control"))
+	assert.Equal(t, "text/plain", headers["/bucket/d.txt"].Get("Content-Type"))
+	assert.Equal(t, "grw", headers["/bucket/d.txt"].Get("X-Amz-Meta-Source"))
+	assert.Equal(t, "aws:kms", headers["/bucket/d.txt"].Get("X-Amz-Server-Side-Encryption"))
+	assert.Equal(t, "alias/example", headers["/bucket/d.txt"].Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+	assert.Equal(t, "STANDARD_IA", headers["/bucket/d.txt"].Get("X-Amz-Storage-Class"))
+	assert.Equal(t, "project=a+b&team=data", headers["/bucket/d.txt"].Get("X-Amz-Tagging"))
+
+	// customer-provided keys are only sent over https
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+	tlsClient, _, err := NewS3Client(&NewS3ClientInput{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		Endpoint:        tlsServer.URL,
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+	tlsClient.Config.HTTPClient = tlsServer.Client()
+
+	customerKey := "0123456789abcdef0123456789abcdef"
+	output, err = WriteToResource(&WriteToResourceInput{
+		URI:            "s3://bucket/e.txt",
+		Alg:            pkgalg.AlgorithmNone,
+		S3Client:       tlsClient,
+		SSECustomerKey: customerKey,
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(BytesHelloWorld)
+	assert.NoError(t, err)
+	require.NoError(t, output.Writer.Close())
+	customerKeyMD5 := md5.Sum([]byte(customerKey))
+	assert.Equal(t, "AES256", headers["/bucket/e.txt"].Get("X-Amz-Server-Side-Encryption-Customer-Algorithm"))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(customerKey)), headers["/bucket/e.txt"].Get("X-Amz-Server-Side-Encryption-Customer-Key"))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(customerKeyMD5[:]), headers["/bucket/e.txt"].Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5"))
+
+	for _, invalid := range []*WriteToResourceInput{
+		{URI: "s3://bucket/f.txt", Alg: pkgalg.AlgorithmNone, S3Client: client, SSEKMSKeyID: "alias/example"},
+		{URI: "s3://bucket/f.txt", Alg: pkgalg.AlgorithmNone, S3Client: client, SSECustomerKey: "short"},
+		{URI: "s3://bucket/f.txt", Alg: pkgalg.AlgorithmNone, S3Client: client, SSECustomerKey: customerKey, ServerSideEncryption: "AES256"},
+	} {
+		output, err = WriteToResource(invalid)
+		require.NoError(t, err)
+		assert.Error(t, output.Writer.Close())
+	}
+	assert.NotContains(t, objects, "/bucket/f.txt")
+
+	_, err = WriteToResource(&WriteToResourceInput{
+		URI:      uri,
+		Alg:      pkgalg.AlgorithmNone,
+		Append:   true,
+		S3Client: client,
+	})
+	assert.Error(t, err)
+
+	_, err = WriteToResource(&WriteToResourceInput{
+		URI: uri,
+		Alg: pkgalg.AlgorithmNone,
+	})
+	assert.Error(t, err)
+
+	forbidden := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer forbidden.Close()
+	client, _, err = NewS3Client(&NewS3ClientInput{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		Endpoint:        forbidden.URL,
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+	output, err = WriteToResource(&WriteToResourceInput{
+		URI:      uri,
+		Alg:      pkgalg.AlgorithmNone,
+		S3Client: client,
+	})
+	require.NoError(t, err)
+	_, _ = output.Writer.Write(BytesHelloWorld)
+	assert.Error(t, output.Writer.Close())
+}
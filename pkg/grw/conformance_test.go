@@ -0,0 +1,804 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grw
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/webdav"
+
+	pkgalg "github.com/spatialcurrent/go-reader-writer/pkg/alg"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grwtest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob/azblobtest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs/gcstest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/sftp2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	pkgos "github.com/spatialcurrent/go-reader-writer/pkg/os"
+)
+
+// conformanceTarget is a resource location that the conformance suite reads from and writes to.
+type conformanceTarget struct {
+	uri     func(p string) string                                   // returns the uri for the path
+	path    func(p string) string                                   // returns the local path backing the path, nil if the resources are stored in memory
+	read    func(input *ReadFromResourceInput)                      // sets the credentials and clients for reading
+	write   func(input *WriteToResourceInput)                       // sets the credentials and clients for writing, nil if the scheme is read-only
+	options *ResourceOptions                                        // the credentials and clients for Stat
+	check   func(p string, appendToFile bool, overwrite bool) error // the check the CLI runs before writing, nil if none
+	appends bool                                                    // true if appending is supported
+	flat    bool                                                    // true if the scheme has no directories, so writing never requires creating parents
+	opaque  bool                                                    // true if directories cannot be told apart from files
+	lists   bool                                                    // true if resources can be listed, which is required by List and Glob
+	removes bool                                                    // true if resources can be removed, which is required by Remove and Move
+	broken  func(p string) (string, func())                         // returns a uri and a function that makes writes to the uri fail, nil if read-only
+}
+
+// conformanceSchemes are the schemes that the conformance suite runs against.
+// Each setup function starts a new server, which is closed when the test finishes.
+// When a scheme is added, it should be added here, so that its behavior is pinned down by the suite.
+// The https and webdavs schemes use the same code as http and webdav, but with TLS, so they are not included.
+// The ssh+cmd scheme is not included, since its uris are commands rather than paths, so it is tested by TestReadFromResourceSSH and TestWriteToResourceSSH.
+var conformanceSchemes = []struct {
+	name  string
+	setup func(t *testing.T) *conformanceTarget
+}{
+	{
+		name: "file",
+		setup: func(t *testing.T) *conformanceTarget {
+			dir := t.TempDir()
+			path := func(p string) string { return filepath.Join(dir, filepath.FromSlash(p)) }
+			return &conformanceTarget{
+				uri:     func(p string) string { return "file://" + path(p) },
+				path:    path,
+				read:    func(input *ReadFromResourceInput) {},
+				write:   func(input *WriteToResourceInput) {},
+				options: &ResourceOptions{},
+				check: func(p string, appendToFile bool, overwrite bool) error {
+					return pkgos.CheckURIWrite("file://"+path(p), appendToFile, overwrite)
+				},
+				appends: true,
+				lists:   true,
+				removes: true,
+				broken: func(p string) (string, func()) {
+					if _, err := os.Stat("/dev/full"); err != nil {
+						return "", nil
+					}
+					return "file:///dev/full", func() {}
+				},
+			}
+		},
+	},
+	{
+		name: "sftp",
+		setup: func(t *testing.T) *conformanceTarget {
+			server := grwtest.NewSFTPServer()
+			t.Cleanup(server.Close)
+			pool := sftp2.NewPool(&sftp2.NewPoolInput{})
+			t.Cleanup(func() { _ = pool.Close() })
+			return &conformanceTarget{
+				uri:  server.URI,
+				path: server.Path,
+				read: func(input *ReadFromResourceInput) {
+					input.Password = server.Password
+					input.HostKeyCallback = server.HostKeyCallback()
+				},
+				write: func(input *WriteToResourceInput) {
+					input.Password = server.Password
+					input.HostKeyCallback = server.HostKeyCallback()
+				},
+				options: &ResourceOptions{
+					Password:        server.Password,
+					HostKeyCallback: server.HostKeyCallback(),
+				},
+				check: func(p string, appendToFile bool, overwrite bool) error {
+					options, err := NewSSHClientOptions(&NewSSHClientOptionsInput{
+						Password:        server.Password,
+						HostKeyCallback: server.HostKeyCallback(),
+					})
+					if err != nil {
+						return err
+					}
+					client, err := pool.Get(server.URI(p), options...)
+					if err != nil {
+						return err
+					}
+					defer func() { _ = pool.Release(client) }()
+					return sftp2.CheckFileWrite(client, server.Path(p), appendToFile, overwrite)
+				},
+				appends: true,
+				lists:   true,
+				removes: true,
+				broken: func(p string) (string, func()) {
+					return server.URI(p), server.Server.CloseConnections
+				},
+			}
+		},
+	},
+	{
+		name: "ftp",
+		setup: func(t *testing.T) *conformanceTarget {
+			server := grwtest.NewFTPServer()
+			t.Cleanup(server.Close)
+			return &conformanceTarget{
+				uri:     server.URI,
+				path:    server.Path,
+				read:    func(input *ReadFromResourceInput) {},
+				options: &ResourceOptions{},
+				lists:   true,
+				removes: true,
+			}
+		},
+	},
+	{
+		name: "http",
+		setup: func(t *testing.T) *conformanceTarget {
+			server := grwtest.NewHTTPServer()
+			t.Cleanup(server.Close)
+			return &conformanceTarget{
+				uri:     server.URI,
+				path:    server.Path,
+				read:    func(input *ReadFromResourceInput) {},
+				options: &ResourceOptions{},
+				opaque:  true,
+				removes: true,
+			}
+		},
+	},
+	{
+		name: "s3",
+		setup: func(t *testing.T) *conformanceTarget {
+			server := grwtest.NewS3Server()
+			t.Cleanup(server.Close)
+			require.NoError(t, server.CreateBucket("bucket"))
+			client := server.S3Client()
+			return &conformanceTarget{
+				uri:  func(p string) string { return server.URI("bucket/" + p) },
+				path: func(p string) string { return server.Path("bucket/" + p) },
+				read: func(input *ReadFromResourceInput) {
+					input.S3Client = client
+				},
+				write: func(input *WriteToResourceInput) {
+					input.S3Client = client
+				},
+				options: &ResourceOptions{S3Client: client},
+				flat:    true,
+				lists:   true,
+				removes: true,
+				broken: func(p string) (string, func()) {
+					// the object is uploaded when the writer is closed, which fails once the bucket is gone
+					return server.URI("bucket/" + p), func() { _ = os.RemoveAll(server.Path("bucket")) }
+				},
+			}
+		},
+	},
+	{
+		name: "ssh",
+		setup: func(t *testing.T) *conformanceTarget {
+			server := grwtest.NewSFTPServer()
+			t.Cleanup(server.Close)
+			uri := func(p string) string { return server.Server.URI("ssh", server.Path(p)) }
+			return &conformanceTarget{
+				uri:  uri,
+				path: server.Path,
+				read: func(input *ReadFromResourceInput) {
+					input.Password = server.Password
+					input.HostKeyCallback = server.HostKeyCallback()
+				},
+				write: func(input *WriteToResourceInput) {
+					input.Password = server.Password
+					input.HostKeyCallback = server.HostKeyCallback()
+				},
+				options: &ResourceOptions{
+					Password:        server.Password,
+					HostKeyCallback: server.HostKeyCallback(),
+				},
+				check: func(p string, appendToFile bool, overwrite bool) error {
+					client, err := dialSSH(uri(p), &NewSSHClientOptionsInput{
+						Password:        server.Password,
+						HostKeyCallback: server.HostKeyCallback(),
+					})
+					if err != nil {
+						return err
+					}
+					defer client.Close()
+					return ssh2.CheckFileWrite(client, server.Path(p), appendToFile, overwrite)
+				},
+				appends: true,
+				broken: func(p string) (string, func()) {
+					return uri(p), server.Server.CloseConnections
+				},
+			}
+		},
+	},
+	{
+		name: "webdav",
+		setup: func(t *testing.T) *conformanceTarget {
+			dir := t.TempDir()
+			handler := &webdav.Handler{FileSystem: webdav.Dir(dir), LockSystem: webdav.NewMemLS()}
+			full := int32(0)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// once full, the server rejects the body of a PUT request, like a server that is out of space
+				if r.Method == http.MethodPut {
+					b, err := io.ReadAll(r.Body)
+					if err != nil || atomic.LoadInt32(&full) == 1 {
+						w.WriteHeader(http.StatusInsufficientStorage)
+						return
+					}
+					r.Body = io.NopCloser(bytes.NewReader(b))
+				}
+				handler.ServeHTTP(w, r)
+			}))
+			t.Cleanup(server.Close)
+			path := func(p string) string { return filepath.Join(dir, filepath.FromSlash(p)) }
+			uri := func(p string) string { return "webdav://" + server.Listener.Addr().String() + "/" + p }
+			return &conformanceTarget{
+				uri:     uri,
+				path:    path,
+				read:    func(input *ReadFromResourceInput) {},
+				write:   func(input *WriteToResourceInput) {},
+				options: &ResourceOptions{},
+				lists:   true,
+				removes: true,
+				broken: func(p string) (string, func()) {
+					return uri(p), func() { atomic.StoreInt32(&full, 1) }
+				},
+			}
+		},
+	},
+	{
+		name: "gs",
+		setup: func(t *testing.T) *conformanceTarget {
+			server := gcstest.NewServer()
+			t.Cleanup(server.Close)
+			server.CreateBucket("bucket")
+			client := server.GCSClient()
+			return &conformanceTarget{
+				uri: func(p string) string { return "gs://bucket/" + p },
+				read: func(input *ReadFromResourceInput) {
+					input.GCSClient = client
+				},
+				write: func(input *WriteToResourceInput) {
+					input.GCSClient = client
+				},
+				options: &ResourceOptions{GCSClient: client},
+				flat:    true,
+				lists:   true,
+				broken: func(p string) (string, func()) {
+					// the object is uploaded when the writer is closed, which fails once the server is gone
+					return "gs://bucket/" + p, server.Close
+				},
+			}
+		},
+	},
+	{
+		name: "azblob",
+		setup: func(t *testing.T) *conformanceTarget {
+			server := azblobtest.NewServer()
+			t.Cleanup(server.Close)
+			server.CreateContainer("container")
+			client := server.AzblobClient()
+			return &conformanceTarget{
+				uri: func(p string) string { return "azblob://container/" + p },
+				read: func(input *ReadFromResourceInput) {
+					input.AzureBlobClient = client
+				},
+				write: func(input *WriteToResourceInput) {
+					input.AzureBlobClient = client
+				},
+				options: &ResourceOptions{AzureBlobClient: client},
+				flat:    true,
+				lists:   true,
+				broken: func(p string) (string, func()) {
+					// the blob is uploaded when the writer is closed, which fails once the server is gone
+					return "azblob://container/" + p, server.Close
+				},
+			}
+		},
+	},
+}
+
+// readOnlyAlgorithms are the algorithms that are only implemented for reading, with a compressed fixture of BytesHelloWorld.
+var readOnlyAlgorithms = map[string]string{
+	pkgalg.AlgorithmBzip2: "../../testdata/doc.txt.bz2",
+	pkgalg.AlgorithmZip:   "../../testdata/doc.txt.zip",
+}
+
+// writeConformance writes the data to the path of the target and returns the first error, if any.
+func writeConformance(target *conformanceTarget, p string, alg string, data []byte, f func(input *WriteToResourceInput)) error {
+	input := &WriteToResourceInput{
+		URI: target.uri(p),
+		Alg: alg,
+	}
+	if target.write != nil {
+		target.write(input)
+	}
+	if f != nil {
+		f(input)
+	}
+	output, err := WriteToResource(input)
+	if err != nil {
+		return err
+	}
+	_, err = output.Writer.Write(data)
+	if err != nil {
+		_ = output.Writer.Close()
+		return err
+	}
+	return closeConformance(output.Writer)
+}
+
+// closeConformance flushes and closes the writer, like WriteAllAndClose, and returns the first error, if any.
+func closeConformance(w io.WriteCloser) error {
+	if flusher, ok := w.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// putConformance creates the resource at the path of the target with the data.
+// If the target is read-only, then the data is written to the local path backing the target.
+func putConformance(t *testing.T, target *conformanceTarget, p string, alg string, data []byte) {
+	if target.write != nil {
+		require.NoError(t, writeConformance(target, p, alg, data, func(input *WriteToResourceInput) {
+			input.Parents = true
+		}))
+		return
+	}
+	output, err := WriteToResource(&WriteToResourceInput{
+		URI:     "file://" + target.path(p),
+		Alg:     alg,
+		Parents: true,
+	})
+	require.NoError(t, err)
+	_, err = output.Writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, closeConformance(output.Writer))
+}
+
+// readConformance reads all the data from the resource at the path of the target.
+func readConformance(target *conformanceTarget, p string, alg string) ([]byte, error) {
+	input := &ReadFromResourceInput{
+		URI: target.uri(p),
+		Alg: alg,
+	}
+	target.read(input)
+	output, err := ReadFromResource(input)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(output.Reader)
+	if err != nil {
+		_ = output.Reader.Close()
+		return nil, err
+	}
+	return b, output.Reader.Close()
+}
+
+func runConformance(t *testing.T, f func(t *testing.T, target *conformanceTarget)) {
+	for _, scheme := range conformanceSchemes {
+		scheme := scheme
+		t.Run(scheme.name, func(t *testing.T) {
+			f(t, scheme.setup(t))
+		})
+	}
+}
+
+func TestConformanceRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog\n"), 1000)
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		for _, alg := range Algorithms {
+			alg := alg
+			t.Run(alg, func(t *testing.T) {
+				p := "roundtrip/data." + alg
+				if fixture, ok := readOnlyAlgorithms[alg]; ok {
+					if target.write != nil {
+						err := writeConformance(target, p, alg, data, func(input *WriteToResourceInput) {
+							input.Parents = true
+						})
+						var errWriterNotImplemented *ErrWriterNotImplemented
+						require.True(t, errors.As(err, &errWriterNotImplemented), "expected writer not implemented, but got %v", err)
+					}
+					// read the compressed fixture instead
+					b, err := ioutil.ReadFile(fixture)
+					require.NoError(t, err)
+					putConformance(t, target, p, pkgalg.AlgorithmNone, b)
+					got, err := readConformance(target, p, alg)
+					require.NoError(t, err)
+					assert.Equal(t, BytesHelloWorld, got)
+					return
+				}
+				putConformance(t, target, p, alg, data)
+				got, err := readConformance(target, p, alg)
+				require.NoError(t, err)
+				assert.Equal(t, data, got)
+			})
+		}
+	})
+}
+
+func TestConformanceAppend(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "append.txt", pkgalg.AlgorithmNone, []byte("hello "))
+		err := writeConformance(target, "append.txt", pkgalg.AlgorithmNone, []byte("world"), func(input *WriteToResourceInput) {
+			input.Append = true
+		})
+		if target.write == nil || !target.appends {
+			require.Error(t, err)
+			got, errRead := readConformance(target, "append.txt", pkgalg.AlgorithmNone)
+			require.NoError(t, errRead)
+			assert.Equal(t, "hello ", string(got))
+			return
+		}
+		require.NoError(t, err)
+		got, err := readConformance(target, "append.txt", pkgalg.AlgorithmNone)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(got))
+	})
+}
+
+func TestConformanceOverwrite(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "overwrite.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+		if target.check != nil {
+			assert.Error(t, target.check("overwrite.txt", false, false))
+			assert.NoError(t, target.check("overwrite.txt", true, false))
+			assert.NoError(t, target.check("overwrite.txt", false, true))
+			assert.NoError(t, target.check("missing.txt", false, false))
+		}
+		err := writeConformance(target, "overwrite.txt", pkgalg.AlgorithmNone, []byte("foo"), nil)
+		if target.write == nil {
+			require.Error(t, err)
+			return
+		}
+		require.NoError(t, err)
+		// the existing file is truncated
+		got, err := readConformance(target, "overwrite.txt", pkgalg.AlgorithmNone)
+		require.NoError(t, err)
+		assert.Equal(t, "foo", string(got))
+	})
+}
+
+func TestConformanceParents(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		err := writeConformance(target, "a/b/c.txt", pkgalg.AlgorithmNone, []byte("hello world"), nil)
+		if target.write == nil {
+			require.Error(t, err)
+			return
+		}
+		if target.flat {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+			err = writeConformance(target, "a/b/c.txt", pkgalg.AlgorithmNone, []byte("hello world"), func(input *WriteToResourceInput) {
+				input.Parents = true
+			})
+			require.NoError(t, err)
+		}
+		got, err := readConformance(target, "a/b/c.txt", pkgalg.AlgorithmNone)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(got))
+	})
+}
+
+func TestConformanceEmpty(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "empty.txt", pkgalg.AlgorithmNone, []byte{})
+		got, err := readConformance(target, "empty.txt", pkgalg.AlgorithmNone)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestConformanceLarge(t *testing.T) {
+	// larger than the part size of multipart uploads to AWS S3
+	data := make([]byte, 6*1024*1024+1)
+	_, _ = rand.New(rand.NewSource(1)).Read(data)
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "large.bin", pkgalg.AlgorithmNone, data)
+		got, err := readConformance(target, "large.bin", pkgalg.AlgorithmNone)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(data, got), "data read does not match data written")
+	})
+}
+
+func TestConformanceMissing(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		_, err := readConformance(target, "missing.txt", pkgalg.AlgorithmNone)
+		require.Error(t, err)
+	})
+}
+
+func TestConformanceCloseError(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		if target.broken == nil {
+			t.Skip("scheme is read-only")
+		}
+		uri, fail := target.broken("broken.txt")
+		if fail == nil {
+			t.Skip("failure cannot be induced")
+		}
+		input := &WriteToResourceInput{
+			URI:        uri,
+			Alg:        pkgalg.AlgorithmNone,
+			BufferSize: DefaultBufferSize,
+		}
+		target.write(input)
+		output, err := WriteToResource(input)
+		require.NoError(t, err)
+		// the data is buffered, so the error is returned when the writer is flushed and closed
+		_, err = output.Writer.Write([]byte("hello world"))
+		require.NoError(t, err)
+		fail()
+		require.Error(t, closeConformance(output.Writer))
+	})
+}
+
+func TestConformanceStat(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "stat/a.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+
+		info, err := Stat(context.Background(), target.uri("stat/a.txt"), target.options)
+		require.NoError(t, err)
+		assert.Equal(t, target.uri("stat/a.txt"), info.URI())
+		assert.Equal(t, "a.txt", info.Name())
+		assert.Equal(t, int64(11), info.Size())
+		assert.True(t, info.IsRegular())
+		assert.False(t, info.IsDir())
+		assert.False(t, info.ModTime().IsZero())
+
+		_, err = Stat(context.Background(), target.uri("stat/missing.txt"), target.options)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, os.ErrNotExist), "expected not exist, but got %v", err)
+
+		if !target.opaque {
+			info, err = Stat(context.Background(), target.uri("stat"), target.options)
+			require.NoError(t, err)
+			assert.Equal(t, "stat", info.Name())
+			assert.True(t, info.IsDir())
+			assert.Equal(t, target.flat, info.IsPrefix())
+		}
+	})
+}
+
+// listConformance returns the paths of the entries listed at the path of the target, with a trailing slash for directories.
+func listConformance(t *testing.T, target *conformanceTarget, p string, recursive bool, maxDepth int) []string {
+	lister, err := List(context.Background(), target.uri(p), &ListOptions{
+		ResourceOptions: *target.options,
+		Recursive:       recursive,
+		MaxDepth:        maxDepth,
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, lister.Close()) }()
+	paths := []string{}
+	for lister.Next() {
+		if lister.Entry().IsDir() {
+			paths = append(paths, lister.Path()+"/")
+		} else {
+			paths = append(paths, lister.Path())
+		}
+	}
+	require.NoError(t, lister.Err())
+	return paths
+}
+
+func TestConformanceList(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "list/a.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+		putConformance(t, target, "list/b/c.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+		putConformance(t, target, "list/b/d/e.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+
+		if !target.lists {
+			_, err := List(context.Background(), target.uri("list"), &ListOptions{ResourceOptions: *target.options})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot be listed")
+			return
+		}
+
+		assert.Equal(t, []string{"a.txt", "b/"}, listConformance(t, target, "list", false, 0))
+		assert.Equal(t, []string{"a.txt", "b/", "b/c.txt", "b/d/", "b/d/e.txt"}, listConformance(t, target, "list", true, 0))
+		assert.Equal(t, []string{"a.txt", "b/", "b/c.txt", "b/d/"}, listConformance(t, target, "list", true, 2))
+		assert.Equal(t, []string{"a.txt"}, listConformance(t, target, "list/a.txt", true, 0))
+
+		lister, err := List(context.Background(), target.uri("list"), &ListOptions{ResourceOptions: *target.options})
+		require.NoError(t, err)
+		require.True(t, lister.Next())
+		assert.Equal(t, target.uri("list/a.txt"), lister.Entry().URI())
+		assert.Equal(t, int64(11), lister.Entry().Size())
+		assert.Equal(t, "a.txt", lister.Entry().Name())
+		require.True(t, lister.Next())
+		assert.Equal(t, target.flat, lister.Entry().IsPrefix())
+		require.NoError(t, lister.Close())
+		assert.False(t, lister.Next())
+
+		_, err = List(context.Background(), target.uri("list/missing"), &ListOptions{ResourceOptions: *target.options})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, os.ErrNotExist), "expected not exist, but got %v", err)
+	})
+}
+
+func TestConformanceGlob(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "glob/2026-10-01/part-0.txt", pkgalg.AlgorithmNone, []byte("a"))
+		putConformance(t, target, "glob/2026-10-01/part-1.txt.gz", pkgalg.AlgorithmGzip, []byte("b"))
+		putConformance(t, target, "glob/2026-10-02/part-0.txt", pkgalg.AlgorithmNone, []byte("c"))
+		putConformance(t, target, "glob/2026-10-02/other.txt", pkgalg.AlgorithmNone, []byte("x"))
+		putConformance(t, target, "glob/2026-11-01/part-0.txt", pkgalg.AlgorithmNone, []byte("x"))
+
+		input := &ReadFromResourceInput{URI: target.uri("glob/2026-10-*/part-*"), DetectAlg: true}
+		target.read(input)
+
+		if !target.lists {
+			_, err := ReadFromGlob(context.Background(), input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot be listed")
+			return
+		}
+
+		matches, err := ReadFromGlob(context.Background(), input)
+		require.NoError(t, err)
+		uris := []string{}
+		data := []byte{}
+		for matches.Next() {
+			uris = append(uris, matches.Entry().URI())
+			b, errRead := io.ReadAll(matches.Reader())
+			require.NoError(t, errRead)
+			data = append(data, b...)
+		}
+		require.NoError(t, matches.Err())
+		require.NoError(t, matches.Close())
+		assert.Equal(t, []string{
+			target.uri("glob/2026-10-01/part-0.txt"),
+			target.uri("glob/2026-10-01/part-1.txt.gz"),
+			target.uri("glob/2026-10-02/part-0.txt"),
+		}, uris)
+		assert.Equal(t, "abc", string(data))
+
+		lister, err := Glob(context.Background(), target.uri("glob/missing-*/part-*"), target.options)
+		require.NoError(t, err)
+		assert.False(t, lister.Next())
+		require.NoError(t, lister.Err())
+		require.NoError(t, lister.Close())
+	})
+}
+
+func TestConformanceRemove(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "rm/a.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+		putConformance(t, target, "rm/b/c.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+		putConformance(t, target, "rm/b/d/e.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+
+		if !target.removes {
+			err := Remove(context.Background(), target.uri("rm/a.txt"), &RemoveOptions{ResourceOptions: *target.options})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot be removed")
+			return
+		}
+
+		removed := []string{}
+		err := Remove(context.Background(), target.uri("rm/a.txt"), &RemoveOptions{
+			ResourceOptions: *target.options,
+			OnRemove:        func(uri string) { removed = append(removed, uri) },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{target.uri("rm/a.txt")}, removed)
+		_, err = os.Stat(target.path("rm/a.txt"))
+		assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+
+		err = Remove(context.Background(), target.uri("rm/missing.txt"), &RemoveOptions{ResourceOptions: *target.options})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, os.ErrNotExist), "expected not exist, but got %v", err)
+
+		if target.opaque {
+			return
+		}
+
+		err = Remove(context.Background(), target.uri("rm/b"), &RemoveOptions{ResourceOptions: *target.options})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not recursive")
+
+		removed = []string{}
+		err = Remove(context.Background(), target.uri("rm/b"), &RemoveOptions{
+			ResourceOptions: *target.options,
+			Recursive:       true,
+			DryRun:          true,
+			OnRemove:        func(uri string) { removed = append(removed, uri) },
+		})
+		require.NoError(t, err)
+		assert.Contains(t, removed, target.uri("rm/b/c.txt"))
+		assert.Contains(t, removed, target.uri("rm/b/d/e.txt"))
+		_, err = os.Stat(target.path("rm/b/d/e.txt"))
+		require.NoError(t, err)
+
+		err = Remove(context.Background(), target.uri("rm/b"), &RemoveOptions{ResourceOptions: *target.options, Recursive: true})
+		require.NoError(t, err)
+		_, err = os.Stat(target.path("rm/b/c.txt"))
+		assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+		_, err = os.Stat(target.path("rm/b/d/e.txt"))
+		assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+		if !target.flat {
+			_, err = os.Stat(target.path("rm/b"))
+			assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+		}
+	})
+}
+
+func TestConformanceMove(t *testing.T) {
+	runConformance(t, func(t *testing.T, target *conformanceTarget) {
+		putConformance(t, target, "mv/a.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+		putConformance(t, target, "mv/b.txt", pkgalg.AlgorithmNone, []byte("hello world"))
+
+		// move to a local file, which streams the resource unless the target is local
+		local := filepath.Join(t.TempDir(), "a.txt")
+		err := Move(context.Background(), target.uri("mv/a.txt"), local, &MoveOptions{ResourceOptions: *target.options})
+		if !target.removes {
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot be removed")
+			return
+		}
+		require.NoError(t, err)
+		b, err := os.ReadFile(local)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(b))
+		_, err = os.Stat(target.path("mv/a.txt"))
+		assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+
+		err = Move(context.Background(), target.uri("mv/missing.txt"), local, &MoveOptions{ResourceOptions: *target.options})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, os.ErrNotExist), "expected not exist, but got %v", err)
+
+		if target.write == nil {
+			return
+		}
+
+		// move within the target, which renames or copies the resource on the server
+		err = Move(context.Background(), target.uri("mv/b.txt"), target.uri("mv/c/d.txt"), &MoveOptions{ResourceOptions: *target.options, Parents: true})
+		require.NoError(t, err)
+		b, err = readConformance(target, "mv/c/d.txt", pkgalg.AlgorithmNone)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(b))
+		_, err = os.Stat(target.path("mv/b.txt"))
+		assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+
+		// move a local file into a directory on the target
+		require.NoError(t, os.WriteFile(local, []byte("hello world"), 0640))
+		err = Move(context.Background(), local, target.uri("mv/c/"), &MoveOptions{ResourceOptions: *target.options})
+		require.NoError(t, err)
+		b, err = readConformance(target, "mv/c/a.txt", pkgalg.AlgorithmNone)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(b))
+		_, err = os.Stat(local)
+		assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+
+		err = Move(context.Background(), target.uri("mv/c/a.txt"), target.uri("mv/c/d.txt"), &MoveOptions{ResourceOptions: *target.options})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, os.ErrExist), "expected exist, but got %v", err)
+
+		err = Move(context.Background(), target.uri("mv/c/a.txt"), target.uri("mv/c/d.txt"), &MoveOptions{ResourceOptions: *target.options, Overwrite: true})
+		require.NoError(t, err)
+		_, err = os.Stat(target.path("mv/c/a.txt"))
+		assert.True(t, os.IsNotExist(err), "expected not exist, but got %v", err)
+		b, err = readConformance(target, "mv/c/d.txt", pkgalg.AlgorithmNone)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(b))
+	})
+}
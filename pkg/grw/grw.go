@@ -71,11 +71,13 @@ var (
 )
 
 var (
-	ErrPathMissing = errors.New("path is missing")
+	ErrPathMissing     = errors.New("path is missing")
+	ErrResourceChanged = errors.New("resource changed since it was last read")
 )
 
 var (
 	DefaultBufferSize = 4096
+	DefaultDirMode    = uint32(0770)
 )
 
 var (
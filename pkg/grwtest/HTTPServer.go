@@ -0,0 +1,78 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grwtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// HTTPServer is a HTTP server for the files in a temporary directory.
+// The server supports range requests and conditional requests using the modification times of the files,
+// and DELETE requests that remove files.
+type HTTPServer struct {
+	Server *httptest.Server // the underlying HTTP server
+	Dir    string           // the temporary directory
+}
+
+// NewHTTPServer starts and returns a new HTTPServer with a new temporary directory.
+// The caller should call Close when finished, to shut it down and remove the directory.
+func NewHTTPServer() *HTTPServer {
+	dir, err := os.MkdirTemp("", "grwtest-http-")
+	if err != nil {
+		panic(fmt.Errorf("grwtest: error creating temporary directory: %w", err))
+	}
+	s := &HTTPServer{Dir: dir}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *HTTPServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.FileServer(http.Dir(s.Dir)).ServeHTTP(w, r)
+		return
+	}
+	p := s.Path(path.Clean("/" + r.URL.Path))
+	fi, err := os.Stat(p)
+	if err != nil || fi.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	err = os.Remove(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Addr returns the address of the server as host:port.
+func (s *HTTPServer) Addr() string {
+	return s.Server.Listener.Addr().String()
+}
+
+// URI returns the http uri for the path in the temporary directory, e.g., http://127.0.0.1:8080/a/b.txt.
+func (s *HTTPServer) URI(p string) string {
+	return s.Server.URL + "/" + strings.TrimPrefix(p, "/")
+}
+
+// Path returns the local path of the file at the path in the temporary directory.
+func (s *HTTPServer) Path(p string) string {
+	return filepath.Join(s.Dir, filepath.FromSlash(p))
+}
+
+// Close shuts down the server and removes the temporary directory.
+func (s *HTTPServer) Close() {
+	s.Server.Close()
+	_ = os.RemoveAll(s.Dir)
+}
@@ -0,0 +1,785 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grwtest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3Server is a S3-compatible server for the files in a temporary directory.
+// Buckets are the directories at the top of the temporary directory and objects are the files within them,
+// so buckets can only be addressed in the path of the url.
+//
+// The server supports getting, heading, putting, copying, and deleting objects, listing objects with versions 1 and 2 of the API,
+// multipart uploads, including parts copied from ranges of objects, and creating and listing buckets.
+// The headers of objects, such as Content-Type and x-amz-meta-*, are kept in memory.
+// Requests must be signed with the access key ID of the server, but the signatures are not verified.
+type S3Server struct {
+	Server          *httptest.Server // the underlying HTTP server
+	Dir             string           // the temporary directory
+	AccessKeyID     string           // access key ID allowed to make requests
+	SecretAccessKey string           // secret access key for the access key ID
+	Region          string           // region of the server
+	mutex           *sync.Mutex
+	headers         map[string]http.Header // headers of objects by bucket/key
+	uploads         map[string]*s3Upload   // multipart uploads by upload ID
+}
+
+// s3Upload is a multipart upload in progress.
+type s3Upload struct {
+	bucket string
+	key    string
+	header http.Header
+	parts  map[int][]byte
+}
+
+// NewS3Server starts and returns a new S3Server with a new temporary directory.
+// The caller should call Close when finished, to shut it down and remove the directory.
+func NewS3Server() *S3Server {
+	dir, err := os.MkdirTemp("", "grwtest-s3-")
+	if err != nil {
+		panic(fmt.Errorf("grwtest: error creating temporary directory: %w", err))
+	}
+	s := &S3Server{
+		Dir:             dir,
+		AccessKeyID:     DefaultAccessKeyID,
+		SecretAccessKey: DefaultSecretAccessKey,
+		Region:          DefaultRegion,
+		mutex:           &sync.Mutex{},
+		headers:         map[string]http.Header{},
+		uploads:         map[string]*s3Upload{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Addr returns the address of the server as host:port.
+func (s *S3Server) Addr() string {
+	return s.Server.Listener.Addr().String()
+}
+
+// Endpoint returns the endpoint url of the server, e.g., http://127.0.0.1:9000.
+func (s *S3Server) Endpoint() string {
+	return s.Server.URL
+}
+
+// URI returns the s3 uri for the bucket and key, e.g., s3://bucket/a/b.txt.
+func (s *S3Server) URI(p string) string {
+	return "s3://" + strings.TrimPrefix(p, "/")
+}
+
+// Path returns the local path of the object at the path, which starts with the bucket.
+func (s *S3Server) Path(p string) string {
+	return filepath.Join(s.Dir, filepath.FromSlash(p))
+}
+
+// CreateBucket creates a bucket with the given name.
+func (s *S3Server) CreateBucket(bucket string) error {
+	if err := os.MkdirAll(s.Path(bucket), 0755); err != nil {
+		return fmt.Errorf("error creating bucket %q: %w", bucket, err)
+	}
+	return nil
+}
+
+// Header returns a copy of the headers kept for the object at the path, which starts with the bucket.
+func (s *S3Server) Header(p string) http.Header {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if h, ok := s.headers[strings.TrimPrefix(p, "/")]; ok {
+		return h.Clone()
+	}
+	return http.Header{}
+}
+
+// S3Client returns a new AWS S3 client for the server, which uses the credentials and region of the server.
+func (s *S3Server) S3Client() *s3.S3 {
+	return s3.New(session.Must(session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials(s.AccessKeyID, s.SecretAccessKey, ""),
+		Endpoint:         aws.String(s.Endpoint()),
+		Region:           aws.String(s.Region),
+		S3ForcePathStyle: aws.Bool(true),
+	})))
+}
+
+// Close shuts down the server and removes the temporary directory.
+func (s *S3Server) Close() {
+	s.Server.Close()
+	_ = os.RemoveAll(s.Dir)
+}
+
+type s3Error struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource"`
+}
+
+type s3Object struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type s3Prefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type s3ListBucketResult struct {
+	XMLName               xml.Name   `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name                  string     `xml:"Name"`
+	Prefix                string     `xml:"Prefix"`
+	Delimiter             string     `xml:"Delimiter,omitempty"`
+	MaxKeys               int        `xml:"MaxKeys"`
+	IsTruncated           bool       `xml:"IsTruncated"`
+	Marker                *string    `xml:"Marker"`
+	NextMarker            string     `xml:"NextMarker,omitempty"`
+	KeyCount              *int       `xml:"KeyCount"`
+	StartAfter            string     `xml:"StartAfter,omitempty"`
+	ContinuationToken     string     `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string     `xml:"NextContinuationToken,omitempty"`
+	Contents              []s3Object `xml:"Contents"`
+	CommonPrefixes        []s3Prefix `xml:"CommonPrefixes"`
+}
+
+type s3Bucket struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+type s3ListAllMyBucketsResult struct {
+	XMLName xml.Name   `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+	Buckets []s3Bucket `xml:"Buckets>Bucket"`
+}
+
+type s3InitiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ InitiateMultipartUploadResult"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+type s3CompleteMultipartUpload struct {
+	Parts []struct {
+		PartNumber int `xml:"PartNumber"`
+	} `xml:"Part"`
+}
+
+type s3CompleteMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CompleteMultipartUploadResult"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+type s3CopyObjectResult struct {
+	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyObjectResult"`
+	LastModified string   `xml:"LastModified"`
+	ETag         string   `xml:"ETag"`
+}
+
+type s3CopyPartResult struct {
+	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyPartResult"`
+	LastModified string   `xml:"LastModified"`
+	ETag         string   `xml:"ETag"`
+}
+
+type s3Delete struct {
+	Objects []struct {
+		Key string `xml:"Key"`
+	} `xml:"Object"`
+	Quiet bool `xml:"Quiet"`
+}
+
+type s3DeleteResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteResult"`
+	Deleted []struct {
+		Key string `xml:"Key"`
+	} `xml:"Deleted"`
+}
+
+// isS3Header returns true if the header is kept with an object.
+func isS3Header(name string) bool {
+	switch name {
+	case "Cache-Control", "Content-Disposition", "Content-Encoding", "Content-Language", "Content-Type", "Expires":
+		return true
+	case "X-Amz-Storage-Class", "X-Amz-Server-Side-Encryption", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id":
+		return true
+	}
+	return strings.HasPrefix(name, "X-Amz-Meta-")
+}
+
+// s3Header returns the headers in the request that are kept with an object.
+func s3Header(r *http.Request) http.Header {
+	h := http.Header{}
+	for name, values := range r.Header {
+		if isS3Header(name) {
+			h[name] = append([]string{}, values...)
+		}
+	}
+	return h
+}
+
+// s3ETag returns the quoted MD5 checksum of the data.
+func s3ETag(data []byte) string {
+	sum := md5.Sum(data)
+	return "\"" + hex.EncodeToString(sum[:]) + "\""
+}
+
+// s3Time formats the time in the format used by S3 in XML.
+func s3Time(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func (s *S3Server) writeError(w http.ResponseWriter, r *http.Request, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(s3Error{Code: code, Message: message, Resource: r.URL.Path})
+}
+
+func (s *S3Server) writeXML(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+// accessKeyID returns the access key ID used to sign the request.
+func (s *S3Server) accessKeyID(r *http.Request) string {
+	credential := r.URL.Query().Get("X-Amz-Credential")
+	if authorization := r.Header.Get("Authorization"); len(authorization) > 0 {
+		if i := strings.Index(authorization, "Credential="); i != -1 {
+			credential = authorization[i+len("Credential="):]
+		}
+	}
+	if i := strings.Index(credential, "/"); i != -1 {
+		return credential[:i]
+	}
+	return credential
+}
+
+func (s *S3Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.accessKeyID(r) != s.AccessKeyID {
+		s.writeError(w, r, http.StatusForbidden, "InvalidAccessKeyId", "The AWS access key ID you provided does not exist in our records.")
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/")
+	if len(p) == 0 {
+		if r.Method != http.MethodGet {
+			s.writeError(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+			return
+		}
+		s.listBuckets(w, r)
+		return
+	}
+
+	bucket, key := p, ""
+	if i := strings.Index(p, "/"); i != -1 {
+		bucket, key = p[:i], p[i+1:]
+	}
+	if strings.HasPrefix(bucket, ".") || strings.Contains("/"+key+"/", "/../") || strings.Contains("/"+key+"/", "/./") {
+		s.writeError(w, r, http.StatusBadRequest, "InvalidArgument", "Invalid bucket or key.")
+		return
+	}
+
+	if len(key) == 0 {
+		switch r.Method {
+		case http.MethodPut:
+			if err := s.CreateBucket(bucket); err != nil {
+				s.writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		case http.MethodHead, http.MethodGet, http.MethodPost:
+		default:
+			s.writeError(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+			return
+		}
+	}
+
+	if fi, err := os.Stat(s.Path(bucket)); err != nil || !fi.IsDir() {
+		s.writeError(w, r, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+		return
+	}
+
+	query := r.URL.Query()
+
+	if len(key) == 0 {
+		switch {
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && hasQuery(query, "delete"):
+			s.deleteObjects(w, r, bucket)
+		case r.Method == http.MethodGet:
+			s.listObjects(w, r, bucket)
+		default:
+			s.writeError(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+		}
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && hasQuery(query, "uploads"):
+		s.createMultipartUpload(w, r, bucket, key)
+	case r.Method == http.MethodPost && hasQuery(query, "uploadId"):
+		s.completeMultipartUpload(w, r, bucket, key, query.Get("uploadId"))
+	case r.Method == http.MethodPut && hasQuery(query, "uploadId") && len(r.Header.Get("X-Amz-Copy-Source")) > 0:
+		s.uploadPartCopy(w, r, query.Get("uploadId"), query.Get("partNumber"))
+	case r.Method == http.MethodPut && hasQuery(query, "uploadId"):
+		s.uploadPart(w, r, query.Get("uploadId"), query.Get("partNumber"))
+	case r.Method == http.MethodDelete && hasQuery(query, "uploadId"):
+		s.abortMultipartUpload(w, r, query.Get("uploadId"))
+	case r.Method == http.MethodPut && len(r.Header.Get("X-Amz-Copy-Source")) > 0:
+		s.copyObject(w, r, bucket, key)
+	case r.Method == http.MethodPut:
+		s.putObject(w, r, bucket, key)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		s.getObject(w, r, bucket, key)
+	case r.Method == http.MethodDelete:
+		s.deleteObject(bucket, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.writeError(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+}
+
+func hasQuery(query url.Values, name string) bool {
+	_, ok := query[name]
+	return ok
+}
+
+func (s *S3Server) listBuckets(w http.ResponseWriter, r *http.Request) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	result := s3ListAllMyBucketsResult{Buckets: []s3Bucket{}}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		fi, errInfo := entry.Info()
+		if errInfo != nil {
+			continue
+		}
+		result.Buckets = append(result.Buckets, s3Bucket{Name: entry.Name(), CreationDate: s3Time(fi.ModTime())})
+	}
+	s.writeXML(w, result)
+}
+
+// writeObject writes the data to the object and keeps the headers.
+func (s *S3Server) writeObject(bucket string, key string, data []byte, header http.Header) error {
+	p := s.Path(bucket + "/" + key)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(p, data, 0644); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.headers[bucket+"/"+key] = header
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *S3Server) putObject(w http.ResponseWriter, r *http.Request, bucket string, key string) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	if errWrite := s.writeObject(bucket, key, data, s3Header(r)); errWrite != nil {
+		s.writeError(w, r, http.StatusInternalServerError, "InternalError", errWrite.Error())
+		return
+	}
+	w.Header().Set("ETag", s3ETag(data))
+	w.WriteHeader(http.StatusOK)
+}
+
+// copySource returns the bucket/key of the object in the X-Amz-Copy-Source header of the request and the data of the object.
+// If the copy source is invalid or the object does not exist, then writes an error and returns false.
+func (s *S3Server) copySource(w http.ResponseWriter, r *http.Request) (string, []byte, bool) {
+	source, err := url.PathUnescape(strings.TrimPrefix(r.Header.Get("X-Amz-Copy-Source"), "/"))
+	if err != nil || !strings.Contains(source, "/") || strings.Contains("/"+source+"/", "/../") {
+		s.writeError(w, r, http.StatusBadRequest, "InvalidArgument", "Invalid copy source.")
+		return "", nil, false
+	}
+	if i := strings.Index(source, "?"); i != -1 {
+		source = source[:i]
+	}
+	data, err := ioutil.ReadFile(s.Path(source))
+	if err != nil {
+		s.writeError(w, r, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return "", nil, false
+	}
+	return source, data, true
+}
+
+func (s *S3Server) copyObject(w http.ResponseWriter, r *http.Request, bucket string, key string) {
+	source, data, ok := s.copySource(w, r)
+	if !ok {
+		return
+	}
+	header := s3Header(r)
+	if r.Header.Get("X-Amz-Metadata-Directive") != "REPLACE" {
+		header = s.Header(source)
+		// as on AWS S3, the storage class and server-side encryption of the copy are set by the request,
+		// and are not copied from the source object.
+		for _, name := range []string{"X-Amz-Storage-Class", "X-Amz-Server-Side-Encryption", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"} {
+			header.Del(name)
+			if values := r.Header.Values(name); len(values) > 0 {
+				header[name] = values
+			}
+		}
+	}
+	if err := s.writeObject(bucket, key, data, header); err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	s.writeXML(w, s3CopyObjectResult{LastModified: s3Time(time.Now()), ETag: s3ETag(data)})
+}
+
+func (s *S3Server) getObject(w http.ResponseWriter, r *http.Request, bucket string, key string) {
+	f, err := os.Open(s.Path(bucket + "/" + key))
+	if err != nil {
+		s.writeError(w, r, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil || !fi.Mode().IsRegular() {
+		s.writeError(w, r, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	for name, values := range s.Header(bucket + "/" + key) {
+		w.Header()[name] = values
+	}
+	if len(w.Header().Get("Content-Type")) == 0 {
+		w.Header().Set("Content-Type", "binary/octet-stream")
+	}
+	w.Header().Set("ETag", s3ETag(data))
+	w.Header().Set("Accept-Ranges", "bytes")
+	http.ServeContent(w, r, "", fi.ModTime(), bytes.NewReader(data))
+}
+
+// deleteObject deletes the object, which does not need to exist.
+func (s *S3Server) deleteObject(bucket string, key string) {
+	_ = os.Remove(s.Path(bucket + "/" + key))
+	s.mutex.Lock()
+	delete(s.headers, bucket+"/"+key)
+	s.mutex.Unlock()
+}
+
+func (s *S3Server) deleteObjects(w http.ResponseWriter, r *http.Request, bucket string) {
+	input := s3Delete{}
+	if err := xml.NewDecoder(r.Body).Decode(&input); err != nil {
+		s.writeError(w, r, http.StatusBadRequest, "MalformedXML", err.Error())
+		return
+	}
+	result := s3DeleteResult{}
+	for _, object := range input.Objects {
+		s.deleteObject(bucket, object.Key)
+		if !input.Quiet {
+			result.Deleted = append(result.Deleted, struct {
+				Key string `xml:"Key"`
+			}{Key: object.Key})
+		}
+	}
+	s.writeXML(w, result)
+}
+
+// keys returns the sorted keys of the objects in the bucket that start with the prefix.
+func (s *S3Server) keys(bucket string, prefix string) ([]string, error) {
+	root := s.Path(bucket)
+	keys := make([]string, 0)
+	err := filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *S3Server) listObjects(w http.ResponseWriter, r *http.Request, bucket string) {
+	query := r.URL.Query()
+	v2 := query.Get("list-type") == "2"
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+	maxKeys := 1000
+	if str := query.Get("max-keys"); len(str) > 0 {
+		n, err := strconv.Atoi(str)
+		if err != nil || n < 0 {
+			s.writeError(w, r, http.StatusBadRequest, "InvalidArgument", "Invalid max-keys.")
+			return
+		}
+		maxKeys = n
+	}
+
+	// the key after which the listing starts
+	after := query.Get("marker")
+	if v2 {
+		after = query.Get("start-after")
+		if token := query.Get("continuation-token"); len(token) > 0 {
+			after = token
+		}
+	}
+
+	keys, err := s.keys(bucket, prefix)
+	if err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+
+	result := s3ListBucketResult{
+		Name:      bucket,
+		Prefix:    prefix,
+		Delimiter: delimiter,
+		MaxKeys:   maxKeys,
+	}
+
+	last := ""
+	count := 0
+	seen := map[string]struct{}{}
+	for _, key := range keys {
+		if key <= after {
+			continue
+		}
+		commonPrefix := ""
+		if len(delimiter) > 0 {
+			if i := strings.Index(key[len(prefix):], delimiter); i != -1 {
+				commonPrefix = key[:len(prefix)+i+len(delimiter)]
+			}
+		}
+		if len(commonPrefix) > 0 {
+			if _, ok := seen[commonPrefix]; ok {
+				continue
+			}
+			if commonPrefix <= after {
+				continue
+			}
+		}
+		if count == maxKeys {
+			result.IsTruncated = true
+			break
+		}
+		count++
+		if len(commonPrefix) > 0 {
+			seen[commonPrefix] = struct{}{}
+			result.CommonPrefixes = append(result.CommonPrefixes, s3Prefix{Prefix: commonPrefix})
+			last = commonPrefix
+			continue
+		}
+		fi, errStat := os.Stat(s.Path(bucket + "/" + key))
+		if errStat != nil {
+			continue
+		}
+		data, errRead := ioutil.ReadFile(s.Path(bucket + "/" + key))
+		if errRead != nil {
+			continue
+		}
+		result.Contents = append(result.Contents, s3Object{
+			Key:          key,
+			LastModified: s3Time(fi.ModTime()),
+			ETag:         s3ETag(data),
+			Size:         fi.Size(),
+			StorageClass: "STANDARD",
+		})
+		last = key
+	}
+
+	if v2 {
+		result.KeyCount = &count
+		result.StartAfter = query.Get("start-after")
+		result.ContinuationToken = query.Get("continuation-token")
+		if result.IsTruncated {
+			result.NextContinuationToken = last
+		}
+	} else {
+		marker := query.Get("marker")
+		result.Marker = &marker
+		if result.IsTruncated && len(delimiter) > 0 {
+			result.NextMarker = last
+		}
+	}
+
+	s.writeXML(w, result)
+}
+
+func (s *S3Server) createMultipartUpload(w http.ResponseWriter, r *http.Request, bucket string, key string) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	uploadID := hex.EncodeToString(b)
+	s.mutex.Lock()
+	s.uploads[uploadID] = &s3Upload{bucket: bucket, key: key, header: s3Header(r), parts: map[int][]byte{}}
+	s.mutex.Unlock()
+	s.writeXML(w, s3InitiateMultipartUploadResult{Bucket: bucket, Key: key, UploadID: uploadID})
+}
+
+func (s *S3Server) upload(uploadID string) (*s3Upload, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	upload, ok := s.uploads[uploadID]
+	return upload, ok
+}
+
+func (s *S3Server) uploadPart(w http.ResponseWriter, r *http.Request, uploadID string, partNumber string) {
+	upload, ok := s.upload(uploadID)
+	if !ok {
+		s.writeError(w, r, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+	n, err := strconv.Atoi(partNumber)
+	if err != nil || n < 1 || n > 10000 {
+		s.writeError(w, r, http.StatusBadRequest, "InvalidArgument", "Part number must be an integer between 1 and 10000, inclusive.")
+		return
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	s.mutex.Lock()
+	upload.parts[n] = data
+	s.mutex.Unlock()
+	w.Header().Set("ETag", s3ETag(data))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *S3Server) uploadPartCopy(w http.ResponseWriter, r *http.Request, uploadID string, partNumber string) {
+	upload, ok := s.upload(uploadID)
+	if !ok {
+		s.writeError(w, r, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+	n, err := strconv.Atoi(partNumber)
+	if err != nil || n < 1 || n > 10000 {
+		s.writeError(w, r, http.StatusBadRequest, "InvalidArgument", "Part number must be an integer between 1 and 10000, inclusive.")
+		return
+	}
+	_, data, ok := s.copySource(w, r)
+	if !ok {
+		return
+	}
+	if copySourceRange := r.Header.Get("X-Amz-Copy-Source-Range"); len(copySourceRange) > 0 {
+		var start, end int
+		if _, errScan := fmt.Sscanf(copySourceRange, "bytes=%d-%d", &start, &end); errScan != nil || start < 0 || end < start || end >= len(data) {
+			s.writeError(w, r, http.StatusBadRequest, "InvalidArgument", "The x-amz-copy-source-range value must be of the form bytes=first-last where first and last are the zero-based offsets of the first and last bytes to copy.")
+			return
+		}
+		data = data[start : end+1]
+	}
+	s.mutex.Lock()
+	upload.parts[n] = data
+	s.mutex.Unlock()
+	s.writeXML(w, s3CopyPartResult{LastModified: s3Time(time.Now()), ETag: s3ETag(data)})
+}
+
+func (s *S3Server) completeMultipartUpload(w http.ResponseWriter, r *http.Request, bucket string, key string, uploadID string) {
+	upload, ok := s.upload(uploadID)
+	if !ok || upload.bucket != bucket || upload.key != key {
+		s.writeError(w, r, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+	input := s3CompleteMultipartUpload{}
+	if err := xml.NewDecoder(r.Body).Decode(&input); err != nil {
+		s.writeError(w, r, http.StatusBadRequest, "MalformedXML", err.Error())
+		return
+	}
+	data := make([]byte, 0)
+	sums := make([]byte, 0)
+	s.mutex.Lock()
+	for i, part := range input.Parts {
+		b, found := upload.parts[part.PartNumber]
+		if !found || (i > 0 && part.PartNumber <= input.Parts[i-1].PartNumber) {
+			s.mutex.Unlock()
+			s.writeError(w, r, http.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found.")
+			return
+		}
+		data = append(data, b...)
+		sum := md5.Sum(b)
+		sums = append(sums, sum[:]...)
+	}
+	delete(s.uploads, uploadID)
+	s.mutex.Unlock()
+	if err := s.writeObject(bucket, key, data, upload.header); err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	sum := md5.Sum(sums)
+	s.writeXML(w, s3CompleteMultipartUploadResult{
+		Location: s.Endpoint() + "/" + bucket + "/" + key,
+		Bucket:   bucket,
+		Key:      key,
+		ETag:     fmt.Sprintf("\"%s-%d\"", hex.EncodeToString(sum[:]), len(input.Parts)),
+	})
+}
+
+func (s *S3Server) abortMultipartUpload(w http.ResponseWriter, r *http.Request, uploadID string) {
+	s.mutex.Lock()
+	_, ok := s.uploads[uploadID]
+	delete(s.uploads, uploadID)
+	s.mutex.Unlock()
+	if !ok {
+		s.writeError(w, r, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
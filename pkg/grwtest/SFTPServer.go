@@ -0,0 +1,70 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grwtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+// SFTPServer is a SSH server with the SFTP subsystem for the files in a temporary directory.
+// The server also executes commands, so it can be used with the ssh and ssh+cmd schemes.
+type SFTPServer struct {
+	Server   *ssh2test.Server // the underlying SSH server, which can be configured, e.g., to allow public keys
+	Dir      string           // the temporary directory
+	User     string           // name of the user allowed to log in
+	Password string           // password of the user
+}
+
+// NewSFTPServer starts and returns a new SFTPServer with a new temporary directory.
+// The caller should call Close when finished, to shut it down and remove the directory.
+func NewSFTPServer() *SFTPServer {
+	dir, err := os.MkdirTemp("", "grwtest-sftp-")
+	if err != nil {
+		panic(fmt.Errorf("grwtest: error creating temporary directory: %w", err))
+	}
+	server := ssh2test.NewServer()
+	return &SFTPServer{
+		Server:   server,
+		Dir:      dir,
+		User:     server.User,
+		Password: server.Password,
+	}
+}
+
+// Addr returns the address of the server as host:port.
+func (s *SFTPServer) Addr() string {
+	return s.Server.Addr()
+}
+
+// URI returns the sftp uri for the path in the temporary directory, e.g., sftp://grw@127.0.0.1:2222//tmp/grwtest-sftp-123/a/b.txt.
+// The password is not included, so it should be set with the password input of grw.
+func (s *SFTPServer) URI(p string) string {
+	return s.Server.URI("sftp", s.Path(p))
+}
+
+// Path returns the local path of the file at the path in the temporary directory.
+func (s *SFTPServer) Path(p string) string {
+	return filepath.Join(s.Dir, filepath.FromSlash(p))
+}
+
+// HostKeyCallback returns a callback that only accepts the host key of the server.
+func (s *SFTPServer) HostKeyCallback() ssh.HostKeyCallback {
+	return ssh.FixedHostKey(s.Server.HostKey.PublicKey())
+}
+
+// Close shuts down the server and removes the temporary directory.
+func (s *SFTPServer) Close() {
+	s.Server.Close()
+	_ = os.RemoveAll(s.Dir)
+}
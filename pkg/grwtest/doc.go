@@ -0,0 +1,12 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+// Package grwtest provides local SFTP, FTP, HTTP, and S3-compatible servers for testing code that uses grw.
+// Each server listens on the loopback interface and serves the files in a new temporary directory,
+// which is removed when the server is closed.
+// The servers return ready-to-use uris and credentials, so tests can read and write resources without a network connection.
+package grwtest
@@ -0,0 +1,180 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package grwtest_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/grw"
+	"github.com/spatialcurrent/go-reader-writer/pkg/grwtest"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ftp"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+func writeFile(t *testing.T, p string, data string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+	require.NoError(t, ioutil.WriteFile(p, []byte(data), 0644))
+}
+
+func TestSFTPServer(t *testing.T) {
+	server := grwtest.NewSFTPServer()
+	defer server.Close()
+
+	writeFile(t, server.Path("a/b.txt"), "hello world")
+
+	output, err := grw.ReadFromResource(&grw.ReadFromResourceInput{
+		URI:             server.URI("a/b.txt"),
+		Password:        server.Password,
+		HostKeyCallback: server.HostKeyCallback(),
+	})
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(output.Reader)
+	require.NoError(t, err)
+	require.NoError(t, output.Reader.Close())
+	assert.Equal(t, "hello world", string(b))
+
+	w, err := grw.WriteToResource(&grw.WriteToResourceInput{
+		URI:             server.URI("a/c.txt"),
+		Alg:             "none",
+		Password:        server.Password,
+		HostKeyCallback: server.HostKeyCallback(),
+	})
+	require.NoError(t, err)
+	_, err = w.Writer.Write([]byte("foo bar"))
+	require.NoError(t, err)
+	require.NoError(t, w.Writer.Close())
+	b, err = ioutil.ReadFile(server.Path("a/c.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo bar", string(b))
+}
+
+func TestFTPServer(t *testing.T) {
+	server := grwtest.NewFTPServer()
+	defer server.Close()
+
+	writeFile(t, server.Path("a/b.txt"), "hello world")
+
+	r, err := ftp.Fetch(server.URI("a/b.txt"))
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "hello world", string(b))
+
+	r, err = ftp.FetchFrom(server.URI("a/b.txt"), 6)
+	require.NoError(t, err)
+	b, err = ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "world", string(b))
+
+	_, err = ftp.Fetch("ftp://" + server.User + ":wrong@" + server.Addr() + "/a/b.txt")
+	require.Error(t, err)
+}
+
+func TestHTTPServer(t *testing.T) {
+	server := grwtest.NewHTTPServer()
+	defer server.Close()
+
+	writeFile(t, server.Path("a/b.txt"), "hello world")
+
+	r, err := http.FetchFrom(server.URI("a/b.txt"), 6)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "world", string(b))
+}
+
+func TestS3Server(t *testing.T) {
+	server := grwtest.NewS3Server()
+	defer server.Close()
+
+	require.NoError(t, server.CreateBucket("bucket"))
+
+	client := server.S3Client()
+
+	_, err := client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String("bucket"),
+		Key:         aws.String("a/b.txt"),
+		Body:        bytes.NewReader([]byte("hello world")),
+		ContentType: aws.String("text/plain"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", server.Header("bucket/a/b.txt").Get("Content-Type"))
+
+	writeFile(t, server.Path("bucket/a/c/d.txt"), "foo")
+	writeFile(t, server.Path("bucket/e.txt"), "bar")
+
+	output, err := grw.ReadFromResource(&grw.ReadFromResourceInput{
+		URI:      server.URI("bucket/a/b.txt"),
+		Offset:   6,
+		S3Client: client,
+	})
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(output.Reader)
+	require.NoError(t, err)
+	require.NoError(t, output.Reader.Close())
+	assert.Equal(t, "world", string(b))
+
+	list, err := client.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket:    aws.String("bucket"),
+		Prefix:    aws.String("a/"),
+		Delimiter: aws.String("/"),
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Contents, 1)
+	assert.Equal(t, "a/b.txt", aws.StringValue(list.Contents[0].Key))
+	assert.Equal(t, int64(11), aws.Int64Value(list.Contents[0].Size))
+	require.Len(t, list.CommonPrefixes, 1)
+	assert.Equal(t, "a/c/", aws.StringValue(list.CommonPrefixes[0].Prefix))
+
+	keys := make([]string, 0)
+	err = client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket:  aws.String("bucket"),
+		MaxKeys: aws.Int64(1),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a/b.txt", "a/c/d.txt", "e.txt"}, keys)
+
+	// 6 MiB is uploaded in 2 parts
+	data := bytes.Repeat([]byte("0123456789abcdef"), 6*1024*1024/16)
+	err = grw.UploadS3Object(&grw.UploadS3ObjectInput{
+		Bucket: "bucket",
+		Key:    "large.bin",
+		Object: bytes.NewReader(data),
+		Client: client,
+	})
+	require.NoError(t, err)
+	b, err = ioutil.ReadFile(server.Path("bucket/large.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, data, b)
+
+	_, err = client.DeleteObject(&s3.DeleteObjectInput{Bucket: aws.String("bucket"), Key: aws.String("e.txt")})
+	require.NoError(t, err)
+	_, err = client.HeadObject(&s3.HeadObjectInput{Bucket: aws.String("bucket"), Key: aws.String("e.txt")})
+	require.Error(t, err)
+
+	_, err = client.GetObject(&s3.GetObjectInput{Bucket: aws.String("missing"), Key: aws.String("e.txt")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NoSuchBucket")
+}
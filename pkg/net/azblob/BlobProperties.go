@@ -0,0 +1,61 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlobProperties are the properties of a blob in Azure Blob Storage.
+type BlobProperties struct {
+	Container       string
+	Name            string
+	Size            int64
+	ContentType     string
+	ContentEncoding string
+	CacheControl    string
+	ContentMD5      string
+	ETag            string
+	LastModified    time.Time
+	BlobType        string
+	AccessTier      string
+	Metadata        map[string]string
+}
+
+// newBlobPropertiesFromHeader returns the properties of a blob from the header of a Get Blob Properties response.
+func newBlobPropertiesFromHeader(container string, name string, header http.Header) *BlobProperties {
+	p := &BlobProperties{
+		Container:       container,
+		Name:            name,
+		ContentType:     header.Get("Content-Type"),
+		ContentEncoding: header.Get("Content-Encoding"),
+		CacheControl:    header.Get("Cache-Control"),
+		ContentMD5:      header.Get("Content-MD5"),
+		ETag:            header.Get("ETag"),
+		BlobType:        header.Get("x-ms-blob-type"),
+		AccessTier:      header.Get("x-ms-access-tier"),
+	}
+	if n, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil {
+		p.Size = n
+	}
+	if t, err := http.ParseTime(header.Get("Last-Modified")); err == nil {
+		p.LastModified = t
+	}
+	for k, v := range header {
+		if key := strings.ToLower(k); strings.HasPrefix(key, "x-ms-meta-") && len(v) > 0 {
+			if p.Metadata == nil {
+				p.Metadata = map[string]string{}
+			}
+			p.Metadata[strings.TrimPrefix(key, "x-ms-meta-")] = v[0]
+		}
+	}
+	return p
+}
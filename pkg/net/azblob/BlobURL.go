@@ -0,0 +1,44 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"net/url"
+	"strings"
+)
+
+// BlobURL is a parsed url of a blob on Azure Blob Storage.
+type BlobURL struct {
+	Endpoint  string // endpoint of the blob service, such as https://myaccount.blob.core.windows.net
+	Container string // name of the container
+	Blob      string // name of the blob
+	SASToken  string // shared access signature in the query of the url, if any
+}
+
+// ParseBlobURL parses a url of a blob on Azure Blob Storage, such as "https://myaccount.blob.core.windows.net/container/path/to/blob".
+// Returns the parsed url and true, or false if the url is not a https url of a blob on Azure Blob Storage.
+func ParseBlobURL(uri string) (*BlobURL, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "https" || len(u.Host) == 0 {
+		return nil, false
+	}
+	host := strings.ToLower(u.Hostname())
+	if !strings.HasSuffix(host, ".blob."+EndpointSuffix) || strings.Count(host, ".") != strings.Count(EndpointSuffix, ".")+2 {
+		return nil, false
+	}
+	container, blob, err := SplitPath(strings.TrimPrefix(u.Path, "/"))
+	if err != nil || len(container) == 0 || len(blob) == 0 {
+		return nil, false
+	}
+	return &BlobURL{
+		Endpoint:  "https://" + u.Host,
+		Container: container,
+		Blob:      blob,
+		SASToken:  u.RawQuery,
+	}, true
+}
@@ -0,0 +1,43 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"net/http"
+	"time"
+)
+
+// Client is a client for the Azure Blob Storage REST API.
+type Client struct {
+	Endpoint   string               // endpoint of the blob service, without a trailing slash
+	Credential *SharedKeyCredential // shared key credential, if any
+	SASToken   string               // shared access signature, without a leading "?", if any
+	HTTPClient *http.Client         // HTTP client used to send requests
+}
+
+// do sets the version and date headers, authorizes the request, and sends the request.
+// If the client has a shared access signature, then it is added to the query of the request.
+func (c *Client) do(request *http.Request) (*http.Response, error) {
+	if len(c.SASToken) > 0 {
+		if len(request.URL.RawQuery) > 0 {
+			request.URL.RawQuery += "&" + c.SASToken
+		} else {
+			request.URL.RawQuery = c.SASToken
+		}
+	}
+	request.Header.Set("x-ms-version", APIVersion)
+	request.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	if c.Credential != nil {
+		c.Credential.Sign(request)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(request)
+}
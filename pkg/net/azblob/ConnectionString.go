@@ -0,0 +1,75 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConnectionString is a parsed connection string of a storage account.
+type ConnectionString struct {
+	Account  string // name of the storage account
+	Key      string // shared key of the storage account
+	SASToken string // shared access signature
+	Endpoint string // endpoint of the blob service
+}
+
+// ParseConnectionString parses a connection string of a storage account,
+// such as "DefaultEndpointsProtocol=https;AccountName=myaccount;AccountKey=mykey;EndpointSuffix=core.windows.net".
+// If UseDevelopmentStorage is true, then the account, key, and endpoint of the Azurite emulator are used.
+// ParseConnectionString returns an error if a setting is invalid or the endpoint cannot be determined.
+func ParseConnectionString(str string) (*ConnectionString, error) {
+	settings := map[string]string{}
+	for _, part := range strings.Split(str, ";") {
+		if len(strings.TrimSpace(part)) == 0 {
+			continue
+		}
+		i := strings.Index(part, "=")
+		if i == -1 {
+			return nil, fmt.Errorf("error parsing connection string: invalid setting %q", part)
+		}
+		settings[strings.ToLower(strings.TrimSpace(part[0:i]))] = strings.TrimSpace(part[i+1:])
+	}
+
+	if strings.EqualFold(settings["usedevelopmentstorage"], "true") {
+		endpoint := DevelopmentEndpoint
+		if proxy := settings["developmentstorageproxyuri"]; len(proxy) > 0 {
+			endpoint = strings.TrimSuffix(proxy, "/") + "/" + DevelopmentAccount
+		}
+		return &ConnectionString{Account: DevelopmentAccount, Key: DevelopmentKey, Endpoint: endpoint}, nil
+	}
+
+	c := &ConnectionString{
+		Account:  settings["accountname"],
+		Key:      settings["accountkey"],
+		SASToken: strings.TrimPrefix(settings["sharedaccesssignature"], "?"),
+		Endpoint: strings.TrimSuffix(settings["blobendpoint"], "/"),
+	}
+
+	if len(c.Endpoint) == 0 {
+		if len(c.Account) == 0 {
+			return nil, fmt.Errorf("error parsing connection string: missing AccountName or BlobEndpoint")
+		}
+		protocol := settings["defaultendpointsprotocol"]
+		if len(protocol) == 0 {
+			protocol = "https"
+		}
+		suffix := settings["endpointsuffix"]
+		if len(suffix) == 0 {
+			suffix = EndpointSuffix
+		}
+		c.Endpoint = fmt.Sprintf("%s://%s.blob.%s", protocol, c.Account, suffix)
+	}
+
+	if len(c.Key) > 0 && len(c.Account) == 0 {
+		return nil, fmt.Errorf("error parsing connection string: AccountKey requires AccountName")
+	}
+
+	return c, nil
+}
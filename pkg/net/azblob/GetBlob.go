@@ -0,0 +1,78 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	pkghttp "github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// GetBlobInput contains the input parameters for GetBlob.
+type GetBlobInput struct {
+	Client    *Client         // client for Azure Blob Storage
+	Container string          // name of the container
+	Blob      string          // name of the blob
+	Offset    int64           // byte offset to start reading from
+	ETag      string          // if set, then the blob must match the ETag
+	Context   context.Context // context for the request, defaults to context.Background()
+}
+
+// GetBlob returns a reader for the contents of the blob, starting at the given offset.
+// If the offset is equal to the size of the blob, then the reader is empty.
+//
+// If the ETag is set and the blob no longer matches the ETag,
+// then GetBlob returns ErrResourceChanged from the http package.
+func GetBlob(input *GetBlobInput) (io.ReadCloser, error) {
+	uri := blobURL(input.Client.Endpoint, input.Container, input.Blob)
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for blob %q in container %q: %w", input.Blob, input.Container, err)
+	}
+	if input.Offset > 0 {
+		request.Header.Set("x-ms-range", fmt.Sprintf("bytes=%d-", input.Offset))
+	}
+	if len(input.ETag) > 0 {
+		request.Header.Set("If-Match", input.ETag)
+	}
+	response, err := input.Client.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error getting blob %q in container %q: %w", input.Blob, input.Container, err)
+	}
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		if input.Offset > 0 && response.StatusCode == http.StatusOK {
+			// the range was ignored, so discard the bytes before the offset.
+			_, err = io.CopyN(io.Discard, response.Body, input.Offset)
+			if err != nil {
+				_ = response.Body.Close()
+				return nil, fmt.Errorf("error discarding %d bytes from blob %q in container %q: %w", input.Offset, input.Blob, input.Container, err)
+			}
+		}
+		return response.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		_ = response.Body.Close()
+		if input.Offset > 0 {
+			// the offset is at or beyond the end of the blob
+			return http.NoBody, nil
+		}
+	case http.StatusPreconditionFailed:
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("error getting blob %q in container %q: %w", input.Blob, input.Container, pkghttp.ErrResourceChanged)
+	default:
+		_ = response.Body.Close()
+	}
+	return nil, fmt.Errorf("error getting blob %q in container %q: %w", input.Blob, input.Container, errUnexpectedStatus(uri, response.StatusCode))
+}
@@ -0,0 +1,136 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListInput contains the input parameters for List.
+type ListInput struct {
+	Client     *Client // client for Azure Blob Storage
+	Container  string  // name of the container
+	Prefix     string  // only list blobs whose names begin with the prefix
+	Delimiter  string  // group blobs whose names contain the delimiter after the prefix, usually "/"
+	Marker     string  // marker for the page of results to return
+	MaxResults int     // maximum number of blobs and prefixes in the page, if greater than zero
+}
+
+// ListOutput contains the output of List.
+type ListOutput struct {
+	Blobs      []*BlobProperties // blobs in the page
+	Prefixes   []string          // prefixes in the page that group blobs when a delimiter is set
+	NextMarker string            // marker for the next page of results, or blank if this is the last page
+}
+
+type enumerationResults struct {
+	Blobs struct {
+		Blob []struct {
+			Name       string `xml:"Name"`
+			Properties struct {
+				LastModified    string `xml:"Last-Modified"`
+				ETag            string `xml:"Etag"`
+				ContentLength   int64  `xml:"Content-Length"`
+				ContentType     string `xml:"Content-Type"`
+				ContentEncoding string `xml:"Content-Encoding"`
+				CacheControl    string `xml:"Cache-Control"`
+				ContentMD5      string `xml:"Content-MD5"`
+				BlobType        string `xml:"BlobType"`
+				AccessTier      string `xml:"AccessTier"`
+			} `xml:"Properties"`
+			Metadata struct {
+				Items []struct {
+					XMLName xml.Name
+					Value   string `xml:",chardata"`
+				} `xml:",any"`
+			} `xml:"Metadata"`
+		} `xml:"Blob"`
+		BlobPrefix []struct {
+			Name string `xml:"Name"`
+		} `xml:"BlobPrefix"`
+	} `xml:"Blobs"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// List returns a page of the blobs in the container.
+// To list all the blobs, call List with the next marker until the marker is blank.
+func List(ctx context.Context, input *ListInput) (*ListOutput, error) {
+	query := url.Values{}
+	query.Set("restype", "container")
+	query.Set("comp", "list")
+	query.Set("include", "metadata")
+	if len(input.Prefix) > 0 {
+		query.Set("prefix", input.Prefix)
+	}
+	if len(input.Delimiter) > 0 {
+		query.Set("delimiter", input.Delimiter)
+	}
+	if len(input.Marker) > 0 {
+		query.Set("marker", input.Marker)
+	}
+	if input.MaxResults > 0 {
+		query.Set("maxresults", strconv.Itoa(input.MaxResults))
+	}
+	uri := containerURL(input.Client.Endpoint, input.Container) + "?" + query.Encode()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for container %q: %w", input.Container, err)
+	}
+	response, err := input.Client.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing blobs in container %q: %w", input.Container, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error listing blobs in container %q: %w", input.Container, errUnexpectedStatus(uri, response.StatusCode))
+	}
+	results := enumerationResults{}
+	err = xml.NewDecoder(response.Body).Decode(&results)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding blobs in container %q: %w", input.Container, err)
+	}
+	output := &ListOutput{
+		Blobs:      make([]*BlobProperties, 0, len(results.Blobs.Blob)),
+		Prefixes:   make([]string, 0, len(results.Blobs.BlobPrefix)),
+		NextMarker: results.NextMarker,
+	}
+	for _, b := range results.Blobs.Blob {
+		p := &BlobProperties{
+			Container:       input.Container,
+			Name:            b.Name,
+			Size:            b.Properties.ContentLength,
+			ContentType:     b.Properties.ContentType,
+			ContentEncoding: b.Properties.ContentEncoding,
+			CacheControl:    b.Properties.CacheControl,
+			ContentMD5:      b.Properties.ContentMD5,
+			ETag:            b.Properties.ETag,
+			BlobType:        b.Properties.BlobType,
+			AccessTier:      b.Properties.AccessTier,
+		}
+		if t, errParse := http.ParseTime(b.Properties.LastModified); errParse == nil {
+			p.LastModified = t
+		}
+		for _, item := range b.Metadata.Items {
+			if p.Metadata == nil {
+				p.Metadata = map[string]string{}
+			}
+			p.Metadata[strings.ToLower(item.XMLName.Local)] = item.Value
+		}
+		output.Blobs = append(output.Blobs, p)
+	}
+	for _, prefix := range results.Blobs.BlobPrefix {
+		output.Prefixes = append(output.Prefixes, prefix.Name)
+	}
+	return output, nil
+}
@@ -0,0 +1,91 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// NewClientInput contains the input parameters for NewClient.
+type NewClientInput struct {
+	Account          string       // name of the storage account
+	Key              string       // shared key of the storage account
+	SASToken         string       // shared access signature
+	ConnectionString string       // connection string of the storage account
+	Endpoint         string       // endpoint of the blob service, defaults to https://<account>.blob.core.windows.net
+	HTTPClient       *http.Client // HTTP client used to send requests
+}
+
+// NewClient returns a new client for Azure Blob Storage.
+//
+// If the input has no account, key, shared access signature, or connection string,
+// then they are read from the AZURE_STORAGE_CONNECTION_STRING, AZURE_STORAGE_ACCOUNT,
+// AZURE_STORAGE_KEY, and AZURE_STORAGE_SAS_TOKEN environment variables.
+// The settings in the connection string are used unless set directly in the input.
+// If the shared key is set, then requests are signed with the shared key.
+// Otherwise, if the shared access signature is set, then the signature is added to every request.
+// If neither is set, then requests are sent without authorization, which only allows access to public containers.
+func NewClient(input *NewClientInput) (*Client, error) {
+	account, key, sasToken, connectionString := input.Account, input.Key, input.SASToken, input.ConnectionString
+	if len(account) == 0 && len(key) == 0 && len(sasToken) == 0 && len(connectionString) == 0 {
+		account = os.Getenv(EnvAccount)
+		key = os.Getenv(EnvKey)
+		sasToken = os.Getenv(EnvSASToken)
+		connectionString = os.Getenv(EnvConnectionString)
+	}
+
+	endpoint := strings.TrimSuffix(input.Endpoint, "/")
+	if len(connectionString) > 0 {
+		c, err := ParseConnectionString(connectionString)
+		if err != nil {
+			return nil, err
+		}
+		if len(account) == 0 {
+			account = c.Account
+		}
+		if len(key) == 0 {
+			key = c.Key
+		}
+		if len(sasToken) == 0 {
+			sasToken = c.SASToken
+		}
+		if len(endpoint) == 0 {
+			endpoint = c.Endpoint
+		}
+	}
+
+	if len(endpoint) == 0 {
+		if len(account) == 0 {
+			return nil, fmt.Errorf("error creating Azure Blob Storage client: missing account or endpoint")
+		}
+		endpoint = fmt.Sprintf("https://%s.blob.%s", account, EndpointSuffix)
+	}
+
+	client := &Client{
+		Endpoint:   endpoint,
+		HTTPClient: input.HTTPClient,
+	}
+
+	if len(key) > 0 {
+		if len(account) == 0 {
+			return nil, fmt.Errorf("error creating Azure Blob Storage client: a shared key requires the account")
+		}
+		credential, err := NewSharedKeyCredential(account, key)
+		if err != nil {
+			return nil, err
+		}
+		client.Credential = credential
+	} else if len(sasToken) > 0 {
+		client.SASToken = strings.TrimPrefix(sasToken, "?")
+	}
+
+	return client, nil
+}
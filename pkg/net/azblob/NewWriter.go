@@ -0,0 +1,53 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
+)
+
+// NewWriterInput contains the input parameters for NewWriter.
+type NewWriterInput struct {
+	Client          *Client           // client for Azure Blob Storage
+	Container       string            // name of the container
+	Blob            string            // name of the blob
+	ContentType     string            // content type of the blob
+	ContentEncoding string            // content encoding of the blob, such as gzip
+	CacheControl    string            // cache control of the blob
+	Metadata        map[string]string // user metadata of the blob
+	BlockSize       int               // size of the blocks of the upload, defaults to DefaultBlockSize
+	Retry           *retry.Policy     // policy for retrying failed requests
+	Context         context.Context   // context for the requests of the upload, defaults to context.Background()
+}
+
+// NewWriter returns a Writer that uploads a block blob in blocks.
+// The blob is created or replaced when the writer is closed.
+// NewWriter returns an error if the block size is invalid.
+func NewWriter(input *NewWriterInput) (*Writer, error) {
+	blockSize := input.BlockSize
+	if blockSize == 0 {
+		blockSize = DefaultBlockSize
+	}
+	if blockSize < 0 {
+		return nil, fmt.Errorf("error creating writer: invalid block size %d", blockSize)
+	}
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &Writer{
+		input:     input,
+		ctx:       ctx,
+		blockSize: blockSize,
+		buffer:    make([]byte, 0, blockSize),
+		blockIDs:  []string{},
+	}, nil
+}
@@ -0,0 +1,115 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SharedKeyCredential signs requests with the shared key of a storage account.
+type SharedKeyCredential struct {
+	account string
+	key     []byte
+}
+
+// NewSharedKeyCredential returns a new SharedKeyCredential for the account and base64-encoded key.
+func NewSharedKeyCredential(account string, key string) (*SharedKeyCredential, error) {
+	b, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding shared key of account %q: %w", account, err)
+	}
+	return &SharedKeyCredential{account: account, key: b}, nil
+}
+
+// Account returns the name of the storage account.
+func (c *SharedKeyCredential) Account() string {
+	return c.account
+}
+
+// Sign sets the Authorization header of the request.
+// The request must already have all the x-ms-* headers that are sent, including x-ms-date.
+func (c *SharedKeyCredential) Sign(request *http.Request) {
+	mac := hmac.New(sha256.New, c.key)
+	_, _ = mac.Write([]byte(c.stringToSign(request)))
+	request.Header.Set("Authorization", "SharedKey "+c.account+":"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+// stringToSign returns the string to sign for the request, as described at
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (c *SharedKeyCredential) stringToSign(request *http.Request) string {
+	header := request.Header
+	contentLength := ""
+	if request.ContentLength > 0 {
+		contentLength = strconv.FormatInt(request.ContentLength, 10)
+	}
+	return strings.Join([]string{
+		request.Method,
+		header.Get("Content-Encoding"),
+		header.Get("Content-Language"),
+		contentLength,
+		header.Get("Content-MD5"),
+		header.Get("Content-Type"),
+		"", // the date is set by x-ms-date
+		header.Get("If-Modified-Since"),
+		header.Get("If-Match"),
+		header.Get("If-None-Match"),
+		header.Get("If-Unmodified-Since"),
+		header.Get("Range"),
+		canonicalizedHeaders(header),
+		c.canonicalizedResource(request.URL),
+	}, "\n")
+}
+
+// canonicalizedHeaders returns the x-ms-* headers sorted by lowercase name.
+func canonicalizedHeaders(header http.Header) string {
+	names := []string{}
+	values := map[string]string{}
+	for k, v := range header {
+		name := strings.ToLower(strings.TrimSpace(k))
+		if strings.HasPrefix(name, "x-ms-") {
+			names = append(names, name)
+			values[name] = strings.Join(v, ",")
+		}
+	}
+	sort.Strings(names)
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, name+":"+values[name])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// canonicalizedResource returns the account, escaped path, and query parameters sorted by name.
+func (c *SharedKeyCredential) canonicalizedResource(u *url.URL) string {
+	str := "/" + c.account
+	if p := u.EscapedPath(); len(p) > 0 {
+		str += p
+	} else {
+		str += "/"
+	}
+	query := u.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := query[name]
+		sort.Strings(values)
+		str += "\n" + strings.ToLower(name) + ":" + strings.Join(values, ",")
+	}
+	return str
+}
@@ -0,0 +1,86 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The signatures were generated with the official Azure SDK for Go.
+func TestSharedKeyCredentialSign(t *testing.T) {
+	credential, err := NewSharedKeyCredential(DevelopmentAccount, DevelopmentKey)
+	require.NoError(t, err)
+	assert.Equal(t, DevelopmentAccount, credential.Account())
+
+	testCases := []struct {
+		method        string
+		uri           string
+		header        map[string]string
+		contentLength int
+		signature     string
+	}{
+		{
+			method:    http.MethodGet,
+			uri:       "http://127.0.0.1:10000/devstoreaccount1/container/a/b%20c.txt",
+			header:    map[string]string{"x-ms-range": "bytes=10-"},
+			signature: "Z4COLMOF67uvFMpu0Vy/pVvLfYxUxL2PTV345QPoHeM=",
+		},
+		{
+			method:        http.MethodPut,
+			uri:           "http://127.0.0.1:10000/devstoreaccount1/container/a.txt?comp=block&blockid=YWJj",
+			contentLength: 5,
+			signature:     "cAJaD78cy71bM5McBkhJAtrwBz/aO7bld/bu2LWKEKM=",
+		},
+		{
+			method:    http.MethodGet,
+			uri:       "https://acct.blob.core.windows.net/container?restype=container&comp=list&prefix=a%2F&delimiter=%2F",
+			signature: "cmGq3I2fLzhZEMGveqWqWbuG/avNfi7bb5ZrUHBNQog=",
+		},
+		{
+			method: http.MethodPut,
+			uri:    "http://127.0.0.1:10000/devstoreaccount1/container/a.txt?comp=blocklist",
+			header: map[string]string{
+				"Content-Type":           "application/xml",
+				"x-ms-blob-content-type": "text/plain",
+				"x-ms-meta-Foo":          "bar",
+			},
+			contentLength: 42,
+			signature:     "/FpNTFdseSL7nrauJlkKkFYfQHUfKPH7+6wl1bDSRmk=",
+		},
+		{
+			method:    http.MethodHead,
+			uri:       "http://127.0.0.1:10000/devstoreaccount1/container/a.txt",
+			header:    map[string]string{"If-Match": "\"0x1\""},
+			signature: "MJHwvIv1DbsNx9FROvltW+H8x4m3oL/Qcm1BbtS18Rs=",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var body *bytes.Reader
+		if testCase.contentLength > 0 {
+			body = bytes.NewReader(make([]byte, testCase.contentLength))
+		}
+		request, errRequest := http.NewRequest(testCase.method, testCase.uri, nil)
+		if body != nil {
+			request, errRequest = http.NewRequest(testCase.method, testCase.uri, body)
+		}
+		require.NoError(t, errRequest)
+		request.Header.Set("x-ms-date", "Mon, 19 Oct 2026 00:00:00 GMT")
+		request.Header.Set("x-ms-version", "2020-04-08")
+		for k, v := range testCase.header {
+			request.Header[k] = []string{v}
+		}
+		credential.Sign(request)
+		assert.Equal(t, "SharedKey devstoreaccount1:"+testCase.signature, request.Header.Get("Authorization"), testCase.uri)
+	}
+}
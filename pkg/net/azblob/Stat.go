@@ -0,0 +1,37 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Stat returns the properties of the blob in the container.
+// Returns a bool indicating whether the blob exists, the blob properties, and an error if any.
+// If the blob does not exist, then the error is supressed and returns false, nil, nil
+func Stat(ctx context.Context, client *Client, container string, blob string) (bool, *BlobProperties, error) {
+	uri := blobURL(client.Endpoint, container, blob)
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, nil)
+	if err != nil {
+		return false, nil, fmt.Errorf("error creating request for blob %q in container %q: %w", blob, container, err)
+	}
+	response, err := client.do(request)
+	if err != nil {
+		return false, nil, fmt.Errorf("error getting properties of blob %q in container %q: %w", blob, container, err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return false, nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return false, nil, fmt.Errorf("error getting properties of blob %q in container %q: %w", blob, container, errUnexpectedStatus(uri, response.StatusCode))
+	}
+	return true, newBlobPropertiesFromHeader(container, blob, response.Header), nil
+}
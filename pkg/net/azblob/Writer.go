@@ -0,0 +1,156 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+var (
+	errWriterClosed = errors.New("writer is closed")
+)
+
+// Writer implements the io.WriteCloser interface to enable writing
+// a block blob to Azure Blob Storage.
+// Each full buffer is uploaded as a block,
+// and the blocks are committed as the contents of the blob when the writer is closed.
+// Uploading a block and committing the blocks are idempotent, so both are retried as set by the policy.
+type Writer struct {
+	input     *NewWriterInput
+	ctx       context.Context
+	blockSize int
+	buffer    []byte
+	blockIDs  []string
+	err       error
+	closed    bool
+}
+
+// Write implements the io.Writer interface.
+// Write uploads a block whenever the buffer is full.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errWriterClosed
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := 0
+	for len(p) > 0 {
+		m := w.blockSize - len(w.buffer)
+		if m > len(p) {
+			m = len(p)
+		}
+		w.buffer = append(w.buffer, p[:m]...)
+		p = p[m:]
+		n += m
+		if len(w.buffer) == w.blockSize {
+			err := w.putBlock()
+			if err != nil {
+				w.err = err
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Close uploads the remaining bytes as a block and commits the blocks.
+func (w *Writer) Close() error {
+	if w.closed {
+		return errWriterClosed
+	}
+	w.closed = true
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.buffer) > 0 {
+		err := w.putBlock()
+		if err != nil {
+			return err
+		}
+	}
+	return w.input.Retry.Do(w.ctx, w.putBlockList)
+}
+
+// putBlock uploads the buffer as a new block.
+func (w *Writer) putBlock() error {
+	if len(w.blockIDs) == MaxBlocks {
+		return fmt.Errorf("error uploading blob %q in container %q: blob has more than %d blocks, so increase the block size", w.input.Blob, w.input.Container, MaxBlocks)
+	}
+	// block ids must have the same length for all the blocks of a blob
+	blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("block-%010d", len(w.blockIDs))))
+	uri := blobURL(w.input.Client.Endpoint, w.input.Container, w.input.Blob) + "?comp=block&blockid=" + url.QueryEscape(blockID)
+	err := w.input.Retry.Do(w.ctx, func() error {
+		request, err := http.NewRequestWithContext(w.ctx, http.MethodPut, uri, bytes.NewReader(w.buffer))
+		if err != nil {
+			return fmt.Errorf("error creating request for blob %q in container %q: %w", w.input.Blob, w.input.Container, err)
+		}
+		response, err := w.input.Client.do(request)
+		if err != nil {
+			return fmt.Errorf("error uploading block of blob %q in container %q: %w", w.input.Blob, w.input.Container, err)
+		}
+		_ = response.Body.Close()
+		if response.StatusCode != http.StatusCreated {
+			return fmt.Errorf("error uploading block of blob %q in container %q: %w", w.input.Blob, w.input.Container, errUnexpectedStatus(uri, response.StatusCode))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.blockIDs = append(w.blockIDs, blockID)
+	w.buffer = w.buffer[:0]
+	return nil
+}
+
+// putBlockList commits the uploaded blocks as the contents of the blob and sets the properties of the blob.
+func (w *Writer) putBlockList() error {
+	blockList := struct {
+		XMLName xml.Name `xml:"BlockList"`
+		Latest  []string `xml:"Latest"`
+	}{Latest: w.blockIDs}
+	body, err := xml.Marshal(blockList)
+	if err != nil {
+		return fmt.Errorf("error encoding block list of blob %q in container %q: %w", w.input.Blob, w.input.Container, err)
+	}
+	uri := blobURL(w.input.Client.Endpoint, w.input.Container, w.input.Blob) + "?comp=blocklist"
+	request, err := http.NewRequestWithContext(w.ctx, http.MethodPut, uri, bytes.NewReader(append([]byte(xml.Header), body...)))
+	if err != nil {
+		return fmt.Errorf("error creating request for blob %q in container %q: %w", w.input.Blob, w.input.Container, err)
+	}
+	request.Header.Set("Content-Type", "application/xml")
+	if len(w.input.ContentType) > 0 {
+		request.Header.Set("x-ms-blob-content-type", w.input.ContentType)
+	}
+	if len(w.input.ContentEncoding) > 0 {
+		request.Header.Set("x-ms-blob-content-encoding", w.input.ContentEncoding)
+	}
+	if len(w.input.CacheControl) > 0 {
+		request.Header.Set("x-ms-blob-cache-control", w.input.CacheControl)
+	}
+	for k, v := range w.input.Metadata {
+		// set the header directly, so the case of the metadata name is preserved
+		request.Header["x-ms-meta-"+k] = []string{v}
+	}
+	response, err := w.input.Client.do(request)
+	if err != nil {
+		return fmt.Errorf("error committing blocks of blob %q in container %q: %w", w.input.Blob, w.input.Container, err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("error committing blocks of blob %q in container %q: %w", w.input.Blob, w.input.Container, errUnexpectedStatus(uri, response.StatusCode))
+	}
+	return nil
+}
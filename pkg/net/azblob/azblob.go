@@ -0,0 +1,69 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+const (
+	APIVersion       = "2020-04-08"       // version of the REST API used for requests
+	DefaultBlockSize = 4 * 1024 * 1024    // size of the blocks of uploads
+	MaxBlocks        = 50000              // maximum number of blocks in a block blob
+	EndpointSuffix   = "core.windows.net" // default endpoint suffix of storage accounts
+)
+
+const (
+	EnvAccount          = "AZURE_STORAGE_ACCOUNT"           // name of the storage account
+	EnvKey              = "AZURE_STORAGE_KEY"               // shared key of the storage account
+	EnvSASToken         = "AZURE_STORAGE_SAS_TOKEN"         // shared access signature
+	EnvConnectionString = "AZURE_STORAGE_CONNECTION_STRING" // connection string of the storage account
+)
+
+// The well-known account, key, and endpoint of the Azurite emulator.
+const (
+	DevelopmentAccount  = "devstoreaccount1"
+	DevelopmentKey      = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+	DevelopmentEndpoint = "http://127.0.0.1:10000/devstoreaccount1"
+)
+
+// SplitPath splits the path of an azblob:// uri into the container and blob name.
+func SplitPath(p string) (string, string, error) {
+	i := strings.Index(p, "/")
+	if i == -1 {
+		return p, "", nil
+	}
+	if i == 0 {
+		return "", "", fmt.Errorf("error parsing path %q: path is missing container", p)
+	}
+	return p[0:i], p[i+1:], nil
+}
+
+// blobURL returns the url of a blob.
+func blobURL(endpoint string, container string, blob string) string {
+	parts := strings.Split(blob, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return fmt.Sprintf("%s/%s/%s", endpoint, url.PathEscape(container), strings.Join(parts, "/"))
+}
+
+// containerURL returns the url of a container.
+func containerURL(endpoint string, container string) string {
+	return fmt.Sprintf("%s/%s", endpoint, url.PathEscape(container))
+}
+
+// errUnexpectedStatus returns an error for a response with an unexpected status code.
+// The errors for 408, 429, and 5xx status codes are temporary, so the requests can be retried.
+func errUnexpectedStatus(uri string, statusCode int) error {
+	return &http.ErrUnexpectedStatus{URI: uri, StatusCode: statusCode}
+}
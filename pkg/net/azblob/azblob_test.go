@@ -0,0 +1,317 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblob_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob/azblobtest"
+	pkghttp "github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
+)
+
+func testData(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + i%26)
+	}
+	return b
+}
+
+func writeBlob(t *testing.T, input *azblob.NewWriterInput, data []byte) {
+	w, err := azblob.NewWriter(input)
+	require.NoError(t, err)
+	// write in odd sizes, so writes cross block boundaries
+	for p := data; len(p) > 0; {
+		n := 100000
+		if n > len(p) {
+			n = len(p)
+		}
+		_, err = w.Write(p[:n])
+		require.NoError(t, err)
+		p = p[n:]
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestWriterAndGetBlob(t *testing.T) {
+	server := azblobtest.NewServer()
+	defer server.Close()
+	server.CreateContainer("container")
+	client := server.AzblobClient()
+
+	data := testData(600 * 1024)
+	writeBlob(t, &azblob.NewWriterInput{
+		Client:       client,
+		Container:    "container",
+		Blob:         "a/b c.txt",
+		ContentType:  "text/plain",
+		CacheControl: "no-cache",
+		Metadata:     map[string]string{"hello": "world"},
+		BlockSize:    256 * 1024,
+	}, data)
+
+	got, ok := server.GetBlob("container", "a/b c.txt")
+	require.True(t, ok)
+	assert.Equal(t, data, got)
+
+	exists, props, err := azblob.Stat(context.Background(), client, "container", "a/b c.txt")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, int64(len(data)), props.Size)
+	assert.Equal(t, "text/plain", props.ContentType)
+	assert.Equal(t, "no-cache", props.CacheControl)
+	assert.Equal(t, "BlockBlob", props.BlobType)
+	assert.Equal(t, map[string]string{"hello": "world"}, props.Metadata)
+	assert.NotEmpty(t, props.ETag)
+	assert.False(t, props.LastModified.IsZero())
+
+	r, err := azblob.GetBlob(&azblob.GetBlobInput{Client: client, Container: "container", Blob: "a/b c.txt"})
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, data, got)
+
+	r, err = azblob.GetBlob(&azblob.GetBlobInput{Client: client, Container: "container", Blob: "a/b c.txt", Offset: 1000, ETag: props.ETag})
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, data[1000:], got)
+
+	r, err = azblob.GetBlob(&azblob.GetBlobInput{Client: client, Container: "container", Blob: "a/b c.txt", Offset: int64(len(data))})
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = azblob.GetBlob(&azblob.GetBlobInput{Client: client, Container: "container", Blob: "a/b c.txt", ETag: "\"changed\""})
+	assert.True(t, errors.Is(err, pkghttp.ErrResourceChanged))
+
+	_, err = azblob.GetBlob(&azblob.GetBlobInput{Client: client, Container: "container", Blob: "missing.txt"})
+	require.Error(t, err)
+	var errUnexpectedStatus *pkghttp.ErrUnexpectedStatus
+	require.True(t, errors.As(err, &errUnexpectedStatus))
+	assert.Equal(t, http.StatusNotFound, errUnexpectedStatus.StatusCode)
+
+	exists, props, err = azblob.Stat(context.Background(), client, "container", "missing.txt")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.Nil(t, props)
+}
+
+func TestWriterEmpty(t *testing.T) {
+	server := azblobtest.NewServer()
+	defer server.Close()
+	server.CreateContainer("container")
+
+	writeBlob(t, &azblob.NewWriterInput{Client: server.AzblobClient(), Container: "container", Blob: "empty.txt"}, []byte{})
+
+	got, ok := server.GetBlob("container", "empty.txt")
+	require.True(t, ok)
+	assert.Empty(t, got)
+}
+
+type failingTransport struct {
+	failures int
+}
+
+func (t *failingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if t.failures > 0 {
+		t.failures--
+		if request.Body != nil {
+			_ = request.Body.Close()
+		}
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       http.NoBody,
+			Header:     http.Header{},
+			Request:    request,
+		}, nil
+	}
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestWriterRetry(t *testing.T) {
+	server := azblobtest.NewServer()
+	defer server.Close()
+	server.CreateContainer("container")
+	client := server.AzblobClient()
+
+	data := testData(1000)
+
+	client.HTTPClient = &http.Client{Transport: &failingTransport{failures: 2}}
+	writeBlob(t, &azblob.NewWriterInput{
+		Client:    client,
+		Container: "container",
+		Blob:      "retry.txt",
+		Retry:     &retry.Policy{Attempts: 3, Retryable: retry.IsRetryable},
+	}, data)
+	got, ok := server.GetBlob("container", "retry.txt")
+	require.True(t, ok)
+	assert.Equal(t, data, got)
+
+	client.HTTPClient = &http.Client{Transport: &failingTransport{failures: 1}}
+	w, err := azblob.NewWriter(&azblob.NewWriterInput{Client: client, Container: "container", Blob: "fail.txt"})
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	assert.Error(t, w.Close())
+	_, ok = server.GetBlob("container", "fail.txt")
+	assert.False(t, ok)
+}
+
+func TestList(t *testing.T) {
+	server := azblobtest.NewServer()
+	defer server.Close()
+	for _, name := range []string{"a/1.txt", "a/2.txt", "a/b/3.txt", "c.txt"} {
+		server.PutBlob("container", name, []byte(name), "text/plain")
+	}
+	client := server.AzblobClient()
+
+	output, err := azblob.List(context.Background(), &azblob.ListInput{Client: client, Container: "container", Delimiter: "/"})
+	require.NoError(t, err)
+	require.Len(t, output.Blobs, 1)
+	assert.Equal(t, "c.txt", output.Blobs[0].Name)
+	assert.Equal(t, int64(5), output.Blobs[0].Size)
+	assert.Equal(t, []string{"a/"}, output.Prefixes)
+	assert.Empty(t, output.NextMarker)
+
+	names := []string{}
+	marker := ""
+	for {
+		output, err = azblob.List(context.Background(), &azblob.ListInput{Client: client, Container: "container", Prefix: "a/", Marker: marker, MaxResults: 2})
+		require.NoError(t, err)
+		for _, b := range output.Blobs {
+			names = append(names, b.Name)
+		}
+		if len(output.NextMarker) == 0 {
+			break
+		}
+		marker = output.NextMarker
+	}
+	assert.Equal(t, []string{"a/1.txt", "a/2.txt", "a/b/3.txt"}, names)
+
+	_, err = azblob.List(context.Background(), &azblob.ListInput{Client: client, Container: "missing"})
+	assert.Error(t, err)
+}
+
+func TestSharedAccessSignature(t *testing.T) {
+	server := azblobtest.NewServer()
+	defer server.Close()
+	server.SASToken = "sv=2020-04-08&sp=rl&sig=abc%2Bdef"
+	server.PutBlob("container", "a.txt", []byte("hello world"), "text/plain")
+
+	client, err := azblob.NewClient(&azblob.NewClientInput{Endpoint: server.Endpoint(), SASToken: "?" + server.SASToken})
+	require.NoError(t, err)
+	exists, _, err := azblob.Stat(context.Background(), client, "container", "a.txt")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	client, err = azblob.NewClient(&azblob.NewClientInput{Endpoint: server.Endpoint(), SASToken: "sv=2020-04-08&sig=wrong"})
+	require.NoError(t, err)
+	_, _, err = azblob.Stat(context.Background(), client, "container", "a.txt")
+	assert.Error(t, err)
+
+	client, err = azblob.NewClient(&azblob.NewClientInput{Endpoint: server.Endpoint(), Account: azblob.DevelopmentAccount, Key: "d3Jvbmc="})
+	require.NoError(t, err)
+	_, _, err = azblob.Stat(context.Background(), client, "container", "a.txt")
+	assert.Error(t, err)
+}
+
+func TestNewClientEnvironment(t *testing.T) {
+	server := azblobtest.NewServer()
+	defer server.Close()
+	server.PutBlob("container", "a.txt", []byte("hello world"), "text/plain")
+
+	t.Setenv(azblob.EnvAccount, "")
+	t.Setenv(azblob.EnvKey, "")
+	t.Setenv(azblob.EnvSASToken, "")
+	t.Setenv(azblob.EnvConnectionString, "UseDevelopmentStorage=true;DevelopmentStorageProxyUri="+server.URL)
+
+	client, err := azblob.NewClient(&azblob.NewClientInput{})
+	require.NoError(t, err)
+	assert.Equal(t, server.Endpoint(), client.Endpoint)
+	exists, _, err := azblob.Stat(context.Background(), client, "container", "a.txt")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestParseConnectionString(t *testing.T) {
+	c, err := azblob.ParseConnectionString("DefaultEndpointsProtocol=https;AccountName=myaccount;AccountKey=bXlrZXk=;EndpointSuffix=core.chinacloudapi.cn")
+	require.NoError(t, err)
+	assert.Equal(t, &azblob.ConnectionString{
+		Account:  "myaccount",
+		Key:      "bXlrZXk=",
+		Endpoint: "https://myaccount.blob.core.chinacloudapi.cn",
+	}, c)
+
+	c, err = azblob.ParseConnectionString("BlobEndpoint=https://myaccount.blob.core.windows.net/;SharedAccessSignature=sv=2020-04-08&sig=abc")
+	require.NoError(t, err)
+	assert.Equal(t, &azblob.ConnectionString{
+		SASToken: "sv=2020-04-08&sig=abc",
+		Endpoint: "https://myaccount.blob.core.windows.net",
+	}, c)
+
+	c, err = azblob.ParseConnectionString("UseDevelopmentStorage=true")
+	require.NoError(t, err)
+	assert.Equal(t, azblob.DevelopmentEndpoint, c.Endpoint)
+	assert.Equal(t, azblob.DevelopmentAccount, c.Account)
+
+	_, err = azblob.ParseConnectionString("AccountKey=bXlrZXk=")
+	assert.Error(t, err)
+
+	_, err = azblob.ParseConnectionString("invalid")
+	assert.Error(t, err)
+}
+
+func TestSplitPath(t *testing.T) {
+	container, blob, err := azblob.SplitPath("container/a/b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "container", container)
+	assert.Equal(t, "a/b.txt", blob)
+
+	container, blob, err = azblob.SplitPath("container")
+	assert.NoError(t, err)
+	assert.Equal(t, "container", container)
+	assert.Equal(t, "", blob)
+
+	_, _, err = azblob.SplitPath("/a/b.txt")
+	assert.Error(t, err)
+}
+
+func TestParseBlobURL(t *testing.T) {
+	u, ok := azblob.ParseBlobURL("https://myaccount.blob.core.windows.net/container/a/b.txt?sv=2020-04-08&sig=abc")
+	require.True(t, ok)
+	assert.Equal(t, &azblob.BlobURL{
+		Endpoint:  "https://myaccount.blob.core.windows.net",
+		Container: "container",
+		Blob:      "a/b.txt",
+		SASToken:  "sv=2020-04-08&sig=abc",
+	}, u)
+
+	for _, uri := range []string{
+		"http://myaccount.blob.core.windows.net/container/a.txt",
+		"https://myaccount.file.core.windows.net/container/a.txt",
+		"https://a.b.blob.core.windows.net/container/a.txt",
+		"https://myaccount.blob.core.windows.net/container",
+		"https://example.com/container/a.txt",
+	} {
+		_, ok = azblob.ParseBlobURL(uri)
+		assert.False(t, ok, uri)
+	}
+}
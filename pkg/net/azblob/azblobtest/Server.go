@@ -0,0 +1,393 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package azblobtest
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/azblob"
+)
+
+// blob is a blob stored by the server.
+type blob struct {
+	data         []byte
+	contentType  string
+	encoding     string
+	cacheControl string
+	etag         string
+	lastModified time.Time
+	metadata     map[string]string
+}
+
+// Server is a fake Azure Blob Storage server for a single storage account that stores blobs in memory.
+// The server uses path-style urls, like Azurite, so the endpoint is the url of the server followed by the account.
+// The server supports getting, listing, and deleting blobs, ranged reads, and uploading block blobs.
+type Server struct {
+	*httptest.Server
+	Account    string                      // name of the storage account
+	Credential *azblob.SharedKeyCredential // if set, then requests must be signed with the shared key
+	SASToken   string                      // if set, then requests may be authorized with the shared access signature
+	mutex      *sync.Mutex
+	containers map[string]map[string]*blob
+	blocks     map[string]map[string][]byte
+	counter    int
+}
+
+// NewServer starts and returns a new Server for the account of the Azurite emulator.
+// Requests must be signed with the well-known shared key of the account.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	credential, err := azblob.NewSharedKeyCredential(azblob.DevelopmentAccount, azblob.DevelopmentKey)
+	if err != nil {
+		panic(err)
+	}
+	s := &Server{
+		Account:    azblob.DevelopmentAccount,
+		Credential: credential,
+		mutex:      &sync.Mutex{},
+		containers: map[string]map[string]*blob{},
+		blocks:     map[string]map[string][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Endpoint returns the endpoint of the blob service.
+func (s *Server) Endpoint() string {
+	return s.URL + "/" + s.Account
+}
+
+// AzblobClient returns a client for the server that signs requests with the shared key.
+func (s *Server) AzblobClient() *azblob.Client {
+	return &azblob.Client{Endpoint: s.Endpoint(), Credential: s.Credential}
+}
+
+// CreateContainer creates the container, if it does not exist.
+func (s *Server) CreateContainer(container string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.containers[container]; !ok {
+		s.containers[container] = map[string]*blob{}
+	}
+}
+
+// PutBlob stores the blob in the container, creating the container if it does not exist.
+func (s *Server) PutBlob(container string, name string, data []byte, contentType string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.containers[container]; !ok {
+		s.containers[container] = map[string]*blob{}
+	}
+	s.putBlob(container, name, &blob{data: data, contentType: contentType})
+}
+
+// GetBlob returns the contents of the blob in the container and true, or false if the blob does not exist.
+func (s *Server) GetBlob(container string, name string) ([]byte, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, ok := s.containers[container][name]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte{}, b.data...), true
+}
+
+func (s *Server) putBlob(container string, name string, b *blob) {
+	s.counter++
+	b.etag = fmt.Sprintf("\"0x8D9%012X\"", s.counter)
+	b.lastModified = time.Now().UTC().Truncate(time.Second)
+	s.containers[container][name] = b
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(statusCode)
+	_, _ = fmt.Fprintf(w, "%s<Error><Code>%s</Code><Message>%s</Message></Error>", xml.Header, code, http.StatusText(statusCode))
+}
+
+// authorized returns true if the request is signed with the shared key or has the shared access signature.
+func (s *Server) authorized(r *http.Request) bool {
+	if len(s.SASToken) > 0 {
+		sas, err := url.ParseQuery(s.SASToken)
+		if err == nil && len(sas.Get("sig")) > 0 && r.URL.Query().Get("sig") == sas.Get("sig") {
+			return true
+		}
+	}
+	if s.Credential == nil {
+		return len(s.SASToken) == 0
+	}
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) == 0 {
+		return false
+	}
+	clone := r.Clone(r.Context())
+	s.Credential.Sign(clone)
+	return clone.Header.Get("Authorization") == authorization
+}
+
+// splitPath splits the escaped path into the account, container, and blob name.
+func splitPath(p string) (string, string, string) {
+	parts := strings.SplitN(strings.TrimPrefix(p, "/"), "/", 3)
+	for i, part := range parts {
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			parts[i] = unescaped
+		}
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "")
+	}
+	return parts[0], parts[1], parts[2]
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusForbidden, "AuthenticationFailed")
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	account, container, name := splitPath(r.URL.EscapedPath())
+	if account != s.Account || len(container) == 0 {
+		writeError(w, http.StatusBadRequest, "InvalidUri")
+		return
+	}
+	query := r.URL.Query()
+	if len(name) == 0 {
+		switch {
+		case r.Method == http.MethodPut && query.Get("restype") == "container":
+			if _, ok := s.containers[container]; ok {
+				writeError(w, http.StatusConflict, "ContainerAlreadyExists")
+				return
+			}
+			s.containers[container] = map[string]*blob{}
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && query.Get("restype") == "container" && query.Get("comp") == "list":
+			s.list(w, r, container)
+		default:
+			writeError(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+		}
+		return
+	}
+	blobs, ok := s.containers[container]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+	switch r.Method {
+	case http.MethodHead, http.MethodGet:
+		b, found := blobs[name]
+		if !found {
+			writeError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		if ifMatch := r.Header.Get("If-Match"); len(ifMatch) > 0 && ifMatch != b.etag {
+			writeError(w, http.StatusPreconditionFailed, "ConditionNotMet")
+			return
+		}
+		s.serveBlob(w, r, b)
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+		key := container + "/" + name
+		switch query.Get("comp") {
+		case "block":
+			if _, found := s.blocks[key]; !found {
+				s.blocks[key] = map[string][]byte{}
+			}
+			s.blocks[key][query.Get("blockid")] = body
+			w.WriteHeader(http.StatusCreated)
+		case "blocklist":
+			blockList := struct {
+				Latest []string `xml:"Latest"`
+			}{}
+			if errUnmarshal := xml.Unmarshal(body, &blockList); errUnmarshal != nil {
+				writeError(w, http.StatusBadRequest, "InvalidXmlDocument")
+				return
+			}
+			data := []byte{}
+			for _, id := range blockList.Latest {
+				block, found := s.blocks[key][id]
+				if !found {
+					writeError(w, http.StatusBadRequest, "InvalidBlockList")
+					return
+				}
+				data = append(data, block...)
+			}
+			delete(s.blocks, key)
+			b := &blob{
+				data:         data,
+				contentType:  r.Header.Get("x-ms-blob-content-type"),
+				encoding:     r.Header.Get("x-ms-blob-content-encoding"),
+				cacheControl: r.Header.Get("x-ms-blob-cache-control"),
+				metadata:     map[string]string{},
+			}
+			for k, v := range r.Header {
+				if n := strings.ToLower(k); strings.HasPrefix(n, "x-ms-meta-") && len(v) > 0 {
+					b.metadata[strings.TrimPrefix(n, "x-ms-meta-")] = v[0]
+				}
+			}
+			s.putBlob(container, name, b)
+			w.Header().Set("ETag", b.etag)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			writeError(w, http.StatusBadRequest, "UnsupportedQueryParameter")
+		}
+	case http.MethodDelete:
+		if _, found := blobs[name]; !found {
+			writeError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		delete(blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "UnsupportedHttpVerb")
+	}
+}
+
+func (s *Server) serveBlob(w http.ResponseWriter, r *http.Request, b *blob) {
+	hash := md5.Sum(b.data)
+	w.Header().Set("ETag", b.etag)
+	w.Header().Set("Last-Modified", b.lastModified.Format(http.TimeFormat))
+	w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(hash[:]))
+	w.Header().Set("x-ms-blob-type", "BlockBlob")
+	w.Header().Set("x-ms-access-tier", "Hot")
+	if len(b.contentType) > 0 {
+		w.Header().Set("Content-Type", b.contentType)
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	if len(b.encoding) > 0 {
+		w.Header().Set("Content-Encoding", b.encoding)
+	}
+	if len(b.cacheControl) > 0 {
+		w.Header().Set("Cache-Control", b.cacheControl)
+	}
+	for k, v := range b.metadata {
+		w.Header()["x-ms-meta-"+k] = []string{v}
+	}
+	data := b.data
+	statusCode := http.StatusOK
+	if rng := r.Header.Get("x-ms-range"); r.Method == http.MethodGet && strings.HasPrefix(rng, "bytes=") && strings.HasSuffix(rng, "-") {
+		start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRange")
+			return
+		}
+		if start >= len(b.data) {
+			writeError(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(b.data)-1, len(b.data)))
+		data = b.data[start:]
+		statusCode = http.StatusPartialContent
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(statusCode)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(data)
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, container string) {
+	blobs, ok := s.containers[container]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+	query := r.URL.Query()
+	prefix, delimiter, marker := query.Get("prefix"), query.Get("delimiter"), query.Get("marker")
+	maxResults, _ := strconv.Atoi(query.Get("maxresults"))
+	names := make([]string, 0, len(blobs))
+	for name := range blobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	type metadataItem struct {
+		XMLName xml.Name
+		Value   string `xml:",chardata"`
+	}
+	type blobItem struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified    string `xml:"Last-Modified"`
+			ETag            string `xml:"Etag"`
+			ContentLength   int    `xml:"Content-Length"`
+			ContentType     string `xml:"Content-Type"`
+			ContentEncoding string `xml:"Content-Encoding"`
+			CacheControl    string `xml:"Cache-Control"`
+			BlobType        string `xml:"BlobType"`
+			AccessTier      string `xml:"AccessTier"`
+		} `xml:"Properties"`
+		Metadata struct {
+			Items []metadataItem
+		} `xml:"Metadata"`
+	}
+	type prefixItem struct {
+		Name string `xml:"Name"`
+	}
+	results := struct {
+		XMLName    xml.Name     `xml:"EnumerationResults"`
+		Blobs      []blobItem   `xml:"Blobs>Blob"`
+		Prefixes   []prefixItem `xml:"Blobs>BlobPrefix"`
+		NextMarker string       `xml:"NextMarker"`
+	}{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) || name < marker {
+			continue
+		}
+		if maxResults > 0 && len(results.Blobs)+len(results.Prefixes) == maxResults {
+			results.NextMarker = name
+			break
+		}
+		if len(delimiter) > 0 {
+			if i := strings.Index(name[len(prefix):], delimiter); i != -1 {
+				p := name[0 : len(prefix)+i+len(delimiter)]
+				if !seen[p] {
+					seen[p] = true
+					results.Prefixes = append(results.Prefixes, prefixItem{Name: p})
+				}
+				continue
+			}
+		}
+		b := blobs[name]
+		item := blobItem{Name: name}
+		item.Properties.LastModified = b.lastModified.Format(http.TimeFormat)
+		item.Properties.ETag = b.etag
+		item.Properties.ContentLength = len(b.data)
+		item.Properties.ContentType = b.contentType
+		item.Properties.ContentEncoding = b.encoding
+		item.Properties.CacheControl = b.cacheControl
+		item.Properties.BlobType = "BlockBlob"
+		item.Properties.AccessTier = "Hot"
+		for k, v := range b.metadata {
+			item.Metadata.Items = append(item.Metadata.Items, metadataItem{XMLName: xml.Name{Local: k}, Value: v})
+		}
+		results.Blobs = append(results.Blobs, item)
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, xml.Header)
+	_ = xml.NewEncoder(w).Encode(results)
+}
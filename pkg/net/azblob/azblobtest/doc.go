@@ -0,0 +1,9 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+// Package azblobtest provides an in-memory fake of the Azure Blob Storage REST API for testing.
+package azblobtest
@@ -0,0 +1,12 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+// Package azblob provides functions for interacting with Azure Blob Storage using the REST API.
+// Requests are authorized with a shared key or a shared access signature (SAS),
+// which can be given directly or through a connection string.
+// The endpoint can be overridden, such as for a local Azurite server.
+package azblob
@@ -0,0 +1,63 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ftp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jlaffaye/ftp"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+)
+
+// Dial connects to the FTP server at the given FTP address
+// and logs in with the user and password in the address, if any.
+// Dial returns the connection, the path in the address relative to the login directory, and an error, if any.
+// The caller should call Quit on the connection when finished.
+//
+// Dial returns an error if the address cannot be dialed,
+// the userinfo cannot be parsed, or
+// the user and password are invalid.
+func Dial(ctx context.Context, uri string) (*ftp.ServerConn, string, error) {
+	_, fullpath := splitter.SplitURI(uri)
+
+	authority, p := fullpath, ""
+	if i := strings.Index(fullpath, "/"); i != -1 {
+		authority, p = fullpath[0:i], fullpath[i+1:]
+	}
+
+	userinfo, host, port := splitter.SplitAuthority(authority)
+	if len(port) == 0 {
+		port = strconv.Itoa(DefaultPort)
+	}
+
+	conn, err := ftp.Dial(fmt.Sprintf("%s:%s", host, port), ftp.DialWithTimeout(DefaultTimeout), ftp.DialWithContext(ctx))
+	if err != nil {
+		return nil, "", fmt.Errorf("error connecting to FTP server at uri %q: %w", uri, err)
+	}
+
+	if len(userinfo) > 0 {
+		user, password, errSplitUserInfo := splitter.SplitUserInfo(userinfo)
+		if errSplitUserInfo != nil {
+			_ = conn.Quit() // attempt to quit the underlying connection
+			return nil, "", fmt.Errorf("error parsing user info %q: %w", userinfo, errSplitUserInfo)
+		}
+		if len(user) > 0 {
+			errLogin := conn.Login(user, password)
+			if errLogin != nil {
+				_ = conn.Quit() // attempt to quit the underlying connection
+				return nil, "", fmt.Errorf("error logging in with user %q: %w", user, errLogin)
+			}
+		}
+	}
+
+	return conn, p, nil
+}
@@ -8,14 +8,9 @@
 package ftp
 
 import (
+	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
-
-	"github.com/jlaffaye/ftp"
-
-	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
 )
 
 const (
@@ -24,49 +19,36 @@ const (
 )
 
 // Fetch returns a Reader for an object for given FTP address.
-// ReadFTPFile returns the Reader and error, if any.
+// Fetch returns the Reader and error, if any.
 //
-// ReadFTPFile returns an error if the address cannot be dialed,
+// Fetch returns an error if the address cannot be dialed,
 // the userinfo cannot be parsed,
 // the user and password are invalid, or
 // the file cannot be retrieved.
 //
 func Fetch(uri string) (*Reader, error) {
+	return FetchFrom(uri, 0)
+}
 
-	_, fullpath := splitter.SplitURI(uri)
-
-	parts := strings.SplitN(fullpath, "/", 2)
-	authority, p := parts[0], parts[1]
-
-	userinfo, host, port := splitter.SplitAuthority(authority)
-	if len(port) == 0 {
-		port = strconv.Itoa(DefaultPort)
-	}
+// FetchFrom returns a Reader for an object for given FTP address starting at the given byte offset.
+// If the offset is greater than zero, then the server is sent a REST command before retrieving the file.
+// FetchFrom returns the Reader and error, if any.
+//
+func FetchFrom(uri string, offset int64) (*Reader, error) {
 
-	conn, err := ftp.Dial(fmt.Sprintf("%s:%s", host, port), ftp.DialWithTimeout(DefaultTimeout))
+	conn, p, err := Dial(context.Background(), uri)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file from uri %q: %w", uri, err)
-	}
-
-	if len(userinfo) > 0 {
-		user, password, errSplitUserInfo := splitter.SplitUserInfo(userinfo)
-		if errSplitUserInfo != nil {
-			return nil, fmt.Errorf("error parsing user info %q: %w", userinfo, errSplitUserInfo)
-		}
-		if len(user) > 0 {
-			errLogin := conn.Login(user, password)
-			if errLogin != nil {
-				return nil, fmt.Errorf("error logging in with user %q: %w", user, errLogin)
-			}
-		}
+		return nil, err
 	}
 
-	resp, errRetr := conn.Retr(p)
+	resp, errRetr := conn.RetrFrom(p, uint64(offset))
 	if errRetr != nil {
+		_ = conn.Quit() // attempt to quit the underlying connection
 		return nil, fmt.Errorf("error reading file from uri %q: %w", uri, errRetr)
 	}
 
 	if resp == nil {
+		_ = conn.Quit() // attempt to quit the underlying connection
 		return nil, fmt.Errorf("error reading file from uri %q: response is empty", uri)
 	}
 
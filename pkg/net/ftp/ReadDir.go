@@ -0,0 +1,33 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ftp
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jlaffaye/ftp"
+)
+
+// ReadDir returns the file info for the files and directories in the directory at the path on the FTP server,
+// listed using MLSD if the server supports it and LIST otherwise.
+// The entries for the directory itself and its parent are not included.
+func ReadDir(conn *ftp.ServerConn, p string) ([]os.FileInfo, error) {
+	entries, err := conn.List(p)
+	if err != nil {
+		return nil, fmt.Errorf("error listing directory %q: %w", p, err)
+	}
+	infos := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name == "." || entry.Name == ".." {
+			continue
+		}
+		infos = append(infos, &fileInfo{entry: entry})
+	}
+	return infos, nil
+}
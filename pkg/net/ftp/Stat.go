@@ -0,0 +1,115 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ftp
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"os"
+	"path"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+)
+
+// Stat returns the file info of the file or directory at the path on the FTP server.
+// Returns a bool indicating whether the file or directory exists, the file info, and an error if any.
+// If the file or directory does not exist, then the error is supressed and returns false, nil, nil
+//
+// The FTP client does not support the MLST or MDTM commands,
+// so the entry is found by listing the parent directory, which uses MLSD if the server supports it and LIST otherwise.
+// If the entry is not in the listing, such as a hidden file, then SIZE is used for files and CWD for directories,
+// in which case the last modified time is unknown.
+// The permissions of files are not known, so the file mode only indicates whether the path is a directory.
+func Stat(conn *ftp.ServerConn, p string) (bool, os.FileInfo, error) {
+	p = path.Clean(p)
+	if p == "." || p == "/" {
+		return true, &fileInfo{entry: &ftp.Entry{Name: p, Type: ftp.EntryTypeFolder}}, nil
+	}
+	name := path.Base(p)
+
+	entries, err := conn.List(path.Dir(p))
+	if err != nil && !isNotFound(err) {
+		return false, nil, fmt.Errorf("error listing parent directory of %q: %w", p, err)
+	}
+	for _, entry := range entries {
+		if entry.Name == name {
+			return true, &fileInfo{entry: entry}, nil
+		}
+	}
+
+	size, err := conn.FileSize(p)
+	if err == nil {
+		return true, &fileInfo{entry: &ftp.Entry{Name: name, Type: ftp.EntryTypeFile, Size: uint64(size)}}, nil
+	}
+	if !isNotFound(err) {
+		return false, nil, fmt.Errorf("error getting size of %q: %w", p, err)
+	}
+
+	cwd, err := conn.CurrentDir()
+	if err != nil {
+		return false, nil, fmt.Errorf("error getting current directory: %w", err)
+	}
+	err = conn.ChangeDir(p)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil, nil
+		}
+		return false, nil, fmt.Errorf("error changing directory to %q: %w", p, err)
+	}
+	err = conn.ChangeDir(cwd)
+	if err != nil {
+		return false, nil, fmt.Errorf("error changing directory back to %q: %w", cwd, err)
+	}
+	return true, &fileInfo{entry: &ftp.Entry{Name: name, Type: ftp.EntryTypeFolder}}, nil
+}
+
+// isNotFound returns true if the server responded that the file or directory is unavailable.
+func isNotFound(err error) bool {
+	var protocolError *textproto.Error
+	return errors.As(err, &protocolError) && protocolError.Code == ftp.StatusFileUnavailable
+}
+
+// fileInfo implements the os.FileInfo interface for an entry on a FTP server.
+type fileInfo struct {
+	entry *ftp.Entry
+}
+
+// Name returns the base name of the file.
+func (fi *fileInfo) Name() string {
+	return fi.entry.Name
+}
+
+// Size returns the length in bytes of the file.
+func (fi *fileInfo) Size() int64 {
+	return int64(fi.entry.Size)
+}
+
+// Mode returns os.ModeDir for directories and 0 otherwise.
+func (fi *fileInfo) Mode() os.FileMode {
+	if fi.IsDir() {
+		return os.ModeDir
+	}
+	return 0
+}
+
+// ModTime returns the last modified time of the file, or the zero time if unknown.
+func (fi *fileInfo) ModTime() time.Time {
+	return fi.entry.Time
+}
+
+// IsDir returns true if the file is a directory.
+func (fi *fileInfo) IsDir() bool {
+	return fi.entry.Type == ftp.EntryTypeFolder
+}
+
+// Sys returns the underlying *ftp.Entry.
+func (fi *fileInfo) Sys() interface{} {
+	return fi.entry
+}
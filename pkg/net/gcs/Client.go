@@ -0,0 +1,36 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Client is a client for the Google Cloud Storage JSON API.
+type Client struct {
+	Endpoint    string       // endpoint of the API, without a trailing slash
+	HTTPClient  *http.Client // HTTP client used to send requests
+	TokenSource TokenSource  // source of access tokens, or nil for unauthorized requests
+}
+
+// do authorizes the request, if the client has a token source, and sends the request.
+func (c *Client) do(request *http.Request) (*http.Response, error) {
+	if c.TokenSource != nil {
+		token, err := c.TokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error authorizing request: %w", err)
+		}
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(request)
+}
@@ -0,0 +1,58 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	CredentialsTypeServiceAccount = "service_account"
+	CredentialsTypeAuthorizedUser = "authorized_user"
+)
+
+// Credentials are the Google credentials from a JSON file,
+// either a service account key or the application default credentials of a user.
+type Credentials struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// ParseCredentials parses the Google credentials from the given JSON.
+// ParseCredentials returns an error if the JSON is invalid or the credentials type is not supported.
+func ParseCredentials(b []byte) (*Credentials, error) {
+	c := &Credentials{}
+	err := json.Unmarshal(b, c)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing credentials: %w", err)
+	}
+	switch c.Type {
+	case CredentialsTypeServiceAccount:
+		if len(c.ClientEmail) == 0 || len(c.PrivateKey) == 0 {
+			return nil, fmt.Errorf("error parsing credentials: service account credentials are missing the client email or private key")
+		}
+	case CredentialsTypeAuthorizedUser:
+		if len(c.ClientID) == 0 || len(c.ClientSecret) == 0 || len(c.RefreshToken) == 0 {
+			return nil, fmt.Errorf("error parsing credentials: authorized user credentials are missing the client id, client secret, or refresh token")
+		}
+	default:
+		return nil, fmt.Errorf("error parsing credentials: unsupported credentials type %q", c.Type)
+	}
+	if len(c.TokenURI) == 0 {
+		c.TokenURI = DefaultTokenURI
+	}
+	return c, nil
+}
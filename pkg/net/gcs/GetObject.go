@@ -0,0 +1,83 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	pkghttp "github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// GetObjectInput contains the input parameters for GetObject.
+type GetObjectInput struct {
+	Client     *Client         // client for Google Cloud Storage
+	Bucket     string          // name of the bucket
+	Object     string          // name of the object
+	Offset     int64           // byte offset to start reading from
+	Generation int64           // if greater than zero, then the object must have this generation
+	Context    context.Context // context for the request, defaults to context.Background()
+}
+
+// GetObject returns a reader for the contents of the object, starting at the given offset.
+// If the offset is equal to the size of the object, then the reader is empty.
+// The reader returns the bytes as stored, so objects with a gzip content encoding are not decompressed.
+//
+// If the generation is set and the object has been replaced with a new generation,
+// then GetObject returns ErrResourceChanged from the http package.
+func GetObject(input *GetObjectInput) (io.ReadCloser, error) {
+	uri := objectURL(input.Client.Endpoint, input.Bucket, input.Object) + "?alt=media"
+	if input.Generation > 0 {
+		uri += "&ifGenerationMatch=" + strconv.FormatInt(input.Generation, 10)
+	}
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for object %q in bucket %q: %w", input.Object, input.Bucket, err)
+	}
+	// accept gzip, so that the service does not transcode objects with a gzip content encoding
+	// and the HTTP client does not transparently decompress the body.
+	request.Header.Set("Accept-Encoding", "gzip")
+	if input.Offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", input.Offset))
+	}
+	response, err := input.Client.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error getting object %q in bucket %q: %w", input.Object, input.Bucket, err)
+	}
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		if input.Offset > 0 && response.StatusCode == http.StatusOK {
+			// the range was ignored, so discard the bytes before the offset.
+			_, err = io.CopyN(io.Discard, response.Body, input.Offset)
+			if err != nil {
+				_ = response.Body.Close()
+				return nil, fmt.Errorf("error discarding %d bytes from object %q in bucket %q: %w", input.Offset, input.Object, input.Bucket, err)
+			}
+		}
+		return response.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		_ = response.Body.Close()
+		if input.Offset > 0 {
+			// the offset is at or beyond the end of the object
+			return http.NoBody, nil
+		}
+	case http.StatusPreconditionFailed:
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("error getting object %q in bucket %q: %w", input.Object, input.Bucket, pkghttp.ErrResourceChanged)
+	default:
+		_ = response.Body.Close()
+	}
+	return nil, fmt.Errorf("error getting object %q in bucket %q: %w", input.Object, input.Bucket, errUnexpectedStatus(uri, response.StatusCode))
+}
@@ -0,0 +1,90 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListInput contains the input parameters for List.
+type ListInput struct {
+	Client     *Client // client for Google Cloud Storage
+	Bucket     string  // name of the bucket
+	Prefix     string  // only list objects whose names begin with the prefix
+	Delimiter  string  // group objects whose names contain the delimiter after the prefix, usually "/"
+	PageToken  string  // token for the page of results to return
+	MaxResults int     // maximum number of objects and prefixes in the page, if greater than zero
+}
+
+// ListOutput contains the output of List.
+type ListOutput struct {
+	Objects       []*ObjectAttrs // objects in the page
+	Prefixes      []string       // prefixes in the page that group objects when a delimiter is set
+	NextPageToken string         // token for the next page of results, or blank if this is the last page
+}
+
+// List returns a page of the objects in the bucket.
+// To list all the objects, call List with the next page token until the token is blank.
+func List(ctx context.Context, input *ListInput) (*ListOutput, error) {
+	query := url.Values{}
+	if len(input.Prefix) > 0 {
+		query.Set("prefix", input.Prefix)
+	}
+	if len(input.Delimiter) > 0 {
+		query.Set("delimiter", input.Delimiter)
+	}
+	if len(input.PageToken) > 0 {
+		query.Set("pageToken", input.PageToken)
+	}
+	if input.MaxResults > 0 {
+		query.Set("maxResults", strconv.Itoa(input.MaxResults))
+	}
+	uri := bucketURL(input.Client.Endpoint, input.Bucket)
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for bucket %q: %w", input.Bucket, err)
+	}
+	response, err := input.Client.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing objects in bucket %q: %w", input.Bucket, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error listing objects in bucket %q: %w", input.Bucket, errUnexpectedStatus(uri, response.StatusCode))
+	}
+	page := struct {
+		Items         []json.RawMessage `json:"items"`
+		Prefixes      []string          `json:"prefixes"`
+		NextPageToken string            `json:"nextPageToken"`
+	}{}
+	err = json.NewDecoder(response.Body).Decode(&page)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding objects in bucket %q: %w", input.Bucket, err)
+	}
+	output := &ListOutput{
+		Objects:       make([]*ObjectAttrs, 0, len(page.Items)),
+		Prefixes:      page.Prefixes,
+		NextPageToken: page.NextPageToken,
+	}
+	for _, item := range page.Items {
+		attrs, errParse := parseObjectAttrs(item)
+		if errParse != nil {
+			return nil, errParse
+		}
+		output.Objects = append(output.Objects, attrs)
+	}
+	return output, nil
+}
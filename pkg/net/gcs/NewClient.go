@@ -0,0 +1,128 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// NewClientInput contains the input parameters for NewClient.
+type NewClientInput struct {
+	Endpoint        string       // endpoint of the API, defaults to the emulator host in the environment or DefaultEndpoint
+	CredentialsFile string       // path to a credentials JSON file
+	Credentials     []byte       // contents of a credentials JSON file
+	AccessToken     string       // OAuth 2.0 access token
+	Anonymous       bool         // send requests without authorization
+	HTTPClient      *http.Client // HTTP client used to send requests
+}
+
+// NewClient returns a new client for Google Cloud Storage.
+//
+// The credentials are taken from the first of the following that is set:
+// the access token, the credentials, the credentials file,
+// the GOOGLE_OAUTH_ACCESS_TOKEN environment variable,
+// the file at the path in the GOOGLE_APPLICATION_CREDENTIALS environment variable, and
+// the application default credentials created by "gcloud auth application-default login".
+//
+// If the STORAGE_EMULATOR_HOST environment variable is set and no endpoint is given,
+// then requests are sent to the emulator without authorization.
+// If no credentials are found, then requests are sent without authorization,
+// which only allows access to public objects.
+func NewClient(input *NewClientInput) (*Client, error) {
+	client := &Client{
+		Endpoint:   strings.TrimSuffix(input.Endpoint, "/"),
+		HTTPClient: input.HTTPClient,
+	}
+
+	anonymous := input.Anonymous
+	if len(client.Endpoint) == 0 {
+		if host := os.Getenv(EnvEmulatorHost); len(host) > 0 {
+			if !strings.Contains(host, "://") {
+				host = "http://" + host
+			}
+			client.Endpoint = strings.TrimSuffix(host, "/")
+			anonymous = true
+		} else {
+			client.Endpoint = DefaultEndpoint
+		}
+	}
+
+	if anonymous {
+		return client, nil
+	}
+
+	if len(input.AccessToken) > 0 {
+		client.TokenSource = StaticTokenSource(input.AccessToken)
+		return client, nil
+	}
+
+	credentials := input.Credentials
+	if len(credentials) == 0 {
+		credentialsFile := input.CredentialsFile
+		if len(credentialsFile) == 0 {
+			if accessToken := os.Getenv(EnvOAuthAccessToken); len(accessToken) > 0 {
+				client.TokenSource = StaticTokenSource(accessToken)
+				return client, nil
+			}
+			credentialsFile = os.Getenv(EnvCredentials)
+		}
+		if len(credentialsFile) == 0 {
+			credentialsFile = wellKnownCredentialsFile()
+			if len(credentialsFile) == 0 {
+				return client, nil
+			}
+		}
+		path, err := homedir.Expand(credentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding credentials file path %q: %w", credentialsFile, err)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading credentials file %q: %w", path, err)
+		}
+		credentials = b
+	}
+
+	c, err := ParseCredentials(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenSource, err := NewTokenSource(c, ScopeReadWrite, input.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+	client.TokenSource = tokenSource
+
+	return client, nil
+}
+
+// wellKnownCredentialsFile returns the path to the application default credentials created by gcloud, if the file exists.
+func wellKnownCredentialsFile() string {
+	var path string
+	if runtime.GOOS == "windows" {
+		path = filepath.Join(os.Getenv("APPDATA"), "gcloud", "application_default_credentials.json")
+	} else {
+		home, err := homedir.Dir()
+		if err != nil {
+			return ""
+		}
+		path = filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
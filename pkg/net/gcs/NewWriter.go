@@ -0,0 +1,103 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
+)
+
+// NewWriterInput contains the input parameters for NewWriter.
+type NewWriterInput struct {
+	Client          *Client           // client for Google Cloud Storage
+	Bucket          string            // name of the bucket
+	Object          string            // name of the object
+	ContentType     string            // content type of the object
+	ContentEncoding string            // content encoding of the object, such as gzip
+	CacheControl    string            // cache control of the object
+	Metadata        map[string]string // user metadata of the object
+	ChunkSize       int               // size of the chunks of the upload, a multiple of 256 KiB, defaults to DefaultChunkSize
+	Retry           *retry.Policy     // policy for retrying failed requests
+	Context         context.Context   // context for the requests of the upload, defaults to context.Background()
+}
+
+// NewWriter starts a resumable upload and returns a Writer that uploads the object in chunks.
+// The object is created or replaced when the writer is closed.
+//
+// NewWriter returns an error if the chunk size is not a multiple of 256 KiB or
+// the upload could not be started.
+func NewWriter(input *NewWriterInput) (*Writer, error) {
+	chunkSize := input.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize < 0 || chunkSize%ChunkSizeUnit != 0 {
+		return nil, fmt.Errorf("error creating writer: chunk size %d is not a multiple of %d", chunkSize, ChunkSizeUnit)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"name":            input.Object,
+		"contentType":     input.ContentType,
+		"contentEncoding": input.ContentEncoding,
+		"cacheControl":    input.CacheControl,
+		"metadata":        input.Metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding attributes of object %q: %w", input.Object, err)
+	}
+
+	ctx := input.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	uri := uploadURL(input.Client.Endpoint, input.Bucket, input.Object)
+	sessionURI := ""
+	err = input.Retry.Do(ctx, func() error {
+		request, errRequest := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(body))
+		if errRequest != nil {
+			return fmt.Errorf("error creating request for object %q in bucket %q: %w", input.Object, input.Bucket, errRequest)
+		}
+		request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		if len(input.ContentType) > 0 {
+			request.Header.Set("X-Upload-Content-Type", input.ContentType)
+		}
+		response, errDo := input.Client.do(request)
+		if errDo != nil {
+			return fmt.Errorf("error starting upload of object %q in bucket %q: %w", input.Object, input.Bucket, errDo)
+		}
+		_ = response.Body.Close()
+		if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+			return fmt.Errorf("error starting upload of object %q in bucket %q: %w", input.Object, input.Bucket, errUnexpectedStatus(uri, response.StatusCode))
+		}
+		sessionURI = response.Header.Get("Location")
+		if len(sessionURI) == 0 {
+			return fmt.Errorf("error starting upload of object %q in bucket %q: response is missing the session uri", input.Object, input.Bucket)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		client:     input.Client,
+		bucket:     input.Bucket,
+		object:     input.Object,
+		sessionURI: sessionURI,
+		chunkSize:  chunkSize,
+		buffer:     make([]byte, 0, chunkSize),
+		retry:      input.Retry,
+		ctx:        ctx,
+	}, nil
+}
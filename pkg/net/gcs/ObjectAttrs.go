@@ -0,0 +1,54 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ObjectAttrs are the attributes of an object in Google Cloud Storage.
+type ObjectAttrs struct {
+	Bucket          string            `json:"bucket"`
+	Name            string            `json:"name"`
+	Size            int64             `json:"size,string"`
+	ContentType     string            `json:"contentType,omitempty"`
+	ContentEncoding string            `json:"contentEncoding,omitempty"`
+	CacheControl    string            `json:"cacheControl,omitempty"`
+	ETag            string            `json:"etag,omitempty"`
+	MD5Hash         string            `json:"md5Hash,omitempty"`
+	CRC32C          string            `json:"crc32c,omitempty"`
+	Generation      int64             `json:"generation,string"`
+	Metageneration  int64             `json:"metageneration,string"`
+	StorageClass    string            `json:"storageClass,omitempty"`
+	Created         time.Time         `json:"timeCreated"`
+	Updated         time.Time         `json:"updated"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+// parseObjectAttrs parses the object resource returned by the API.
+// The API returns the size and generation as strings, but some emulators return numbers, so both are supported.
+func parseObjectAttrs(b []byte) (*ObjectAttrs, error) {
+	raw := struct {
+		ObjectAttrs
+		Size           json.Number `json:"size"`
+		Generation     json.Number `json:"generation"`
+		Metageneration json.Number `json:"metageneration"`
+	}{}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding object attributes: %w", err)
+	}
+	attrs := &raw.ObjectAttrs
+	attrs.Size, _ = strconv.ParseInt(raw.Size.String(), 10, 64)
+	attrs.Generation, _ = strconv.ParseInt(raw.Generation.String(), 10, 64)
+	attrs.Metageneration, _ = strconv.ParseInt(raw.Metageneration.String(), 10, 64)
+	return attrs, nil
+}
@@ -0,0 +1,46 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Stat returns the attributes of the object in the bucket.
+// Returns a bool indicating whether the object exists, the object attributes, and an error if any.
+// If the object does not exist, then the error is supressed and returns false, nil, nil
+func Stat(ctx context.Context, client *Client, bucket string, object string) (bool, *ObjectAttrs, error) {
+	uri := objectURL(client.Endpoint, bucket, object)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return false, nil, fmt.Errorf("error creating request for object %q in bucket %q: %w", object, bucket, err)
+	}
+	response, err := client.do(request)
+	if err != nil {
+		return false, nil, fmt.Errorf("error getting attributes of object %q in bucket %q: %w", object, bucket, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return false, nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return false, nil, fmt.Errorf("error getting attributes of object %q in bucket %q: %w", object, bucket, errUnexpectedStatus(uri, response.StatusCode))
+	}
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false, nil, fmt.Errorf("error reading attributes of object %q in bucket %q: %w", object, bucket, err)
+	}
+	attrs, err := parseObjectAttrs(b)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, attrs, nil
+}
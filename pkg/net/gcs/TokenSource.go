@@ -0,0 +1,117 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource returns OAuth 2.0 access tokens for authorizing requests.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// StaticTokenSource is a TokenSource that always returns the same access token.
+type StaticTokenSource string
+
+// Token returns the access token.
+func (s StaticTokenSource) Token() (string, error) {
+	return string(s), nil
+}
+
+// credentialsTokenSource exchanges credentials for access tokens,
+// and caches each token until shortly before it expires.
+type credentialsTokenSource struct {
+	credentials *Credentials
+	privateKey  *rsa.PrivateKey
+	scope       string
+	httpClient  *http.Client
+	mutex       *sync.Mutex
+	token       string
+	expiry      time.Time
+}
+
+// NewTokenSource returns a TokenSource for the given credentials and scope.
+// If the http client is nil, then the default client is used.
+// NewTokenSource returns an error if the private key of a service account cannot be parsed.
+func NewTokenSource(credentials *Credentials, scope string, httpClient *http.Client) (TokenSource, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	s := &credentialsTokenSource{
+		credentials: credentials,
+		scope:       scope,
+		httpClient:  httpClient,
+		mutex:       &sync.Mutex{},
+	}
+	if credentials.Type == CredentialsTypeServiceAccount {
+		key, err := parsePrivateKey(credentials.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing private key of service account %q: %w", credentials.ClientEmail, err)
+		}
+		s.privateKey = key
+	}
+	return s, nil
+}
+
+// Token returns a cached access token or requests a new one.
+func (s *credentialsTokenSource) Token() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := time.Now()
+	if len(s.token) > 0 && now.Add(time.Minute).Before(s.expiry) {
+		return s.token, nil
+	}
+	form := url.Values{}
+	if s.credentials.Type == CredentialsTypeServiceAccount {
+		assertion, err := signJWT(s.credentials, s.privateKey, s.scope, now)
+		if err != nil {
+			return "", err
+		}
+		form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+		form.Set("assertion", assertion)
+	} else {
+		form.Set("grant_type", "refresh_token")
+		form.Set("client_id", s.credentials.ClientID)
+		form.Set("client_secret", s.credentials.ClientSecret)
+		form.Set("refresh_token", s.credentials.RefreshToken)
+	}
+	response, err := s.httpClient.PostForm(s.credentials.TokenURI, form)
+	if err != nil {
+		return "", fmt.Errorf("error requesting access token: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error requesting access token: unexpected status code %d (%s) from %q", response.StatusCode, http.StatusText(response.StatusCode), s.credentials.TokenURI)
+	}
+	t := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+		TokenType   string `json:"token_type"`
+	}{}
+	err = json.NewDecoder(response.Body).Decode(&t)
+	if err != nil {
+		return "", fmt.Errorf("error decoding access token: %w", err)
+	}
+	if len(t.AccessToken) == 0 {
+		return "", fmt.Errorf("error requesting access token: response is missing access token")
+	}
+	if len(t.TokenType) > 0 && !strings.EqualFold(t.TokenType, "Bearer") {
+		return "", fmt.Errorf("error requesting access token: unsupported token type %q", t.TokenType)
+	}
+	s.token = t.AccessToken
+	s.expiry = now.Add(time.Duration(t.ExpiresIn) * time.Second)
+	return s.token, nil
+}
@@ -0,0 +1,274 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
+)
+
+// statusResumeIncomplete is the status code returned by the API when a resumable upload is not yet complete.
+const statusResumeIncomplete = 308
+
+var (
+	errWriterClosed = errors.New("writer is closed")
+)
+
+// Writer implements the io.WriteCloser interface to enable writing
+// an object to Google Cloud Storage using a resumable upload.
+// Each chunk is retried as set by the policy.
+// Before retrying, the writer asks the server how much of the object has been persisted,
+// so only the remaining bytes are sent again.
+type Writer struct {
+	client     *Client
+	bucket     string
+	object     string
+	sessionURI string
+	chunkSize  int
+	buffer     []byte // bytes that have not been persisted, starting at the offset
+	offset     int64  // number of bytes persisted by the server
+	uncertain  bool   // if true, then the number of bytes persisted is unknown
+	retry      *retry.Policy
+	ctx        context.Context
+	attrs      *ObjectAttrs
+	err        error
+	closed     bool
+}
+
+// Write implements the io.Writer interface.
+// Write uploads a chunk whenever the buffer is full.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errWriterClosed
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := 0
+	for len(p) > 0 {
+		m := w.chunkSize - len(w.buffer)
+		if m > len(p) {
+			m = len(p)
+		}
+		w.buffer = append(w.buffer, p[:m]...)
+		p = p[m:]
+		n += m
+		if len(w.buffer) == w.chunkSize {
+			err := w.flush(false)
+			if err != nil {
+				w.err = err
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Close uploads the remaining bytes and completes the upload.
+// If the upload failed, then Close cancels the upload and returns the error.
+func (w *Writer) Close() error {
+	if w.closed {
+		return errWriterClosed
+	}
+	w.closed = true
+	if w.err == nil {
+		w.err = w.flush(true)
+	}
+	if w.err != nil {
+		w.cancel()
+		return w.err
+	}
+	return nil
+}
+
+// CloseWithError cancels the upload with the error, instead of completing it.
+// Bytes that have been written, but not uploaded, are discarded.
+func (w *Writer) CloseWithError(err error) error {
+	if w.closed {
+		return errWriterClosed
+	}
+	w.closed = true
+	if w.err == nil {
+		w.err = err
+	}
+	w.cancel()
+	return nil
+}
+
+// Attrs returns the attributes of the object after the writer is closed.
+func (w *Writer) Attrs() *ObjectAttrs {
+	return w.attrs
+}
+
+// flush uploads the buffer, retrying as set by the policy.
+// If final is false, then only whole units of 256 KiB are uploaded.
+func (w *Writer) flush(final bool) error {
+	return w.retry.Do(w.ctx, func() error {
+		if w.uncertain {
+			err := w.status()
+			if err != nil {
+				return err
+			}
+			if w.attrs != nil {
+				return nil
+			}
+		}
+		for {
+			done, err := w.upload(final)
+			if err != nil || done {
+				return err
+			}
+		}
+	})
+}
+
+// upload sends the buffer to the server and returns true if no bytes remain to be sent.
+func (w *Writer) upload(final bool) (bool, error) {
+	n := len(w.buffer)
+	if !final {
+		n -= n % ChunkSizeUnit
+		if n == 0 {
+			return true, nil
+		}
+	}
+	contentRange := ""
+	if final {
+		total := w.offset + int64(n)
+		if n == 0 {
+			contentRange = fmt.Sprintf("bytes */%d", total)
+		} else {
+			contentRange = fmt.Sprintf("bytes %d-%d/%d", w.offset, total-1, total)
+		}
+	} else {
+		contentRange = fmt.Sprintf("bytes %d-%d/*", w.offset, w.offset+int64(n)-1)
+	}
+	request, err := http.NewRequestWithContext(w.ctx, http.MethodPut, w.sessionURI, bytes.NewReader(w.buffer[:n]))
+	if err != nil {
+		return false, fmt.Errorf("error creating request for object %q in bucket %q: %w", w.object, w.bucket, err)
+	}
+	request.Header.Set("Content-Range", contentRange)
+	response, err := w.client.do(request)
+	if err != nil {
+		w.uncertain = true
+		return false, fmt.Errorf("error uploading object %q in bucket %q: %w", w.object, w.bucket, err)
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		if !final {
+			return false, fmt.Errorf("error uploading object %q in bucket %q: upload completed before the final chunk", w.object, w.bucket)
+		}
+		return true, w.complete(response.Body)
+	case statusResumeIncomplete:
+		persisted, errRange := parseRange(response.Header.Get("Range"))
+		if errRange != nil {
+			return false, fmt.Errorf("error uploading object %q in bucket %q: %w", w.object, w.bucket, errRange)
+		}
+		if persisted == w.offset && final {
+			return false, fmt.Errorf("error uploading object %q in bucket %q: no bytes were persisted", w.object, w.bucket)
+		}
+		err = w.advance(persisted)
+		if err != nil {
+			return false, err
+		}
+		return !final, nil
+	}
+	w.uncertain = true
+	return false, fmt.Errorf("error uploading object %q in bucket %q: %w", w.object, w.bucket, errUnexpectedStatus(w.sessionURI, response.StatusCode))
+}
+
+// status asks the server how many bytes have been persisted and drops the persisted bytes from the buffer.
+func (w *Writer) status() error {
+	request, err := http.NewRequestWithContext(w.ctx, http.MethodPut, w.sessionURI, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request for object %q in bucket %q: %w", w.object, w.bucket, err)
+	}
+	request.Header.Set("Content-Range", "bytes */*")
+	response, err := w.client.do(request)
+	if err != nil {
+		return fmt.Errorf("error getting status of upload of object %q in bucket %q: %w", w.object, w.bucket, err)
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		w.uncertain = false
+		return w.complete(response.Body)
+	case statusResumeIncomplete:
+		persisted, errRange := parseRange(response.Header.Get("Range"))
+		if errRange != nil {
+			return fmt.Errorf("error getting status of upload of object %q in bucket %q: %w", w.object, w.bucket, errRange)
+		}
+		w.uncertain = false
+		return w.advance(persisted)
+	}
+	return fmt.Errorf("error getting status of upload of object %q in bucket %q: %w", w.object, w.bucket, errUnexpectedStatus(w.sessionURI, response.StatusCode))
+}
+
+// advance drops the bytes from the buffer that the server has persisted.
+func (w *Writer) advance(persisted int64) error {
+	skip := persisted - w.offset
+	if skip < 0 || skip > int64(len(w.buffer)) {
+		return fmt.Errorf("error uploading object %q in bucket %q: server persisted %d bytes, but only bytes %d to %d were sent", w.object, w.bucket, persisted, w.offset, w.offset+int64(len(w.buffer)))
+	}
+	w.buffer = append(w.buffer[:0], w.buffer[skip:]...)
+	w.offset = persisted
+	return nil
+}
+
+// complete parses the attributes of the uploaded object and clears the buffer.
+func (w *Writer) complete(body io.Reader) error {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("error reading attributes of object %q in bucket %q: %w", w.object, w.bucket, err)
+	}
+	attrs, err := parseObjectAttrs(b)
+	if err != nil {
+		return err
+	}
+	w.attrs = attrs
+	w.offset += int64(len(w.buffer))
+	w.buffer = w.buffer[:0]
+	return nil
+}
+
+// cancel attempts to cancel the upload, so that the object is not created.
+func (w *Writer) cancel() {
+	request, err := http.NewRequest(http.MethodDelete, w.sessionURI, nil)
+	if err != nil {
+		return
+	}
+	response, err := w.client.do(request)
+	if err != nil {
+		return
+	}
+	_ = response.Body.Close()
+}
+
+// parseRange parses the Range header of an incomplete upload and returns the number of bytes persisted.
+// If the header is blank, then no bytes have been persisted.
+func parseRange(str string) (int64, error) {
+	if len(str) == 0 {
+		return 0, nil
+	}
+	if !strings.HasPrefix(str, "bytes=0-") {
+		return 0, fmt.Errorf("invalid range %q", str)
+	}
+	end, err := strconv.ParseInt(strings.TrimPrefix(str, "bytes=0-"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid range %q: %w", str, err)
+	}
+	return end + 1, nil
+}
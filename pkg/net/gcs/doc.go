@@ -0,0 +1,12 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+// Package gcs provides functions for interacting with Google Cloud Storage using the JSON API.
+// Requests are authorized with OAuth 2.0 access tokens for a service account or an authorized user,
+// as created by "gcloud auth application-default login".
+// The endpoint can be overridden, such as for a local fake GCS server.
+package gcs
@@ -0,0 +1,63 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+const (
+	DefaultEndpoint  = "https://storage.googleapis.com"      // endpoint of the Google Cloud Storage JSON API
+	DefaultTokenURI  = "https://oauth2.googleapis.com/token" // endpoint for exchanging credentials for access tokens
+	DefaultChunkSize = 8 * 1024 * 1024                       // size of the chunks of resumable uploads
+	ChunkSizeUnit    = 256 * 1024                            // chunk sizes must be a multiple of 256 KiB
+	ScopeReadWrite   = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+const (
+	EnvEmulatorHost     = "STORAGE_EMULATOR_HOST"          // host of a GCS emulator, which disables authorization
+	EnvCredentials      = "GOOGLE_APPLICATION_CREDENTIALS" // path to a credentials JSON file
+	EnvOAuthAccessToken = "GOOGLE_OAUTH_ACCESS_TOKEN"      // OAuth 2.0 access token
+)
+
+// SplitPath splits the path of a gs:// uri into the bucket and object name.
+func SplitPath(p string) (string, string, error) {
+	i := strings.Index(p, "/")
+	if i == -1 {
+		return p, "", nil
+	}
+	if i == 0 {
+		return "", "", fmt.Errorf("error parsing path %q: path is missing bucket", p)
+	}
+	return p[0:i], p[i+1:], nil
+}
+
+// objectURL returns the url of the metadata of an object.
+func objectURL(endpoint string, bucket string, object string) string {
+	return fmt.Sprintf("%s/storage/v1/b/%s/o/%s", endpoint, url.PathEscape(bucket), url.PathEscape(object))
+}
+
+// bucketURL returns the url of the objects in a bucket.
+func bucketURL(endpoint string, bucket string) string {
+	return fmt.Sprintf("%s/storage/v1/b/%s/o", endpoint, url.PathEscape(bucket))
+}
+
+// uploadURL returns the url for initiating a resumable upload.
+func uploadURL(endpoint string, bucket string, object string) string {
+	return fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=resumable&name=%s", endpoint, url.PathEscape(bucket), url.QueryEscape(object))
+}
+
+// errUnexpectedStatus returns an error for a response with an unexpected status code.
+// The errors for 408, 429, and 5xx status codes are temporary, so the requests can be retried.
+func errUnexpectedStatus(uri string, statusCode int) error {
+	return &http.ErrUnexpectedStatus{URI: uri, StatusCode: statusCode}
+}
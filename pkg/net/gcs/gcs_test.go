@@ -0,0 +1,306 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcs_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs/gcstest"
+	pkghttp "github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+	"github.com/spatialcurrent/go-reader-writer/pkg/retry"
+)
+
+func testData(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + i%26)
+	}
+	return b
+}
+
+func writeObject(t *testing.T, input *gcs.NewWriterInput, data []byte) *gcs.ObjectAttrs {
+	w, err := gcs.NewWriter(input)
+	require.NoError(t, err)
+	// write in odd sizes, so writes cross chunk boundaries
+	for p := data; len(p) > 0; {
+		n := 100000
+		if n > len(p) {
+			n = len(p)
+		}
+		_, err = w.Write(p[:n])
+		require.NoError(t, err)
+		p = p[n:]
+	}
+	require.NoError(t, w.Close())
+	return w.Attrs()
+}
+
+func TestWriterAndGetObject(t *testing.T) {
+	server := gcstest.NewServer()
+	defer server.Close()
+	server.CreateBucket("bucket")
+	client := server.GCSClient()
+
+	data := testData(600 * 1024)
+	attrs := writeObject(t, &gcs.NewWriterInput{
+		Client:      client,
+		Bucket:      "bucket",
+		Object:      "a/b/c.txt",
+		ContentType: "text/plain",
+		Metadata:    map[string]string{"hello": "world"},
+		ChunkSize:   gcs.ChunkSizeUnit,
+	}, data)
+	require.NotNil(t, attrs)
+	assert.Equal(t, int64(len(data)), attrs.Size)
+	assert.Equal(t, "text/plain", attrs.ContentType)
+	assert.Equal(t, map[string]string{"hello": "world"}, attrs.Metadata)
+
+	got, ok := server.GetObject("bucket", "a/b/c.txt")
+	require.True(t, ok)
+	assert.Equal(t, data, got)
+
+	r, err := gcs.GetObject(&gcs.GetObjectInput{Client: client, Bucket: "bucket", Object: "a/b/c.txt"})
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, data, got)
+
+	r, err = gcs.GetObject(&gcs.GetObjectInput{Client: client, Bucket: "bucket", Object: "a/b/c.txt", Offset: 1000, Generation: attrs.Generation})
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, data[1000:], got)
+
+	r, err = gcs.GetObject(&gcs.GetObjectInput{Client: client, Bucket: "bucket", Object: "a/b/c.txt", Offset: int64(len(data))})
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = gcs.GetObject(&gcs.GetObjectInput{Client: client, Bucket: "bucket", Object: "a/b/c.txt", Generation: attrs.Generation + 1})
+	assert.True(t, errors.Is(err, pkghttp.ErrResourceChanged))
+}
+
+func TestWriterEmpty(t *testing.T) {
+	server := gcstest.NewServer()
+	defer server.Close()
+	server.CreateBucket("bucket")
+
+	attrs := writeObject(t, &gcs.NewWriterInput{Client: server.GCSClient(), Bucket: "bucket", Object: "empty"}, []byte{})
+	require.NotNil(t, attrs)
+	assert.Equal(t, int64(0), attrs.Size)
+}
+
+// failingTransport sends the first upload of a chunk to the server, but then responds with 503 Service Unavailable.
+type failingTransport struct {
+	failed bool
+}
+
+func (t *failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	response, err := http.DefaultTransport.RoundTrip(r)
+	if err != nil || t.failed || r.Method != http.MethodPut || r.ContentLength <= 0 {
+		return response, err
+	}
+	t.failed = true
+	_ = response.Body.Close()
+	return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Header: http.Header{}, Request: r}, nil
+}
+
+func TestWriterRetry(t *testing.T) {
+	server := gcstest.NewServer()
+	defer server.Close()
+	server.CreateBucket("bucket")
+
+	transport := &failingTransport{}
+	client := server.GCSClient()
+	client.HTTPClient = &http.Client{Transport: transport}
+
+	data := testData(700 * 1024)
+	attrs := writeObject(t, &gcs.NewWriterInput{
+		Client:    client,
+		Bucket:    "bucket",
+		Object:    "retry.txt",
+		ChunkSize: gcs.ChunkSizeUnit,
+		Retry:     &retry.Policy{Attempts: 3, BaseDelay: time.Millisecond},
+	}, data)
+	assert.True(t, transport.failed)
+	require.NotNil(t, attrs)
+	got, ok := server.GetObject("bucket", "retry.txt")
+	require.True(t, ok)
+	assert.Equal(t, data, got)
+}
+
+func TestStat(t *testing.T) {
+	server := gcstest.NewServer()
+	defer server.Close()
+	server.PutObject("bucket", "a/hello.txt", []byte("hello world"), "text/plain")
+	client := server.GCSClient()
+
+	exists, attrs, err := gcs.Stat(context.Background(), client, "bucket", "a/hello.txt")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NotNil(t, attrs)
+	assert.Equal(t, "a/hello.txt", attrs.Name)
+	assert.Equal(t, int64(11), attrs.Size)
+	assert.Equal(t, "text/plain", attrs.ContentType)
+	assert.NotEmpty(t, attrs.ETag)
+	assert.True(t, attrs.Generation > 0)
+	assert.False(t, attrs.Updated.IsZero())
+
+	exists, attrs, err = gcs.Stat(context.Background(), client, "bucket", "missing.txt")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.Nil(t, attrs)
+}
+
+func TestList(t *testing.T) {
+	server := gcstest.NewServer()
+	defer server.Close()
+	for _, name := range []string{"a/1", "a/2", "a/b/3", "c"} {
+		server.PutObject("bucket", name, []byte(name), "")
+	}
+	client := server.GCSClient()
+
+	output, err := gcs.List(context.Background(), &gcs.ListInput{Client: client, Bucket: "bucket", Prefix: "a/", Delimiter: "/"})
+	require.NoError(t, err)
+	names := []string{}
+	for _, o := range output.Objects {
+		names = append(names, o.Name)
+	}
+	assert.Equal(t, []string{"a/1", "a/2"}, names)
+	assert.Equal(t, []string{"a/b/"}, output.Prefixes)
+	assert.Empty(t, output.NextPageToken)
+
+	names = []string{}
+	pageToken := ""
+	for {
+		output, err = gcs.List(context.Background(), &gcs.ListInput{Client: client, Bucket: "bucket", MaxResults: 3, PageToken: pageToken})
+		require.NoError(t, err)
+		for _, o := range output.Objects {
+			names = append(names, o.Name)
+		}
+		if len(output.NextPageToken) == 0 {
+			break
+		}
+		pageToken = output.NextPageToken
+	}
+	assert.Equal(t, []string{"a/1", "a/2", "a/b/3", "c"}, names)
+}
+
+func TestNewClientServiceAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	requests := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		require.Len(t, parts, 3)
+		signature, errDecode := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, errDecode)
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if errVerify := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature); errVerify != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		claims, errDecode := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, errDecode)
+		assert.Contains(t, string(claims), `"iss":"grw@example.iam.gserviceaccount.com"`)
+		assert.Contains(t, string(claims), gcs.ScopeReadWrite)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "secret", "expires_in": 3600, "token_type": "Bearer"})
+	}))
+	defer tokenServer.Close()
+
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "grw@example.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})),
+		"token_uri":    tokenServer.URL,
+	})
+	require.NoError(t, err)
+
+	server := gcstest.NewServer()
+	defer server.Close()
+	server.Token = "secret"
+	server.PutObject("bucket", "hello.txt", []byte("hello world"), "text/plain")
+
+	client, err := gcs.NewClient(&gcs.NewClientInput{Endpoint: server.URL, Credentials: credentials})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		exists, _, errStat := gcs.Stat(context.Background(), client, "bucket", "hello.txt")
+		assert.NoError(t, errStat)
+		assert.True(t, exists)
+	}
+	// the access token is cached
+	assert.Equal(t, 1, requests)
+
+	_, _, err = gcs.Stat(context.Background(), server.GCSClient(), "bucket", "hello.txt")
+	assert.NoError(t, err)
+	server.Token = "other"
+	_, _, err = gcs.Stat(context.Background(), client, "bucket", "hello.txt")
+	assert.Error(t, err)
+}
+
+func TestNewClientEmulatorHost(t *testing.T) {
+	t.Setenv(gcs.EnvEmulatorHost, "localhost:4443")
+	client, err := gcs.NewClient(&gcs.NewClientInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:4443", client.Endpoint)
+	assert.Nil(t, client.TokenSource)
+
+	client, err = gcs.NewClient(&gcs.NewClientInput{Endpoint: "https://example.com/", AccessToken: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", client.Endpoint)
+	token, err := client.TokenSource.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", token)
+}
+
+func TestParseCredentials(t *testing.T) {
+	_, err := gcs.ParseCredentials([]byte(`{"type":"external_account"}`))
+	assert.Error(t, err)
+	_, err = gcs.ParseCredentials([]byte(`{"type":"service_account","client_email":"a@b.c"}`))
+	assert.Error(t, err)
+	c, err := gcs.ParseCredentials([]byte(`{"type":"authorized_user","client_id":"a","client_secret":"b","refresh_token":"c"}`))
+	require.NoError(t, err)
+	assert.Equal(t, gcs.DefaultTokenURI, c.TokenURI)
+}
+
+func TestSplitPath(t *testing.T) {
+	bucket, object, err := gcs.SplitPath("bucket/a/b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "bucket", bucket)
+	assert.Equal(t, "a/b.txt", object)
+	_, _, err = gcs.SplitPath("/a/b.txt")
+	assert.Error(t, err)
+}
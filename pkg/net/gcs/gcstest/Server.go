@@ -0,0 +1,355 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package gcstest
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/gcs"
+)
+
+// object is an object stored by the server.
+type object struct {
+	attrs *gcs.ObjectAttrs
+	data  []byte
+}
+
+// upload is a resumable upload in progress.
+type upload struct {
+	attrs *gcs.ObjectAttrs
+	data  []byte
+}
+
+// Server is a fake Google Cloud Storage server that stores objects in memory.
+// The server supports getting, listing, and deleting objects, ranged reads, and resumable uploads.
+type Server struct {
+	*httptest.Server
+	Token      string // if set, then requests must be authorized with this bearer token
+	mutex      *sync.Mutex
+	objects    map[string]map[string]*object
+	uploads    map[string]*upload
+	generation int64
+}
+
+// NewServer starts and returns a new Server.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		mutex:   &sync.Mutex{},
+		objects: map[string]map[string]*object{},
+		uploads: map[string]*upload{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// GCSClient returns a client for the server.
+func (s *Server) GCSClient() *gcs.Client {
+	client := &gcs.Client{Endpoint: s.URL}
+	if len(s.Token) > 0 {
+		client.TokenSource = gcs.StaticTokenSource(s.Token)
+	}
+	return client
+}
+
+// CreateBucket creates the bucket, if it does not exist.
+func (s *Server) CreateBucket(bucket string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.objects[bucket]; !ok {
+		s.objects[bucket] = map[string]*object{}
+	}
+}
+
+// PutObject stores the object in the bucket, creating the bucket if it does not exist.
+func (s *Server) PutObject(bucket string, name string, data []byte, contentType string) *gcs.ObjectAttrs {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.putObject(&gcs.ObjectAttrs{Bucket: bucket, Name: name, ContentType: contentType}, data)
+}
+
+// GetObject returns the contents of the object in the bucket and true, or false if the object does not exist.
+func (s *Server) GetObject(bucket string, name string) ([]byte, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	o, ok := s.objects[bucket][name]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte{}, o.data...), true
+}
+
+// Uploads returns the number of resumable uploads that have been started, but not completed or cancelled.
+func (s *Server) Uploads() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.uploads)
+}
+
+func (s *Server) putObject(attrs *gcs.ObjectAttrs, data []byte) *gcs.ObjectAttrs {
+	if _, ok := s.objects[attrs.Bucket]; !ok {
+		s.objects[attrs.Bucket] = map[string]*object{}
+	}
+	s.generation++
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	hash := md5.Sum(data)
+	a := *attrs
+	a.Size = int64(len(data))
+	a.Generation = s.generation
+	a.Metageneration = 1
+	a.ETag = fmt.Sprintf("\"%x\"", hash)
+	a.MD5Hash = base64.StdEncoding.EncodeToString(hash[:])
+	a.StorageClass = "STANDARD"
+	a.Created = now
+	a.Updated = now
+	s.objects[attrs.Bucket][attrs.Name] = &object{attrs: &a, data: data}
+	return &a
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{"code": statusCode, "message": http.StatusText(statusCode)},
+	})
+}
+
+// splitPath splits the escaped path into unescaped segments.
+func splitPath(p string) []string {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	for i, part := range parts {
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			parts[i] = unescaped
+		}
+	}
+	return parts
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(s.Token) > 0 && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized)
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	parts := splitPath(r.URL.EscapedPath())
+	switch {
+	case len(parts) == 5 && parts[0] == "storage" && parts[1] == "v1" && parts[2] == "b" && parts[4] == "o" && r.Method == http.MethodGet:
+		s.list(w, r, parts[3])
+	case len(parts) == 6 && parts[0] == "storage" && parts[1] == "v1" && parts[2] == "b" && parts[4] == "o":
+		s.serveObject(w, r, parts[3], parts[5])
+	case len(parts) == 6 && parts[0] == "upload" && parts[1] == "storage" && parts[2] == "v1" && parts[3] == "b" && parts[5] == "o" && r.Method == http.MethodPost:
+		s.startUpload(w, r, parts[4])
+	case len(parts) == 2 && parts[0] == "upload" && parts[1] != "":
+		s.serveUpload(w, r, parts[1])
+	default:
+		writeError(w, http.StatusNotFound)
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, bucket string) {
+	objects, ok := s.objects[bucket]
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	query := r.URL.Query()
+	prefix, delimiter, pageToken := query.Get("prefix"), query.Get("delimiter"), query.Get("pageToken")
+	maxResults, _ := strconv.Atoi(query.Get("maxResults"))
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	items := []*gcs.ObjectAttrs{}
+	prefixes := []string{}
+	seen := map[string]bool{}
+	nextPageToken := ""
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) || name <= pageToken {
+			continue
+		}
+		if maxResults > 0 && len(items)+len(prefixes) == maxResults {
+			nextPageToken = name
+			break
+		}
+		if len(delimiter) > 0 {
+			if i := strings.Index(name[len(prefix):], delimiter); i != -1 {
+				p := name[0 : len(prefix)+i+len(delimiter)]
+				if !seen[p] {
+					seen[p] = true
+					prefixes = append(prefixes, p)
+				}
+				continue
+			}
+		}
+		items = append(items, objects[name].attrs)
+	}
+	if nextPageToken != "" {
+		// continue after the last name on this page
+		i := sort.SearchStrings(names, nextPageToken)
+		nextPageToken = names[i-1]
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":          "storage#objects",
+		"items":         items,
+		"prefixes":      prefixes,
+		"nextPageToken": nextPageToken,
+	})
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, bucket string, name string) {
+	o, ok := s.objects[bucket][name]
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	if g := r.URL.Query().Get("ifGenerationMatch"); len(g) > 0 && g != strconv.FormatInt(o.attrs.Generation, 10) {
+		writeError(w, http.StatusPreconditionFailed)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if r.URL.Query().Get("alt") != "media" {
+			writeJSON(w, http.StatusOK, o.attrs)
+			return
+		}
+		w.Header().Set("Content-Type", o.attrs.ContentType)
+		w.Header().Set("ETag", o.attrs.ETag)
+		w.Header().Set("X-Goog-Generation", strconv.FormatInt(o.attrs.Generation, 10))
+		if rng := r.Header.Get("Range"); strings.HasPrefix(rng, "bytes=") && strings.HasSuffix(rng, "-") {
+			start, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"), 10, 64)
+			if err != nil {
+				writeError(w, http.StatusBadRequest)
+				return
+			}
+			if start >= int64(len(o.data)) {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(o.data)))
+				writeError(w, http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(o.data)-1, len(o.data)))
+			w.Header().Set("Content-Length", strconv.FormatInt(int64(len(o.data))-start, 10))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(o.data[start:])
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(o.data)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(o.data)
+	case http.MethodDelete:
+		delete(s.objects[bucket], name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) startUpload(w http.ResponseWriter, r *http.Request, bucket string) {
+	if r.URL.Query().Get("uploadType") != "resumable" {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	attrs := &gcs.ObjectAttrs{}
+	if err := json.NewDecoder(r.Body).Decode(attrs); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	attrs.Bucket = bucket
+	if name := r.URL.Query().Get("name"); len(name) > 0 {
+		attrs.Name = name
+	}
+	if len(attrs.Name) == 0 {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	if _, ok := s.objects[bucket]; !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	id := strconv.Itoa(len(s.uploads) + 1)
+	s.uploads[id] = &upload{attrs: attrs}
+	w.Header().Set("Location", s.URL+"/upload/"+id)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request, id string) {
+	u, ok := s.uploads[id]
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		delete(s.uploads, id)
+		w.WriteHeader(499)
+		return
+	}
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	// Content-Range is "bytes start-end/total", "bytes start-end/*", "bytes */total", or "bytes */*".
+	contentRange := strings.TrimPrefix(r.Header.Get("Content-Range"), "bytes ")
+	i := strings.Index(contentRange, "/")
+	if i == -1 {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	byteRange, total := contentRange[0:i], contentRange[i+1:]
+	if byteRange != "*" {
+		j := strings.Index(byteRange, "-")
+		if j == -1 {
+			writeError(w, http.StatusBadRequest)
+			return
+		}
+		start, errStart := strconv.Atoi(byteRange[0:j])
+		if errStart != nil || start > len(u.data) {
+			writeError(w, http.StatusBadRequest)
+			return
+		}
+		// bytes before the end of the persisted data are ignored
+		if start+len(body) > len(u.data) {
+			u.data = append(u.data, body[len(u.data)-start:]...)
+		}
+	}
+	if total != "*" {
+		n, errTotal := strconv.Atoi(total)
+		if errTotal != nil || n != len(u.data) {
+			writeError(w, http.StatusBadRequest)
+			return
+		}
+		delete(s.uploads, id)
+		writeJSON(w, http.StatusOK, s.putObject(u.attrs, u.data))
+		return
+	}
+	if len(u.data) > 0 {
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(u.data)-1))
+	}
+	w.WriteHeader(308)
+}
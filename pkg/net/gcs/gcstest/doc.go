@@ -0,0 +1,9 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+// Package gcstest provides an in-memory fake of the Google Cloud Storage JSON API for testing.
+package gcstest
@@ -0,0 +1,46 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Delete sends a DELETE request for the resource at the given HTTP address.
+//
+// Delete returns an error if the address cannot be reached,
+// the userinfo cannot be parsed,
+// the user and password are invalid, or
+// the server responds with a status code other than 2xx.
+func Delete(ctx context.Context, uri string, options ...ClientOption) error {
+
+	client, err := NewClient(options...)
+	if err != nil {
+		return err
+	}
+
+	request, err := NewRequest(http.MethodDelete, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	response, errDo := client.Do(request.WithContext(ctx))
+	if errDo != nil {
+		return fmt.Errorf("error deleting resource at uri %q: %w", uri, errDo)
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return &ErrUnexpectedStatus{URI: uri, StatusCode: response.StatusCode}
+	}
+
+	return nil
+
+}
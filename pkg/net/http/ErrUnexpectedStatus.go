@@ -0,0 +1,34 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ErrUnexpectedStatus is returned when a HTTP server responds with an unexpected status code.
+type ErrUnexpectedStatus struct {
+	URI        string
+	StatusCode int
+}
+
+// Error returns the error as a string.
+func (e *ErrUnexpectedStatus) Error() string {
+	return fmt.Sprintf("unexpected status code %d (%s) for uri %q", e.StatusCode, http.StatusText(e.StatusCode), e.URI)
+}
+
+// Temporary returns true if the request could succeed if sent again.
+// Request timeouts, rate limiting, and server errors are considered temporary.
+func (e *ErrUnexpectedStatus) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	}
+	return e.StatusCode >= 500 && e.StatusCode != http.StatusNotImplemented && e.StatusCode != http.StatusHTTPVersionNotSupported
+}
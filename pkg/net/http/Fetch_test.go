@@ -8,11 +8,14 @@
 package http
 
 import (
+	"bytes"
 	"crypto/tls"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -63,3 +66,76 @@ func TestFetchHTTPSWithOption(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestFetchFromRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "doc.txt", time.Time{}, bytes.NewReader([]byte("hello world")))
+	}))
+	defer server.Close()
+	r, err := FetchFrom(server.URL+"/doc.txt", 6)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	got, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("world"), got)
+	err = r.Close()
+	assert.NoError(t, err)
+}
+
+func TestFetchFromRangeNotSupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer server.Close()
+	r, err := FetchFrom(server.URL+"/doc.txt", 6)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	got, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("world"), got)
+	err = r.Close()
+	assert.NoError(t, err)
+}
+
+func TestFetchUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	r, err := Fetch(server.URL + "/missing")
+	require.Nil(t, r)
+	require.IsType(t, &ErrUnexpectedStatus{}, err)
+	assert.Equal(t, http.StatusNotFound, err.(*ErrUnexpectedStatus).StatusCode)
+	assert.False(t, err.(*ErrUnexpectedStatus).Temporary())
+
+	r, err = Fetch(server.URL + "/unavailable")
+	require.Nil(t, r)
+	require.IsType(t, &ErrUnexpectedStatus{}, err)
+	assert.True(t, err.(*ErrUnexpectedStatus).Temporary())
+}
+
+func TestFetchIdentityEncoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "identity" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer server.Close()
+	r, err := Fetch(server.URL + "/doc.txt.gz")
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	got, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello world"), got)
+	err = r.Close()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,123 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetInput contains the input parameters for Get.
+type GetInput struct {
+	URI     string          // uri of the object
+	Offset  int64           // byte offset to start reading from
+	IfRange string          // ETag or Last-Modified date the object must match when reading from an offset
+	Options []ClientOption  // options for the HTTP client
+	Context context.Context // context for the request, defaults to context.Background()
+}
+
+// Get sends a GET request for an object at the given HTTP address and returns the response.
+// If the offset is greater than zero, then Get sends a range request.
+// If the server does not support range requests, then the bytes before the offset are discarded from the body.
+// If the offset is equal to the size of the object, then the body of the response is empty.
+// Get requests the identity encoding, so the body is returned as stored, even if the Content-Encoding is gzip.
+//
+// If the IfRange validator is set and the object no longer matches the validator,
+// then Get returns ErrResourceChanged.
+//
+// Get returns an error if the address cannot be reached,
+// the userinfo cannot be parsed,
+// the user and password are invalid,
+// the file cannot be retrieved, or
+// the server responds with a status code other than 2xx.
+func Get(input *GetInput) (*http.Response, error) {
+
+	uri := input.URI
+
+	client, err := NewClient(input.Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Context != nil {
+		request = request.WithContext(input.Context)
+	}
+
+	// request the object as stored, so the transport does not decompress the body,
+	// which would change the offsets and the ETag used to resume reading.
+	request.Header.Set("Accept-Encoding", "identity")
+
+	if input.Offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", input.Offset))
+		if len(input.IfRange) > 0 {
+			request.Header.Set("If-Range", input.IfRange)
+		}
+	}
+
+	response, errDo := client.Do(request)
+	if errDo != nil {
+		return nil, fmt.Errorf("error reading file from uri %q: %w", uri, errDo)
+	}
+
+	if response == nil {
+		return nil, fmt.Errorf("error reading file from uri %q: response is empty", uri)
+	}
+
+	if input.Offset > 0 && response.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// the offset is at or beyond the end of the object
+		if size, ok := parseContentRangeSize(response.Header.Get("Content-Range")); ok && size == input.Offset {
+			_ = response.Body.Close()
+			response.Body = http.NoBody
+			response.ContentLength = 0
+			return response, nil
+		}
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		_ = response.Body.Close()
+		return nil, &ErrUnexpectedStatus{URI: uri, StatusCode: response.StatusCode}
+	}
+
+	if input.Offset > 0 && response.StatusCode != http.StatusPartialContent {
+		if len(input.IfRange) > 0 {
+			// the server sent the entire object, since the object no longer matches the validator.
+			_ = response.Body.Close()
+			return nil, fmt.Errorf("error reading file from uri %q: %w", uri, ErrResourceChanged)
+		}
+		_, errDiscard := io.CopyN(io.Discard, response.Body, input.Offset)
+		if errDiscard != nil {
+			_ = response.Body.Close()
+			return nil, fmt.Errorf("error discarding %d bytes from uri %q: %w", input.Offset, uri, errDiscard)
+		}
+	}
+
+	return response, nil
+
+}
+
+// parseContentRangeSize returns the complete length from a Content-Range header, e.g., "bytes */1234".
+func parseContentRangeSize(contentRange string) (int64, bool) {
+	i := strings.LastIndex(contentRange, "/")
+	if i == -1 {
+		return 0, false
+	}
+	size, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return size, true
+}
@@ -0,0 +1,47 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Head sends a HEAD request for an object at the given HTTP address and returns the response.
+// The body of the response is empty.
+//
+// Head returns an error if the address cannot be reached,
+// the userinfo cannot be parsed,
+// the user and password are invalid, or
+// the server responds with a status code other than 2xx.
+func Head(ctx context.Context, uri string, options ...ClientOption) (*http.Response, error) {
+
+	client, err := NewClient(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := NewRequest(http.MethodHead, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, errDo := client.Do(request.WithContext(ctx))
+	if errDo != nil {
+		return nil, fmt.Errorf("error requesting headers for uri %q: %w", uri, errDo)
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, &ErrUnexpectedStatus{URI: uri, StatusCode: response.StatusCode}
+	}
+
+	return response, nil
+
+}
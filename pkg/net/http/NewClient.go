@@ -0,0 +1,24 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package http
+
+import (
+	"fmt"
+)
+
+// NewClient returns a new Client after running the given options.
+func NewClient(options ...ClientOption) (*Client, error) {
+	client := &Client{}
+	for i, option := range options {
+		err := option(client)
+		if err != nil {
+			return nil, fmt.Errorf("error running client option %d: %w", i, err)
+		}
+	}
+	return client, nil
+}
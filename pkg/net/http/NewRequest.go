@@ -0,0 +1,61 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+)
+
+// NewRequest returns a new request for the given method and HTTP address.
+// If the address includes a user and password, then the request uses basic authentication.
+// The user info is removed from the URL of the request.
+//
+// NewRequest returns an error if the scheme is not http or https,
+// the userinfo cannot be parsed, or
+// the request cannot be created.
+func NewRequest(method string, uri string, body io.Reader) (*http.Request, error) {
+
+	scheme, fullpath := splitter.SplitURI(uri)
+
+	if scheme != "http" && scheme != "https" {
+		return nil, fmt.Errorf("error creating request for uri %q: only schemes http and https are supported", uri)
+	}
+
+	authority, p := splitPath(fullpath)
+
+	userinfo, host, port := splitter.SplitAuthority(authority)
+	if len(port) == 0 {
+		if scheme == "https" {
+			port = strconv.Itoa(DefaultPortHTTPS)
+		} else {
+			port = strconv.Itoa(DefaultPortHTTP)
+		}
+	}
+
+	request, errNewRequest := http.NewRequest(method, fmt.Sprintf("%s://%s:%s%s", scheme, host, port, p), body)
+	if errNewRequest != nil {
+		return nil, fmt.Errorf("error creating new requestf for %q: %w", fmt.Sprintf("%s://%s:%s%s", scheme, host, port, p), errNewRequest)
+	}
+
+	if len(userinfo) > 0 {
+		user, password, errSplitUserInfo := splitter.SplitUserInfo(userinfo)
+		if errSplitUserInfo != nil {
+			return nil, fmt.Errorf("error parsing user info %q: %w", userinfo, errSplitUserInfo)
+		}
+		if len(user) > 0 && len(password) > 0 {
+			request.SetBasicAuth(user, password)
+		}
+	}
+
+	return request, nil
+}
@@ -0,0 +1,29 @@
+// =================================================================
+//
+// Copyright (C) 2020 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package http
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	DefaultPortHTTP  = 80
+	DefaultPortHTTPS = 443
+)
+
+var (
+	ErrResourceChanged = errors.New("resource changed since the validator was issued")
+)
+
+func splitPath(str string) (string, string) {
+	if i := strings.Index(str, "/"); i != -1 {
+		return str[0:i], str[i:]
+	}
+	return str, ""
+}
@@ -0,0 +1,62 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package sftp2
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/pkg/sftp"
+)
+
+// MkdirAll creates a directory on a SFTP server named p, along with any necessary parents, and returns nil, or else returns an error.
+// If mode is not zero, then the mode of the directories that MkdirAll creates is changed to mode,
+// since the SFTP server creates directories with its own default mode.
+// If p is already a directory, MkdirAll does nothing and returns nil.
+//
+//  - https://pkg.go.dev/github.com/pkg/sftp#Client.MkdirAll
+func MkdirAll(client *sftp.Client, p string, mode os.FileMode) error {
+	// find the directories that do not exist, starting from the deepest
+	missing := make([]string, 0)
+	for dir := p; ; {
+		exists, _, err := Stat(client, dir)
+		if err != nil {
+			return fmt.Errorf("error stating directory %q: %w", dir, err)
+		}
+		if exists {
+			break
+		}
+		missing = append(missing, dir)
+		parent := path.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	err := client.MkdirAll(p)
+	if err != nil {
+		return fmt.Errorf("error creating directory %q: %w", p, err)
+	}
+
+	if mode != 0 {
+		for i := len(missing) - 1; i >= 0; i-- {
+			errChmod := client.Chmod(missing[i], mode)
+			if errChmod != nil {
+				return fmt.Errorf("error changing mode of directory %q: %w", missing[i], errChmod)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package sftp2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+func TestMkdirAll(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	sshClient, sftpClient, err := dialSession(server.URI(ssh2.SchemeSFTP, ""), serverOption(server))
+	require.NoError(t, err)
+	defer sshClient.Close()
+	defer sftpClient.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0755))
+
+	p := filepath.Join(dir, "a", "b", "c")
+	require.NoError(t, MkdirAll(sftpClient, p, 0750))
+
+	for _, d := range []string{filepath.Join(dir, "a"), filepath.Join(dir, "a", "b"), p} {
+		fi, errStat := os.Stat(d)
+		require.NoError(t, errStat)
+		assert.True(t, fi.IsDir())
+		assert.Equal(t, os.FileMode(0750), fi.Mode().Perm(), d)
+	}
+
+	// existing directories are not changed
+	fi, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), fi.Mode().Perm())
+	assert.NoError(t, MkdirAll(sftpClient, p, 0700))
+	fi, err = os.Stat(p)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0750), fi.Mode().Perm())
+
+	// a file in the path is an error
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), []byte("hello"), 0600))
+	assert.Error(t, MkdirAll(sftpClient, filepath.Join(dir, "file", "d"), 0750))
+}
@@ -0,0 +1,322 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package sftp2
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+)
+
+// NewPoolInput contains the input parameters for NewPool.
+type NewPoolInput struct {
+	IdleTimeout time.Duration // sessions that are not used for this long are closed, defaults to DefaultIdleTimeout
+	MaxSessions int           // maximum number of open sessions, if zero then the number of sessions is not limited
+}
+
+// Pool is a pool of SFTP sessions that are shared by uris with the same user, host, and port.
+// Each session is a SSH connection with a SFTP client, which is safe for concurrent use.
+// The pool is safe for concurrent use.
+type Pool struct {
+	idleTimeout time.Duration
+	maxSessions int
+	mutex       *sync.Mutex
+	cond        *sync.Cond
+	sessions    map[string]*poolSession       // open sessions and sessions being dialed by key
+	clients     map[*sftp.Client]*poolSession // sessions by SFTP client, until all the references are released
+	open        int                           // number of open sessions and sessions being dialed
+	closed      bool
+}
+
+// poolSession is a SSH connection with a SFTP client in a pool.
+type poolSession struct {
+	key        string
+	sshClient  *ssh.Client
+	sftpClient *sftp.Client // nil while the session is being dialed
+	refs       int          // number of times the SFTP client was returned by Get and not yet released
+	checking   bool         // true while the idle session is being checked before it is returned
+	lastUsed   time.Time
+	timer      *time.Timer // closes the session once idle for the idle timeout
+	closed     bool
+}
+
+// NewPool returns a new Pool.
+// The caller should call Close when finished, to close the sessions that are still open.
+func NewPool(input *NewPoolInput) *Pool {
+	idleTimeout := input.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+	mutex := &sync.Mutex{}
+	return &Pool{
+		idleTimeout: idleTimeout,
+		maxSessions: input.MaxSessions,
+		mutex:       mutex,
+		cond:        sync.NewCond(mutex),
+		sessions:    map[string]*poolSession{},
+		clients:     map[*sftp.Client]*poolSession{},
+	}
+}
+
+// Get returns the SFTP client of the session for the user, host, and port in the SFTP uri.
+// If there is no session for the uri, then a new session is dialed with ssh2.Dial using the options.
+// The options are ignored if the session is already open, so uris with the same authority should use the same options.
+//
+// Before a session that is not in use is returned, it is checked with a request to the server,
+// and if the check fails, then the session is closed and a new session is dialed.
+// Other calls for the session wait until the check finishes.
+// If the pool already has the maximum number of sessions,
+// then the session unused for the longest time is closed,
+// or if every session is in use, Get waits until a session is released.
+//
+// Every client returned by Get must be released with Release, and must not be closed by the caller.
+func (p *Pool) Get(uri string, options ...ssh2.ClientOption) (*sftp.Client, error) {
+	key, err := poolKey(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	for {
+		if p.closed {
+			p.mutex.Unlock()
+			return nil, ErrPoolClosed
+		}
+		if s, ok := p.sessions[key]; ok {
+			if s.sftpClient == nil || s.checking {
+				// wait for the session being dialed or checked by another call
+				p.cond.Wait()
+				continue
+			}
+			s.refs++
+			if s.refs > 1 {
+				p.mutex.Unlock()
+				return s.sftpClient, nil
+			}
+			// the session was idle, so check that it still works before returning it
+			s.timer.Stop()
+			s.checking = true
+			p.mutex.Unlock()
+			_, errCheck := s.sftpClient.Getwd()
+			p.mutex.Lock()
+			s.checking = false
+			p.cond.Broadcast()
+			if errCheck == nil {
+				p.mutex.Unlock()
+				return s.sftpClient, nil
+			}
+			_ = p.remove(s)
+			s.refs--
+			if s.refs == 0 {
+				delete(p.clients, s.sftpClient)
+			}
+			continue
+		}
+		if p.maxSessions > 0 && p.open >= p.maxSessions {
+			if s := p.leastRecentlyUsed(); s != nil {
+				_ = p.remove(s)
+				continue
+			}
+			p.cond.Wait()
+			continue
+		}
+		break
+	}
+	// reserve the session, so other calls for the same key wait for it
+	s := &poolSession{key: key, refs: 1}
+	p.sessions[key] = s
+	p.open++
+	p.mutex.Unlock()
+
+	sshClient, sftpClient, err := dialSession(uri, options...)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	defer p.cond.Broadcast()
+	if err != nil {
+		delete(p.sessions, key)
+		p.open--
+		return nil, err
+	}
+	s.sshClient, s.sftpClient = sshClient, sftpClient
+	p.clients[sftpClient] = s
+	if p.closed {
+		s.refs--
+		_ = p.remove(s)
+		return nil, ErrPoolClosed
+	}
+	go p.watch(s)
+	return sftpClient, nil
+}
+
+// Release releases a SFTP client returned by Get.
+// Once all the references to a session are released, the session is closed after the idle timeout, unless used again.
+func (p *Pool) Release(client *sftp.Client) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	s, ok := p.clients[client]
+	if !ok || s.refs == 0 {
+		return errors.New("SFTP client was not returned by the pool or was already released")
+	}
+	s.refs--
+	if s.refs > 0 {
+		return nil
+	}
+	if s.closed {
+		delete(p.clients, client)
+		return nil
+	}
+	s.lastUsed = time.Now()
+	if s.timer == nil {
+		s.timer = time.AfterFunc(p.idleTimeout, func() { p.expire(s) })
+	} else {
+		s.timer.Reset(p.idleTimeout)
+	}
+	// a call waiting for a free session can now close this session
+	p.cond.Broadcast()
+	return nil
+}
+
+// Len returns the number of open sessions.
+func (p *Pool) Len() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	n := 0
+	for _, s := range p.sessions {
+		if s.sftpClient != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Close closes all the sessions, including the sessions in use, and returns the first error, if any.
+// After the pool is closed, Get returns ErrPoolClosed.
+func (p *Pool) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closed = true
+	var err error
+	for _, s := range p.sessions {
+		if s.sftpClient == nil {
+			// the call dialing the session closes it
+			continue
+		}
+		if errRemove := p.remove(s); errRemove != nil && err == nil {
+			err = errRemove
+		}
+	}
+	p.cond.Broadcast()
+	return err
+}
+
+// expire closes the session if it is still idle after the idle timeout.
+func (p *Pool) expire(s *poolSession) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if s.closed || s.refs > 0 || time.Since(s.lastUsed) < p.idleTimeout {
+		return
+	}
+	_ = p.remove(s)
+	p.cond.Broadcast()
+}
+
+// watch removes the session from the pool once the SSH connection is closed, such as by the server.
+func (p *Pool) watch(s *poolSession) {
+	_ = s.sshClient.Wait()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !s.closed {
+		_ = p.remove(s)
+		p.cond.Broadcast()
+	}
+}
+
+// leastRecentlyUsed returns the idle session that was released the longest time ago, or nil if every session is in use.
+func (p *Pool) leastRecentlyUsed() *poolSession {
+	var oldest *poolSession
+	for _, s := range p.sessions {
+		if s.sftpClient == nil || s.refs > 0 {
+			continue
+		}
+		if oldest == nil || s.lastUsed.Before(oldest.lastUsed) {
+			oldest = s
+		}
+	}
+	return oldest
+}
+
+// remove removes the session from the pool and closes the SFTP client and SSH connection.
+// The session stays known to Release until all the references are released.
+// The caller must hold the mutex.
+func (p *Pool) remove(s *poolSession) error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if p.sessions[s.key] == s {
+		delete(p.sessions, s.key)
+	}
+	if s.refs == 0 {
+		delete(p.clients, s.sftpClient)
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	p.open--
+	errSFTP := s.sftpClient.Close()
+	errSSH := s.sshClient.Close()
+	if errSFTP != nil {
+		return fmt.Errorf("error closing SFTP client connection: %w", errSFTP)
+	}
+	if errSSH != nil && !errors.Is(errSSH, net.ErrClosed) {
+		return fmt.Errorf("error closing SSH client connection: %w", errSSH)
+	}
+	return nil
+}
+
+// poolKey returns the key of the session for the uri, which is the user, host, and port as written in the uri.
+func poolKey(uri string) (string, error) {
+	scheme, fullpath := splitter.SplitURI(uri)
+	if scheme != ssh2.SchemeSFTP {
+		return "", fmt.Errorf("error getting SFTP client for %q: unknown scheme %q", uri, scheme)
+	}
+	userinfo, host, port := splitter.SplitAuthority(strings.SplitN(fullpath, "/", 2)[0])
+	user := ""
+	if len(userinfo) > 0 {
+		u, _, err := splitter.SplitUserInfo(userinfo)
+		if err != nil {
+			return "", fmt.Errorf("error parsing user info %q: %w", userinfo, err)
+		}
+		user = u
+	}
+	return user + "@" + host + ":" + port, nil
+}
+
+// dialSession dials the SSH server at the uri and starts a SFTP client that sends the requests for large writes concurrently.
+func dialSession(uri string, options ...ssh2.ClientOption) (*ssh.Client, *sftp.Client, error) {
+	sshClient, err := ssh2.Dial(uri, options...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating SSH client: %w", err)
+	}
+	sftpClient, err := sftp.NewClient(sshClient.Client, sftp.UseConcurrentWrites(true))
+	if err != nil {
+		_ = sshClient.Close() // attempt to close the underlying SSH connection
+		return nil, nil, fmt.Errorf("error creating SFTP client: %w", err)
+	}
+	return sshClient.Client, sftpClient, nil
+}
@@ -0,0 +1,179 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package sftp2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2"
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+// serverOption returns the option for authenticating with the server and verifying its host key.
+func serverOption(server *ssh2test.Server) ssh2.ClientOption {
+	return func(config *ssh2.ClientConfig) error {
+		config.Auth = []ssh.AuthMethod{ssh.Password(server.Password)}
+		config.HostKeyCallback = ssh.FixedHostKey(server.HostKey.PublicKey())
+		return nil
+	}
+}
+
+func TestPool(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	pool := NewPool(&NewPoolInput{})
+	defer pool.Close()
+
+	a, err := pool.Get(server.URI(ssh2.SchemeSFTP, "/tmp/a"), serverOption(server))
+	require.NoError(t, err)
+	b, err := pool.Get(server.URI(ssh2.SchemeSFTP, "/tmp/b"), serverOption(server))
+	require.NoError(t, err)
+	assert.True(t, a == b)
+	assert.Equal(t, 1, server.Handshakes())
+	assert.Equal(t, 1, pool.Len())
+
+	require.NoError(t, pool.Release(a))
+	require.NoError(t, pool.Release(b))
+	assert.Error(t, pool.Release(b))
+
+	// the idle session is checked and reused
+	c, err := pool.Get(server.URI(ssh2.SchemeSFTP, "/tmp/c"), serverOption(server))
+	require.NoError(t, err)
+	assert.True(t, a == c)
+	assert.Equal(t, 1, server.Handshakes())
+	require.NoError(t, pool.Release(c))
+
+	// a different user is a different session
+	_, err = pool.Get("sftp://other@"+server.Addr()+"//tmp/d", serverOption(server))
+	assert.Error(t, err)
+	assert.Equal(t, 1, pool.Len())
+
+	require.NoError(t, pool.Close())
+	assert.Equal(t, 0, pool.Len())
+	_, err = pool.Get(server.URI(ssh2.SchemeSFTP, "/tmp/c"), serverOption(server))
+	assert.ErrorIs(t, err, ErrPoolClosed)
+}
+
+func TestPoolIdleTimeout(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	pool := NewPool(&NewPoolInput{IdleTimeout: 50 * time.Millisecond})
+	defer pool.Close()
+
+	c, err := pool.Get(server.URI(ssh2.SchemeSFTP, "/tmp/a"), serverOption(server))
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, pool.Len(), "session in use was closed")
+
+	require.NoError(t, pool.Release(c))
+	assert.Eventually(t, func() bool { return pool.Len() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestPoolBrokenSession(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	pool := NewPool(&NewPoolInput{})
+	defer pool.Close()
+
+	c, err := pool.Get(server.URI(ssh2.SchemeSFTP, "/tmp/a"), serverOption(server))
+	require.NoError(t, err)
+	require.NoError(t, pool.Release(c))
+
+	server.CloseConnections()
+
+	c, err = pool.Get(server.URI(ssh2.SchemeSFTP, "/tmp/a"), serverOption(server))
+	require.NoError(t, err)
+	_, err = c.Getwd()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.Handshakes())
+	require.NoError(t, pool.Release(c))
+}
+
+func TestPoolGetWaitsForCheck(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	pool := NewPool(&NewPoolInput{})
+	defer pool.Close()
+
+	uri := server.URI(ssh2.SchemeSFTP, "/tmp/a")
+	c, err := pool.Get(uri, serverOption(server))
+	require.NoError(t, err)
+	require.NoError(t, pool.Release(c))
+
+	// another call is checking the idle session
+	key, err := poolKey(uri)
+	require.NoError(t, err)
+	pool.mutex.Lock()
+	pool.sessions[key].checking = true
+	pool.mutex.Unlock()
+
+	done := make(chan error)
+	go func() {
+		b, errGet := pool.Get(uri, serverOption(server))
+		if errGet == nil {
+			errGet = pool.Release(b)
+		}
+		done <- errGet
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("session was returned while it was being checked")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	pool.mutex.Lock()
+	pool.sessions[key].checking = false
+	pool.cond.Broadcast()
+	pool.mutex.Unlock()
+
+	require.NoError(t, <-done)
+	assert.Equal(t, 1, server.Handshakes())
+}
+
+func TestPoolMaxSessions(t *testing.T) {
+	first := ssh2test.NewServer()
+	defer first.Close()
+	second := ssh2test.NewServer()
+	defer second.Close()
+
+	pool := NewPool(&NewPoolInput{MaxSessions: 1})
+	defer pool.Close()
+
+	a, err := pool.Get(first.URI(ssh2.SchemeSFTP, "/tmp/a"), serverOption(first))
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		b, errGet := pool.Get(second.URI(ssh2.SchemeSFTP, "/tmp/b"), serverOption(second))
+		if errGet == nil {
+			errGet = pool.Release(b)
+		}
+		done <- errGet
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("session was opened while every session was in use")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, pool.Release(a))
+	require.NoError(t, <-done)
+	assert.Equal(t, 1, pool.Len())
+	assert.Equal(t, 1, second.Handshakes())
+}
@@ -20,6 +20,7 @@ type Reader struct {
 	sftpFile   *sftp.File
 	sftpClient *sftp.Client
 	sshClient  *ssh.Client
+	pool       *Pool // if not nil, then the SFTP client is released to the pool instead of closed
 }
 
 // Read implements the io.Reader interface.
@@ -28,8 +29,19 @@ func (r *Reader) Read(p []byte) (int, error) {
 }
 
 // Close closes the file reader, the SFTP connection, and the SSH connection.
+// If the SFTP client is from a pool, then the client is released to the pool instead.
 func (r *Reader) Close() error {
 	err := r.sftpFile.Close()
+	if r.pool != nil {
+		errRelease := r.pool.Release(r.sftpClient)
+		if err != nil {
+			return fmt.Errorf("error closing SFTP file: %w", err)
+		}
+		if errRelease != nil {
+			return fmt.Errorf("error releasing SFTP client: %w", errRelease)
+		}
+		return nil
+	}
 	if err != nil {
 		if r.sftpClient != nil {
 			_ = r.sftpClient.Close() // attempt to close the underlying SFTP connection
@@ -61,3 +73,8 @@ func (r *Reader) Close() error {
 func NewReader(file *sftp.File, sftpClient *sftp.Client, sshClient *ssh.Client) *Reader {
 	return &Reader{sftpFile: file, sftpClient: sftpClient, sshClient: sshClient}
 }
+
+// NewPooledReader creates a new Reader for reading a file from a SFTP server using a SFTP client returned by the pool.
+func NewPooledReader(file *sftp.File, pool *Pool, sftpClient *sftp.Client) *Reader {
+	return &Reader{sftpFile: file, sftpClient: sftpClient, pool: pool}
+}
@@ -25,7 +25,7 @@ func WriteFile(uri string, fileMode os.FileMode, options ...ssh2.ClientOption) (
 		return nil, fmt.Errorf("error creating SSH client: %w", err)
 	}
 
-	sftpClient, err := sftp.NewClient(sshClient.Client)
+	sftpClient, err := sftp.NewClient(sshClient.Client, sftp.UseConcurrentWrites(true))
 	if err != nil {
 		return nil, fmt.Errorf("error creating SFTP client: %w", err)
 	}
@@ -8,10 +8,19 @@
 package sftp2
 
 import (
+	"errors"
 	"time"
 )
 
 const (
 	DefaultPort    = 22
 	DefaultTimeout = 5 * time.Second
+
+	DefaultIdleTimeout = 1 * time.Minute
+
+	ExtensionPosixRename = "posix-rename@openssh.com"
+)
+
+var (
+	ErrPoolClosed = errors.New("pool is closed")
 )
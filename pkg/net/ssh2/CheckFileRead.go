@@ -0,0 +1,23 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// CheckFileRead returns an error if the path on the SSH server is not a readable regular file or named pipe.
+func CheckFileRead(client *ssh.Client, p string) error {
+	_, err := Run(client, fmt.Sprintf("test -f %s -o -p %s && test -r %s", quote(p), quote(p), quote(p)))
+	if err != nil {
+		return fmt.Errorf("file at path %q does not exist or cannot be read: %w", p, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// CheckFileWrite returns an error if a file already exists at the path on the SSH server
+// and neither append or overwrite is set.
+// Character devices and named pipes, such as /dev/null, can always be written to.
+func CheckFileWrite(client *ssh.Client, p string, appendToFile bool, overwrite bool) error {
+	if (!overwrite) && (!appendToFile) {
+		stdout, err := Run(client, fmt.Sprintf("if test -c %s -o -p %s; then echo device; elif test -e %s; then echo exists; fi", quote(p), quote(p), quote(p)))
+		if err != nil {
+			return fmt.Errorf("error stating file at path %q: %w", p, err)
+		}
+		if strings.TrimSpace(string(stdout)) == "exists" {
+			return fmt.Errorf("file already exists at path %q and neither append or overwrite is set", p)
+		}
+	}
+	return nil
+}
@@ -8,11 +8,19 @@
 package ssh2
 
 import (
+	"io"
+
 	"golang.org/x/crypto/ssh"
 )
 
 type ClientConfig struct {
 	ssh.ClientConfig
+	Signers     []ssh.Signer   // keys used for public key authentication, which is tried before the other methods
+	SSHConfig   *Config        // if not nil, then host aliases are resolved using the ssh config
+	JumpHosts   []string       // jump hosts connected to in order, each as a ssh uri or [user@]host[:port], overrides the ProxyJump in the ssh config
+	JumpOptions []ClientOption // options used when connecting to each jump host, such as authentication
+	JumpClient  *ssh.Client    // if not nil, then the server is dialed through this client
+	Closers     []io.Closer    // closed once the client is connected, such as the connection to a SSH agent
 }
 
 type ClientOption func(config *ClientConfig) error
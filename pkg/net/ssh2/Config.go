@@ -0,0 +1,131 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"strings"
+)
+
+// Config is a parsed ssh config file, such as "~/.ssh/config".
+// Use LoadConfig to load a ssh config file.
+type Config struct {
+	blocks []*configBlock
+}
+
+// configBlock is a Host or Match block of a ssh config file.
+type configBlock struct {
+	patterns []string // host patterns, which are negated if prefixed with "!"
+	match    bool     // if true, then the block is a Match block and only matches if patterns is set
+	options  []configOption
+}
+
+// configOption is a keyword and its arguments.
+type configOption struct {
+	keyword string // lowercase keyword
+	args    []string
+}
+
+// Host returns the values in the config file for the host alias.
+// Like OpenSSH, the first value obtained for each keyword is used, except for IdentityFile, which accumulates.
+// The tokens "%h", "%p", "%r", "%u", "%d", and "%%" and a leading "~" are expanded in IdentityFile and UserKnownHostsFile.
+// If the config is nil, then returns an empty HostConfig.
+func (c *Config) Host(alias string) *HostConfig {
+	hostConfig := &HostConfig{}
+	if c == nil {
+		return hostConfig
+	}
+	for _, block := range c.blocks {
+		if !block.matches(alias) {
+			continue
+		}
+		for _, option := range block.options {
+			switch option.keyword {
+			case "hostname":
+				if len(hostConfig.HostName) == 0 {
+					hostConfig.HostName = strings.ReplaceAll(option.args[0], "%h", alias)
+				}
+			case "port":
+				if len(hostConfig.Port) == 0 {
+					hostConfig.Port = option.args[0]
+				}
+			case "user":
+				if len(hostConfig.User) == 0 {
+					hostConfig.User = option.args[0]
+				}
+			case "identityfile":
+				hostConfig.IdentityFiles = append(hostConfig.IdentityFiles, option.args[0])
+			case "proxyjump":
+				if len(hostConfig.ProxyJump) == 0 {
+					hostConfig.ProxyJump = option.args[0]
+				}
+			case "userknownhostsfile":
+				if len(hostConfig.UserKnownHostsFiles) == 0 {
+					hostConfig.UserKnownHostsFiles = append([]string{}, option.args...)
+				}
+			}
+		}
+	}
+	if len(hostConfig.HostName) == 0 {
+		hostConfig.HostName = alias
+	}
+	for i, file := range hostConfig.IdentityFiles {
+		hostConfig.IdentityFiles[i] = hostConfig.expandTokens(file)
+	}
+	for i, file := range hostConfig.UserKnownHostsFiles {
+		hostConfig.UserKnownHostsFiles[i] = hostConfig.expandTokens(file)
+	}
+	return hostConfig
+}
+
+// matches returns true if the block applies to the host alias.
+// Match blocks only match with "Match all", since other criteria are not supported.
+func (b *configBlock) matches(alias string) bool {
+	if b.match {
+		return len(b.patterns) > 0
+	}
+	alias = strings.ToLower(alias)
+	matched := false
+	for _, pattern := range b.patterns {
+		if strings.HasPrefix(pattern, "!") {
+			if matchPattern(strings.ToLower(pattern[1:]), alias) {
+				return false
+			}
+			continue
+		}
+		if matchPattern(strings.ToLower(pattern), alias) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// matchPattern returns true if the pattern matches the string,
+// where "*" matches zero or more characters and "?" matches exactly one character.
+func matchPattern(pattern string, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := 0; i <= len(s); i++ {
+				if matchPattern(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+		default:
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return len(s) == 0
+}
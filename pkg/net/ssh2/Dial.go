@@ -9,6 +9,7 @@ package ssh2
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -17,8 +18,16 @@ import (
 	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
 )
 
-// Dial returns a Client for the SSH server at a given SSH or SFTP URI.
+// Dial returns a Client for the SSH server at a given SSH, SSH command, or SFTP URI.
 // The ClientOption options are processed after the authority from the URI.
+// If an option sets the SSHConfig, then the host in the URI is resolved as an alias using the ssh config,
+// but the port and user in the URI take precedence.
+// If no option sets the HostKeyCallback, then the host key is verified using the DefaultKnownHostsFile.
+//
+// If an option sets the JumpClient, then the server is dialed through the existing client.
+// Otherwise, if an option sets the JumpHosts or the ProxyJump for the host in the ssh config is set,
+// then the jump hosts are connected to in order, each through the previous one, using the JumpOptions.
+// The jump hosts are closed when the returned client is closed.
 //
 // Dial returns an error if the address cannot be dialed,
 // the userinfo cannot be parsed,
@@ -27,24 +36,29 @@ import (
 //
 //
 func Dial(uri string, options ...ClientOption) (*Client, error) {
+	return dial(uri, 0, options...)
+}
+
+// dial returns a Client for the SSH server at a given SSH or SFTP URI.
+// The depth is the number of jump hosts that led to this server, which is used to stop loops of jump hosts.
+func dial(uri string, depth int, options ...ClientOption) (*Client, error) {
 
 	scheme, fullpath := splitter.SplitURI(uri)
 
-	if scheme != SchemeSSH && scheme != SchemeSFTP {
+	if scheme != SchemeSSH && scheme != SchemeSSHCommand && scheme != SchemeSFTP {
 		return nil, fmt.Errorf("error dialing %q: unknown scheme %q", uri, scheme)
 	}
 
 	parts := strings.SplitN(fullpath, "/", 2)
 
-	userinfo, host, port := splitter.SplitAuthority(parts[0])
-	if len(port) == 0 {
-		port = strconv.Itoa(DefaultPort)
-	}
+	// the query of a ssh+cmd uri may follow the authority
+	authority := strings.SplitN(parts[0], "?", 2)[0]
+
+	userinfo, host, port := splitter.SplitAuthority(authority)
 
 	sshClientConfig := &ClientConfig{
 		ClientConfig: ssh.ClientConfig{
-			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-			Timeout:         DefaultTimeout,
+			Timeout: DefaultTimeout,
 		},
 	}
 
@@ -63,6 +77,13 @@ func Dial(uri string, options ...ClientOption) (*Client, error) {
 		}
 	}
 
+	// the closers are only needed while connecting
+	defer func() {
+		for _, closer := range sshClientConfig.Closers {
+			_ = closer.Close()
+		}
+	}()
+
 	for i, option := range options {
 		err := option(sshClientConfig)
 		if err != nil {
@@ -70,11 +91,131 @@ func Dial(uri string, options ...ClientOption) (*Client, error) {
 		}
 	}
 
-	sshClient, err := ssh.Dial("tcp", fmt.Sprintf("%s:%s", host, port), &sshClientConfig.ClientConfig)
+	jumpHosts := sshClientConfig.JumpHosts
+
+	if sshClientConfig.SSHConfig != nil {
+		hostConfig := sshClientConfig.SSHConfig.Host(host)
+		if len(jumpHosts) == 0 && sshClientConfig.JumpClient == nil && len(hostConfig.ProxyJump) > 0 {
+			jumpHosts = strings.Split(hostConfig.ProxyJump, ",")
+		}
+		host = hostConfig.HostName
+		if len(port) == 0 {
+			port = hostConfig.Port
+		}
+		if len(sshClientConfig.User) == 0 {
+			sshClientConfig.User = hostConfig.User
+		}
+		signers, err := hostConfig.signers()
+		if err != nil {
+			return nil, fmt.Errorf("error loading identity files for %q: %w", host, err)
+		}
+		sshClientConfig.Signers = append(sshClientConfig.Signers, signers...)
+		if sshClientConfig.HostKeyCallback == nil && len(hostConfig.UserKnownHostsFiles) > 0 {
+			hostKeyCallback, errCallback := NewHostKeyCallback(&NewHostKeyCallbackInput{KnownHostsFiles: hostConfig.UserKnownHostsFiles})
+			if errCallback != nil {
+				return nil, fmt.Errorf("error creating host key callback: %w", errCallback)
+			}
+			sshClientConfig.HostKeyCallback = hostKeyCallback
+		}
+	}
+
+	if len(port) == 0 {
+		port = strconv.Itoa(DefaultPort)
+	}
+
+	// the Go client tries each kind of authentication method only once, so the public keys are combined into a single method.
+	if len(sshClientConfig.Signers) > 0 {
+		sshClientConfig.Auth = append([]ssh.AuthMethod{ssh.PublicKeys(sshClientConfig.Signers...)}, sshClientConfig.Auth...)
+	}
+
+	address := net.JoinHostPort(host, port)
+
+	if sshClientConfig.HostKeyCallback == nil {
+		hostKeyCallback, err := NewHostKeyCallback(&NewHostKeyCallbackInput{})
+		if err != nil {
+			return nil, fmt.Errorf("error creating host key callback: %w", err)
+		}
+		sshClientConfig.HostKeyCallback = hostKeyCallback
+	}
+
+	if len(sshClientConfig.HostKeyAlgorithms) == 0 {
+		sshClientConfig.HostKeyAlgorithms = hostKeyAlgorithms(sshClientConfig.HostKeyCallback, address)
+	}
+
+	if len(jumpHosts) == 1 && jumpHosts[0] == "none" {
+		jumpHosts = nil
+	}
+
+	if len(jumpHosts) > 0 && depth >= MaxJumpHosts {
+		return nil, fmt.Errorf("error dialing %q: more than %d jump hosts", address, MaxJumpHosts)
+	}
+
+	// the jump hosts that are connected to by this function, which are closed with the client
+	jumpClients := make([]*ssh.Client, 0, len(jumpHosts))
+	closeJumpClients := func() {
+		for i := len(jumpClients) - 1; i >= 0; i-- {
+			_ = jumpClients[i].Close()
+		}
+	}
+
+	jumpClient := sshClientConfig.JumpClient
+	for _, jumpHost := range jumpHosts {
+		jumpURI := strings.TrimSpace(jumpHost)
+		if !strings.Contains(jumpURI, "://") {
+			jumpURI = SchemeSSH + "://" + jumpURI
+		}
+		jumpOptions := append([]ClientOption{
+			func(config *ClientConfig) error {
+				config.SSHConfig = sshClientConfig.SSHConfig
+				config.JumpOptions = sshClientConfig.JumpOptions
+				return nil
+			},
+		}, sshClientConfig.JumpOptions...)
+		previous := jumpClient
+		jumpOptions = append(jumpOptions, func(config *ClientConfig) error {
+			config.JumpClient = previous
+			return nil
+		})
+		c, err := dial(jumpURI, depth+1, jumpOptions...)
+		if err != nil {
+			closeJumpClients()
+			return nil, fmt.Errorf("error connecting to jump host %q: %w", jumpHost, err)
+		}
+		jumpClients = append(jumpClients, c.Client)
+		jumpClient = c.Client
+	}
+
+	sshClient, err := dialThrough(jumpClient, address, &sshClientConfig.ClientConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating SSH client for %q: %w", fmt.Sprintf("%s:%s", host, port), err)
+		closeJumpClients()
+		return nil, fmt.Errorf("error creating SSH client for %q: %w", address, err)
+	}
+
+	if len(jumpClients) > 0 {
+		// the SFTP and SSH clients are closed by their users, so close the jump hosts once the connection is closed.
+		go func() {
+			_ = sshClient.Wait()
+			closeJumpClients()
+		}()
 	}
 
 	return &Client{sshClient}, nil
 
 }
+
+// dialThrough returns a SSH client for the address, which is dialed through the jump client if not nil.
+func dialThrough(jumpClient *ssh.Client, address string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	if jumpClient == nil {
+		return ssh.Dial("tcp", address, config)
+	}
+	conn, err := jumpClient.Dial("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %q through jump host: %w", address, err)
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
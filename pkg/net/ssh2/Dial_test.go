@@ -8,13 +8,19 @@
 package ssh2
 
 import (
+	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"golang.org/x/crypto/ssh"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
 )
 
 func TestDialPassword(t *testing.T) {
@@ -49,3 +55,137 @@ func TestDialKey(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestDialJumpHosts(t *testing.T) {
+	target := ssh2test.NewServer()
+	defer target.Close()
+	// the jump hosts have their own passwords
+	bastion1 := ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.Password = "secret1"
+	})
+	defer bastion1.Close()
+	bastion2 := ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.Password = "secret2"
+	})
+	defer bastion2.Close()
+
+	client, err := Dial(target.URI(SchemeSFTP, "tmp"), func(config *ClientConfig) error {
+		config.Auth = []ssh.AuthMethod{ssh.Password(target.Password)}
+		config.HostKeyCallback = ssh.FixedHostKey(target.HostKey.PublicKey())
+		config.JumpHosts = []string{
+			fmt.Sprintf("grw:secret1@%s", bastion1.Addr()),
+			fmt.Sprintf("ssh://grw:secret2@%s", bastion2.Addr()),
+		}
+		config.JumpOptions = []ClientOption{
+			func(config *ClientConfig) error {
+				config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+				return nil
+			},
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, bastion1.Handshakes())
+	assert.Equal(t, 1, bastion2.Handshakes())
+	assert.Equal(t, 1, target.Handshakes())
+	assert.NoError(t, client.Close())
+
+	// the host keys of the jump hosts are verified
+	_, err = Dial(target.URI(SchemeSFTP, "tmp"), func(config *ClientConfig) error {
+		config.Auth = []ssh.AuthMethod{ssh.Password(target.Password)}
+		config.HostKeyCallback = ssh.FixedHostKey(target.HostKey.PublicKey())
+		config.JumpHosts = []string{fmt.Sprintf("grw:secret1@%s", bastion1.Addr())}
+		config.JumpOptions = []ClientOption{
+			func(config *ClientConfig) error {
+				config.HostKeyCallback = ssh.FixedHostKey(target.HostKey.PublicKey())
+				return nil
+			},
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, target.Handshakes())
+}
+
+func TestDialJumpClient(t *testing.T) {
+	target := ssh2test.NewServer()
+	defer target.Close()
+	bastion := ssh2test.NewServer()
+	defer bastion.Close()
+
+	bastionClient, err := Dial(bastion.URI(SchemeSSH, ""), func(config *ClientConfig) error {
+		config.Auth = []ssh.AuthMethod{ssh.Password(bastion.Password)}
+		config.HostKeyCallback = ssh.FixedHostKey(bastion.HostKey.PublicKey())
+		return nil
+	})
+	require.NoError(t, err)
+	defer bastionClient.Close()
+
+	for i := 0; i < 2; i++ {
+		client, errDial := Dial(target.URI(SchemeSFTP, "tmp"), NewJumpClientOption(bastionClient.Client), func(config *ClientConfig) error {
+			config.Auth = []ssh.AuthMethod{ssh.Password(target.Password)}
+			config.HostKeyCallback = ssh.FixedHostKey(target.HostKey.PublicKey())
+			return nil
+		})
+		require.NoError(t, errDial)
+		// closing the client does not close the existing client
+		assert.NoError(t, client.Close())
+	}
+	assert.Equal(t, 1, bastion.Handshakes())
+	assert.Equal(t, 2, target.Handshakes())
+}
+
+func TestDialProxyJump(t *testing.T) {
+	target := ssh2test.NewServer()
+	defer target.Close()
+	bastion := ssh2test.NewServer()
+	defer bastion.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "known_hosts"), []byte(target.KnownHostsLine()+"\n"+bastion.KnownHostsLine()+"\n"), 0600))
+
+	lines := []string{}
+	for alias, server := range map[string]*ssh2test.Server{"target": target, "bastion": bastion} {
+		host, port, err := net.SplitHostPort(server.Addr())
+		require.NoError(t, err)
+		lines = append(lines, fmt.Sprintf("Host %s\n  HostName %s\n  Port %s\n  User %s", alias, host, port, server.User))
+	}
+	lines = append(lines, "Host target\n  ProxyJump bastion", "Host loop\n  ProxyJump loop", "Host *\n  UserKnownHostsFile "+filepath.Join(dir, "known_hosts"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config"), []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+	sshConfig, err := LoadConfig(filepath.Join(dir, "config"))
+	require.NoError(t, err)
+
+	passwordOption := func(config *ClientConfig) error {
+		config.Auth = []ssh.AuthMethod{ssh.Password(target.Password)}
+		return nil
+	}
+
+	client, err := Dial("sftp://target/tmp", func(config *ClientConfig) error {
+		config.SSHConfig = sshConfig
+		config.JumpOptions = []ClientOption{passwordOption}
+		return nil
+	}, passwordOption)
+	require.NoError(t, err)
+	assert.Equal(t, 1, bastion.Handshakes())
+	assert.Equal(t, 1, target.Handshakes())
+	assert.NoError(t, client.Close())
+
+	// the jump hosts option overrides the ssh config
+	client, err = Dial("sftp://target/tmp", func(config *ClientConfig) error {
+		config.SSHConfig = sshConfig
+		config.JumpHosts = []string{"none"}
+		return nil
+	}, passwordOption)
+	require.NoError(t, err)
+	assert.Equal(t, 1, bastion.Handshakes())
+	assert.Equal(t, 2, target.Handshakes())
+	assert.NoError(t, client.Close())
+
+	_, err = Dial("sftp://loop/tmp", func(config *ClientConfig) error {
+		config.SSHConfig = sshConfig
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("more than %d jump hosts", MaxJumpHosts))
+}
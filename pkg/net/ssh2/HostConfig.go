@@ -0,0 +1,84 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/ssh"
+)
+
+// HostConfig contains the values in a ssh config file for a host.
+// Empty values are not set by the config file.
+type HostConfig struct {
+	HostName            string   // real host name to connect to
+	Port                string   // port to connect to
+	User                string   // user to log in as
+	IdentityFiles       []string // paths to the private keys used for authentication, in the order they are tried
+	ProxyJump           string   // comma-separated list of jump hosts, or "none"
+	UserKnownHostsFiles []string // paths to the known_hosts files used to verify the host key
+}
+
+// signers returns the keys in the identity files.
+// Like OpenSSH, missing identity files are skipped.
+// Encrypted identity files are also skipped, since the keys can be added to a SSH agent instead.
+func (hc *HostConfig) signers() ([]ssh.Signer, error) {
+	signers := make([]ssh.Signer, 0, len(hc.IdentityFiles))
+	for _, file := range hc.IdentityFiles {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading identity file %q: %w", file, err)
+		}
+		signer, err := ssh.ParsePrivateKey(b)
+		if err != nil {
+			var passphraseMissingError *ssh.PassphraseMissingError
+			if errors.As(err, &passphraseMissingError) {
+				continue
+			}
+			return nil, fmt.Errorf("error parsing identity file %q: %w", file, err)
+		}
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
+
+// expandTokens expands the tokens and leading "~" in a path.
+func (hc *HostConfig) expandTokens(p string) string {
+	if expanded, err := homedir.Expand(p); err == nil {
+		p = expanded
+	}
+	if !strings.Contains(p, "%") {
+		return p
+	}
+	port := hc.Port
+	if len(port) == 0 {
+		port = "22"
+	}
+	localUser := ""
+	if u, err := user.Current(); err == nil {
+		localUser = u.Username
+	}
+	home, _ := homedir.Dir()
+	replacer := strings.NewReplacer(
+		"%%", "%",
+		"%d", home,
+		"%h", hc.HostName,
+		"%p", port,
+		"%r", hc.User,
+		"%u", localUser,
+	)
+	return replacer.Replace(p)
+}
@@ -0,0 +1,156 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// maxConfigDepth is the maximum depth of nested Include directives.
+const maxConfigDepth = 16
+
+// LoadConfig loads the ssh config file at the path, such as DefaultConfigFile.
+// A leading "~" in the path is expanded to the home directory of the user.
+// If the file does not exist, then returns an empty config.
+//
+// Host blocks, "Match all" blocks, and Include directives are supported.
+// Other Match blocks are ignored.
+// Relative paths in Include directives are relative to the directory of the including file.
+func LoadConfig(path string) (*Config, error) {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding ssh config path %q: %w", path, err)
+	}
+	config := &Config{}
+	err = config.load(expanded, &configBlock{patterns: []string{"*"}}, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("error loading ssh config: %w", err)
+	}
+	return config, nil
+}
+
+// load appends the blocks in the file to the config.
+// Options before the first Host or Match block in the file are added to the current block.
+func (c *Config) load(path string, current *configBlock, depth int) error {
+	if depth > maxConfigDepth {
+		return fmt.Errorf("error loading ssh config file %q: too many nested includes", path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// add the options to a copy of the current block, so that options after the include stay in order
+	block := &configBlock{patterns: current.patterns, match: current.match}
+	c.blocks = append(c.blocks, block)
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		keyword, args, errParse := parseConfigLine(scanner.Text())
+		if errParse != nil {
+			return fmt.Errorf("error parsing line %d of ssh config file %q: %w", line, path, errParse)
+		}
+		switch keyword {
+		case "":
+			continue
+		case "host":
+			block = &configBlock{patterns: args}
+			c.blocks = append(c.blocks, block)
+			continue
+		case "match":
+			block = &configBlock{match: true}
+			if len(args) == 1 && strings.EqualFold(args[0], "all") {
+				block.patterns = args
+			}
+			c.blocks = append(c.blocks, block)
+			continue
+		case "include":
+			for _, arg := range args {
+				pattern, errExpand := homedir.Expand(arg)
+				if errExpand != nil {
+					return fmt.Errorf("error expanding include %q in ssh config file %q: %w", arg, path, errExpand)
+				}
+				if !filepath.IsAbs(pattern) {
+					pattern = filepath.Join(filepath.Dir(path), pattern)
+				}
+				matches, errGlob := filepath.Glob(pattern)
+				if errGlob != nil {
+					return fmt.Errorf("error expanding include %q in ssh config file %q: %w", arg, path, errGlob)
+				}
+				for _, match := range matches {
+					errLoad := c.load(match, block, depth+1)
+					if errLoad != nil && !os.IsNotExist(errLoad) {
+						return errLoad
+					}
+				}
+			}
+			// continue the current block after the included blocks
+			block = &configBlock{patterns: block.patterns, match: block.match}
+			c.blocks = append(c.blocks, block)
+			continue
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("error parsing line %d of ssh config file %q: missing argument for %q", line, path, keyword)
+		}
+		block.options = append(block.options, configOption{keyword: keyword, args: args})
+	}
+	if errScan := scanner.Err(); errScan != nil {
+		return fmt.Errorf("error reading ssh config file %q: %w", path, errScan)
+	}
+	return nil
+}
+
+// parseConfigLine returns the lowercase keyword and arguments of a line in a ssh config file.
+// The keyword is separated from the arguments by whitespace or "=".
+// Arguments may be enclosed in double quotes.
+// If the line is empty or a comment, then returns an empty keyword.
+func parseConfigLine(line string) (string, []string, error) {
+	line = strings.TrimSpace(line)
+	if len(line) == 0 || strings.HasPrefix(line, "#") {
+		return "", nil, nil
+	}
+	end := strings.IndexAny(line, " \t=")
+	if end == -1 {
+		return strings.ToLower(line), nil, nil
+	}
+	keyword := strings.ToLower(line[:end])
+	rest := strings.TrimSpace(line[end:])
+	if strings.HasPrefix(rest, "=") {
+		rest = strings.TrimSpace(rest[1:])
+	}
+	args := []string{}
+	for len(rest) > 0 {
+		if rest[0] == '"' {
+			quote := strings.Index(rest[1:], "\"")
+			if quote == -1 {
+				return "", nil, fmt.Errorf("unterminated quote in %q", line)
+			}
+			args = append(args, rest[1:quote+1])
+			rest = strings.TrimLeft(rest[quote+2:], " \t")
+			continue
+		}
+		space := strings.IndexAny(rest, " \t")
+		if space == -1 {
+			args = append(args, rest)
+			break
+		}
+		args = append(args, rest[:space])
+		rest = strings.TrimLeft(rest[space:], " \t")
+	}
+	return keyword, args, nil
+}
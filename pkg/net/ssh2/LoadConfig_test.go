@@ -0,0 +1,178 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "config.d"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.d", "bastion"), []byte(`
+Host bastion
+  HostName bastion.example.com
+  User jump
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config"), []byte(`
+# global options apply to every host
+IdentityFile ~/.ssh/id_global
+
+Include config.d/*
+
+Host db-* !db-legacy
+  HostName %h.internal.example.com
+  Port=2222
+  ProxyJump bastion
+  IdentityFile "/keys/%r@%h"
+
+Host *.example.com db-legacy
+  User deploy
+  UserKnownHostsFile /hosts/a /hosts/b
+
+Match exec "false"
+  User ignored
+
+Match all
+  User fallback
+  Port 22
+`), 0600))
+
+	config, err := LoadConfig(filepath.Join(dir, "config"))
+	require.NoError(t, err)
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	assert.Equal(t, &HostConfig{
+		HostName:      "db-1.internal.example.com",
+		Port:          "2222",
+		User:          "fallback",
+		IdentityFiles: []string{filepath.Join(home, ".ssh", "id_global"), "/keys/fallback@db-1.internal.example.com"},
+		ProxyJump:     "bastion",
+	}, config.Host("db-1"))
+
+	assert.Equal(t, &HostConfig{
+		HostName:            "db-legacy",
+		Port:                "22",
+		User:                "deploy",
+		IdentityFiles:       []string{filepath.Join(home, ".ssh", "id_global")},
+		UserKnownHostsFiles: []string{"/hosts/a", "/hosts/b"},
+	}, config.Host("db-legacy"))
+
+	assert.Equal(t, &HostConfig{
+		HostName:      "bastion.example.com",
+		Port:          "22",
+		User:          "jump",
+		IdentityFiles: []string{filepath.Join(home, ".ssh", "id_global")},
+	}, config.Host("bastion"))
+
+	// host patterns match the alias rather than the host name
+	assert.Equal(t, "deploy", config.Host("www.example.com").User)
+
+	missing, err := LoadConfig(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, &HostConfig{HostName: "example.com"}, missing.Host("example.com"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid"), []byte("Host example\n  HostName\n"), 0600))
+	_, err = LoadConfig(filepath.Join(dir, "invalid"))
+	assert.Error(t, err)
+}
+
+func TestParseConfigLine(t *testing.T) {
+	testCases := []struct {
+		line    string
+		keyword string
+		args    []string
+	}{
+		{line: "", keyword: "", args: nil},
+		{line: "  # comment", keyword: "", args: nil},
+		{line: "HostName example.com", keyword: "hostname", args: []string{"example.com"}},
+		{line: "\tPort = 22", keyword: "port", args: []string{"22"}},
+		{line: "Port=22", keyword: "port", args: []string{"22"}},
+		{line: `IdentityFile "~/My Keys/id_ed25519"`, keyword: "identityfile", args: []string{"~/My Keys/id_ed25519"}},
+		{line: "Host a b  c", keyword: "host", args: []string{"a", "b", "c"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.line, func(t *testing.T) {
+			keyword, args, err := parseConfigLine(testCase.line)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.keyword, keyword)
+			if testCase.args == nil {
+				assert.Empty(t, args)
+			} else {
+				assert.Equal(t, testCase.args, args)
+			}
+		})
+	}
+	_, _, err := parseConfigLine(`IdentityFile "unterminated`)
+	assert.Error(t, err)
+}
+
+func TestDialConfig(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	server := ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.Password = ""
+		s.AuthorizedKeys = []ssh.PublicKey{publicKey}
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "id_ecdsa"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "known_hosts"), []byte(server.KnownHostsLine()+"\n"), 0600))
+
+	host, port, err := net.SplitHostPort(server.Addr())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config"), []byte(fmt.Sprintf(`
+Host myalias
+  HostName %s
+  Port %s
+  User %s
+  IdentityFile %s
+  IdentityFile %s
+  UserKnownHostsFile %s
+`, host, port, server.User, filepath.Join(dir, "missing"), filepath.Join(dir, "id_ecdsa"), filepath.Join(dir, "known_hosts"))), 0600))
+
+	config, err := LoadConfig(filepath.Join(dir, "config"))
+	require.NoError(t, err)
+
+	client, err := Dial("sftp://myalias/tmp", func(c *ClientConfig) error {
+		c.SSHConfig = config
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NoError(t, client.Close())
+
+	// the user in the uri takes precedence over the config
+	_, err = Dial("sftp://root@myalias/tmp", func(c *ClientConfig) error {
+		c.SSHConfig = config
+		return nil
+	})
+	assert.Error(t, err)
+}
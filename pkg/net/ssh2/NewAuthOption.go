@@ -0,0 +1,78 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// NewAuthOptionInput contains the input parameters for NewAuthOption.
+type NewAuthOptionInput struct {
+	Password           string                 // password
+	PrivateKey         []byte                 // PEM-encoded private key, which may be encrypted
+	Passphrase         []byte                 // passphrase of an encrypted private key
+	PassphraseProvider func() ([]byte, error) // if the private key is encrypted and the passphrase is not set, then called for the passphrase
+	AgentSocket        string                 // path to the socket of a SSH agent, such as the value of SSH_AUTH_SOCK
+}
+
+// NewAuthOption returns a client option that appends authentication methods to the client config.
+// The private key and then the keys in the SSH agent are appended to the signers of the config,
+// which are tried before the password.
+// The private key is parsed immediately, so that the passphrase is only requested once.
+//
+// If the SSH agent cannot be reached, then the agent is skipped.
+// The connection to the agent is closed once the client is connected.
+func NewAuthOption(input *NewAuthOptionInput) (ClientOption, error) {
+	var signer ssh.Signer
+	if len(input.PrivateKey) > 0 {
+		key, err := ParsePrivateKey(&ParsePrivateKeyInput{
+			PrivateKey:         input.PrivateKey,
+			Passphrase:         input.Passphrase,
+			PassphraseProvider: input.PassphraseProvider,
+		})
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	}
+	password := input.Password
+	agentSocket := input.AgentSocket
+	return func(config *ClientConfig) error {
+		if signer != nil {
+			config.Signers = append(config.Signers, signer)
+		}
+		if len(agentSocket) > 0 {
+			if conn, err := net.Dial("unix", agentSocket); err == nil {
+				config.Closers = append(config.Closers, conn)
+				agentSigners, errSigners := agent.NewClient(conn).Signers()
+				if errSigners != nil {
+					return fmt.Errorf("error listing keys in SSH agent: %w", errSigners)
+				}
+				config.Signers = append(config.Signers, agentSigners...)
+			}
+		}
+		if len(password) > 0 {
+			config.Auth = append(
+				config.Auth,
+				ssh.Password(password),
+				ssh.KeyboardInteractive(func(user string, instruction string, questions []string, echos []bool) ([]string, error) {
+					answers := make([]string, len(questions))
+					for i := range answers {
+						answers[i] = password
+					}
+					return answers, nil
+				}),
+			)
+		}
+		return nil
+	}, nil
+}
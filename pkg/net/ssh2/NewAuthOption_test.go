@@ -0,0 +1,130 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+// newEncryptedPrivateKey returns a new private key, the private key as PEM encrypted with the passphrase, and the public key.
+func newEncryptedPrivateKey(t *testing.T, passphrase string) (*ecdsa.PrivateKey, []byte, ssh.PublicKey) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	// legacy PEM encryption is deprecated, but is still supported by OpenSSH
+	block, err := x509.EncryptPEMBlock(rand.Reader, "EC PRIVATE KEY", der, []byte(passphrase), x509.PEMCipherAES256)
+	require.NoError(t, err)
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	return privateKey, pem.EncodeToMemory(block), publicKey
+}
+
+func dialWithAuth(server *ssh2test.Server, input *NewAuthOptionInput) error {
+	authOption, err := NewAuthOption(input)
+	if err != nil {
+		return err
+	}
+	client, err := Dial(server.URI(SchemeSFTP, "tmp"), authOption, func(config *ClientConfig) error {
+		config.HostKeyCallback = ssh.FixedHostKey(server.HostKey.PublicKey())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return client.Close()
+}
+
+func TestNewAuthOptionPrivateKey(t *testing.T) {
+	_, privateKey, publicKey := newEncryptedPrivateKey(t, "passphrase")
+	server := ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.Password = ""
+		s.AuthorizedKeys = []ssh.PublicKey{publicKey}
+	})
+	defer server.Close()
+
+	assert.NoError(t, dialWithAuth(server, &NewAuthOptionInput{PrivateKey: privateKey, Passphrase: []byte("passphrase")}))
+
+	prompts := 0
+	assert.NoError(t, dialWithAuth(server, &NewAuthOptionInput{
+		PrivateKey: privateKey,
+		PassphraseProvider: func() ([]byte, error) {
+			prompts++
+			return []byte("passphrase"), nil
+		},
+	}))
+	assert.Equal(t, 1, prompts)
+
+	assert.Error(t, dialWithAuth(server, &NewAuthOptionInput{PrivateKey: privateKey}))
+	assert.Error(t, dialWithAuth(server, &NewAuthOptionInput{PrivateKey: privateKey, Passphrase: []byte("invalid")}))
+}
+
+func TestNewAuthOptionAgent(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	// unix socket paths are limited in length, so use a short temporary directory
+	dir, err := os.MkdirTemp("", "agent")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	keyring := agent.NewKeyring()
+	privateKey, _, publicKey := newEncryptedPrivateKey(t, "passphrase")
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: privateKey}))
+	go func() {
+		for {
+			conn, errAccept := listener.Accept()
+			if errAccept != nil {
+				return
+			}
+			go func() {
+				_ = agent.ServeAgent(keyring, conn)
+				_ = conn.Close()
+			}()
+		}
+	}()
+
+	// the key in the agent is not authorized, so fall back to the password
+	assert.NoError(t, dialWithAuth(server, &NewAuthOptionInput{AgentSocket: socket, Password: server.Password}))
+
+	// the key in the agent is not authorized and passwords are not allowed
+	unauthorized := ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.Password = ""
+	})
+	defer unauthorized.Close()
+	assert.Error(t, dialWithAuth(unauthorized, &NewAuthOptionInput{AgentSocket: socket}))
+
+	authorized := ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.Password = ""
+		s.AuthorizedKeys = []ssh.PublicKey{publicKey}
+	})
+	defer authorized.Close()
+	assert.NoError(t, dialWithAuth(authorized, &NewAuthOptionInput{AgentSocket: socket}))
+
+	// the agent cannot be reached, so the agent is skipped
+	assert.Error(t, dialWithAuth(authorized, &NewAuthOptionInput{AgentSocket: filepath.Join(dir, "missing.sock")}))
+}
@@ -0,0 +1,208 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// NewHostKeyCallbackInput contains the input parameters for NewHostKeyCallback.
+type NewHostKeyCallbackInput struct {
+	KnownHostsFiles []string // paths to known_hosts files, defaults to DefaultKnownHostsFile
+	Fingerprint     string   // if set, then only the host key with this SHA256 or legacy MD5 fingerprint is accepted
+	AcceptNew       bool     // if true, then the host keys of unknown hosts are accepted and appended to the first known_hosts file
+	Insecure        bool     // if true, then any host key is accepted
+}
+
+// NewHostKeyCallback returns a callback that verifies the host key of a SSH server.
+//
+// If a fingerprint is set, then the host key must have the fingerprint, e.g., "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s" or "MD5:16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48".
+// Otherwise, the host key must match a key for the host in the known_hosts files.
+// Missing known_hosts files are treated as empty.
+// If AcceptNew is true, then the host keys of unknown hosts are appended to the first known_hosts file,
+// but changed host keys are still rejected.
+// The known_hosts files are read every time the callback is called, so hosts added by other connections are seen.
+//
+// If insecure is true, then any host key is accepted, which allows man-in-the-middle attacks.
+func NewHostKeyCallback(input *NewHostKeyCallbackInput) (ssh.HostKeyCallback, error) {
+	if input.Insecure {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	if len(input.Fingerprint) > 0 {
+		fingerprint := input.Fingerprint
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if !matchFingerprint(fingerprint, key) {
+				return fmt.Errorf("host key %s of %q does not match the fingerprint %s", ssh.FingerprintSHA256(key), hostname, fingerprint)
+			}
+			return nil
+		}, nil
+	}
+
+	files := make([]string, 0, len(input.KnownHostsFiles))
+	for _, file := range input.KnownHostsFiles {
+		expanded, err := homedir.Expand(file)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding known_hosts path %q: %w", file, err)
+		}
+		files = append(files, expanded)
+	}
+	if len(files) == 0 {
+		file, err := homedir.Expand(DefaultKnownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding known_hosts path %q: %w", DefaultKnownHostsFile, err)
+		}
+		files = append(files, file)
+	}
+
+	// check that the known_hosts files can be parsed before connecting
+	if _, err := newKnownHostsCallback(files); err != nil {
+		return nil, err
+	}
+
+	mutex := &sync.Mutex{}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		callback, err := newKnownHostsCallback(files)
+		if err != nil {
+			return err
+		}
+		err = callback(hostname, remote, key)
+		if err == nil {
+			return nil
+		}
+		var keyError *knownhosts.KeyError
+		if !errors.As(err, &keyError) {
+			return err
+		}
+		if len(keyError.Want) > 0 {
+			return fmt.Errorf(
+				"host key %s of %q does not match the host key in %s, which could be a man-in-the-middle attack: %w",
+				ssh.FingerprintSHA256(key),
+				hostname,
+				keyError.Want[0].String(),
+				err)
+		}
+		if !input.AcceptNew || bytes.Equal(key.Marshal(), probeKey().Marshal()) {
+			return fmt.Errorf("host key %s of %q is not in the known_hosts files %s: %w", ssh.FingerprintSHA256(key), hostname, strings.Join(files, ", "), err)
+		}
+		errAppend := appendKnownHost(files[0], hostname, remote, key)
+		if errAppend != nil {
+			return fmt.Errorf("error adding host key of %q to known_hosts file %q: %w", hostname, files[0], errAppend)
+		}
+		return nil
+	}, nil
+}
+
+// hostKeyAlgorithms returns the types of the host keys known for the address by the callback.
+// The Go client prefers ECDSA host keys, so without these the server may present a key of a type that is not known,
+// while OpenSSH adds ed25519 keys to known_hosts files by default.
+// If the callback does not use known_hosts files or the host is unknown, then returns nil.
+func hostKeyAlgorithms(callback ssh.HostKeyCallback, address string) []string {
+	if callback == nil {
+		return nil
+	}
+	var keyError *knownhosts.KeyError
+	if err := callback(address, &net.TCPAddr{}, probeKey()); !errors.As(err, &keyError) || len(keyError.Want) == 0 {
+		return nil
+	}
+	algorithms := make([]string, 0, len(keyError.Want))
+	for _, knownKey := range keyError.Want {
+		algorithms = append(algorithms, knownKey.Key.Type())
+	}
+	return algorithms
+}
+
+var (
+	probeKeyOnce  sync.Once
+	probeKeyValue ssh.PublicKey
+)
+
+// probeKey returns a random public key used to look up the known host keys for an address.
+func probeKey() ssh.PublicKey {
+	probeKeyOnce.Do(func() {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			panic(fmt.Errorf("error generating probe key: %w", err))
+		}
+		probeKeyValue, err = ssh.NewPublicKey(publicKey)
+		if err != nil {
+			panic(fmt.Errorf("error creating probe key: %w", err))
+		}
+	})
+	return probeKeyValue
+}
+
+// matchFingerprint returns true if the key has the SHA256 or legacy MD5 fingerprint.
+func matchFingerprint(fingerprint string, key ssh.PublicKey) bool {
+	if strings.HasPrefix(fingerprint, "MD5:") {
+		return strings.EqualFold(fingerprint[len("MD5:"):], ssh.FingerprintLegacyMD5(key))
+	}
+	if strings.HasPrefix(fingerprint, "SHA256:") {
+		return strings.TrimRight(fingerprint, "=") == ssh.FingerprintSHA256(key)
+	}
+	if strings.Count(fingerprint, ":") == 15 {
+		return strings.EqualFold(fingerprint, ssh.FingerprintLegacyMD5(key))
+	}
+	return "SHA256:"+strings.TrimRight(fingerprint, "=") == ssh.FingerprintSHA256(key)
+}
+
+// newKnownHostsCallback returns a callback for the known_hosts files that exist.
+func newKnownHostsCallback(files []string) (ssh.HostKeyCallback, error) {
+	existing := make([]string, 0, len(files))
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading known_hosts file %q: %w", file, err)
+		}
+		existing = append(existing, file)
+	}
+	callback, err := knownhosts.New(existing...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading known_hosts files: %w", err)
+	}
+	return callback, nil
+}
+
+// appendKnownHost appends the host key to the known_hosts file, creating the file and its parent directory as needed.
+func appendKnownHost(file string, hostname string, remote net.Addr, key ssh.PublicKey) error {
+	err := os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	addresses := []string{hostname}
+	if remote != nil && remote.String() != hostname {
+		addresses = append(addresses, remote.String())
+	}
+	_, err = fmt.Fprintln(f, knownhosts.Line(addresses, key))
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
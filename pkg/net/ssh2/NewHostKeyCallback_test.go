@@ -0,0 +1,131 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+func dialWithHostKey(server *ssh2test.Server, input *NewHostKeyCallbackInput) error {
+	hostKeyCallback, err := NewHostKeyCallback(input)
+	if err != nil {
+		return err
+	}
+	client, err := Dial(server.URI(SchemeSFTP, "tmp"), func(config *ClientConfig) error {
+		config.Auth = []ssh.AuthMethod{ssh.Password(server.Password)}
+		config.HostKeyCallback = hostKeyCallback
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return client.Close()
+}
+
+func newHostKey(t *testing.T) ssh.Signer {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	require.NoError(t, err)
+	return signer
+}
+
+func TestNewHostKeyCallbackKnownHosts(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+
+	// the known_hosts file does not exist
+	err := dialWithHostKey(server, &NewHostKeyCallbackInput{KnownHostsFiles: []string{knownHostsFile}})
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(server.KnownHostsLine()+"\n"), 0600))
+	err = dialWithHostKey(server, &NewHostKeyCallbackInput{KnownHostsFiles: []string{knownHostsFile}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{ssh.KeyAlgoED25519}, hostKeyAlgorithms(mustNewHostKeyCallback(t, knownHostsFile), server.Addr()))
+	assert.Nil(t, hostKeyAlgorithms(mustNewHostKeyCallback(t, knownHostsFile), "example.com:22"))
+
+	// the host key has changed, since the known host has a different key
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(knownhosts.Line([]string{server.Addr()}, newHostKey(t).PublicKey())+"\n"), 0600))
+	err = dialWithHostKey(server, &NewHostKeyCallbackInput{KnownHostsFiles: []string{knownHostsFile}, AcceptNew: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "man-in-the-middle")
+
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte("invalid\n"), 0600))
+	_, err = NewHostKeyCallback(&NewHostKeyCallbackInput{KnownHostsFiles: []string{knownHostsFile}})
+	assert.Error(t, err)
+}
+
+func mustNewHostKeyCallback(t *testing.T, knownHostsFile string) ssh.HostKeyCallback {
+	hostKeyCallback, err := NewHostKeyCallback(&NewHostKeyCallbackInput{KnownHostsFiles: []string{knownHostsFile}})
+	require.NoError(t, err)
+	return hostKeyCallback
+}
+
+func TestNewHostKeyCallbackAcceptNew(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	knownHostsFile := filepath.Join(t.TempDir(), "ssh", "known_hosts")
+
+	input := &NewHostKeyCallbackInput{KnownHostsFiles: []string{knownHostsFile}, AcceptNew: true}
+	require.NoError(t, dialWithHostKey(server, input))
+	require.NoError(t, dialWithHostKey(server, input))
+
+	b, err := os.ReadFile(knownHostsFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(b), "\n"))
+	assert.Contains(t, string(b), knownhosts.Normalize(server.Addr()))
+
+	// the host key has changed, since the known host has a different key
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(knownhosts.Line([]string{server.Addr()}, newHostKey(t).PublicKey())+"\n"), 0600))
+	assert.Error(t, dialWithHostKey(server, input))
+}
+
+func TestNewHostKeyCallbackFingerprint(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	publicKey := server.HostKey.PublicKey()
+	for _, fingerprint := range []string{
+		ssh.FingerprintSHA256(publicKey),
+		strings.TrimPrefix(ssh.FingerprintSHA256(publicKey), "SHA256:"),
+		"MD5:" + ssh.FingerprintLegacyMD5(publicKey),
+		strings.ToUpper(ssh.FingerprintLegacyMD5(publicKey)),
+	} {
+		assert.NoError(t, dialWithHostKey(server, &NewHostKeyCallbackInput{Fingerprint: fingerprint}), fingerprint)
+	}
+
+	otherKey := newHostKey(t).PublicKey()
+	assert.Error(t, dialWithHostKey(server, &NewHostKeyCallbackInput{Fingerprint: ssh.FingerprintSHA256(otherKey)}))
+	assert.Error(t, dialWithHostKey(server, &NewHostKeyCallbackInput{Fingerprint: "MD5:" + ssh.FingerprintLegacyMD5(otherKey)}))
+}
+
+func TestNewHostKeyCallbackInsecure(t *testing.T) {
+	server := ssh2test.NewServer()
+	defer server.Close()
+
+	assert.NoError(t, dialWithHostKey(server, &NewHostKeyCallbackInput{
+		KnownHostsFiles: []string{filepath.Join(t.TempDir(), "known_hosts")},
+		Insecure:        true,
+	}))
+}
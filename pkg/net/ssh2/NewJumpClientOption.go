@@ -0,0 +1,22 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"golang.org/x/crypto/ssh"
+)
+
+// NewJumpClientOption returns a client option that dials the server through an existing client,
+// such as a client connected to a bastion host.
+// The existing client is not closed when the new client is closed.
+func NewJumpClientOption(client *ssh.Client) ClientOption {
+	return func(config *ClientConfig) error {
+		config.JumpClient = client
+		return nil
+	}
+}
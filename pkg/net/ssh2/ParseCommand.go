@@ -0,0 +1,32 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParseCommand returns the command in the "cmd" query parameter of a SSH command URI,
+// e.g., "ssh+cmd://user@host?cmd=journalctl%20-o%20json" returns "journalctl -o json".
+func ParseCommand(uri string) (string, error) {
+	i := strings.Index(uri, "?")
+	if i == -1 {
+		return "", fmt.Errorf("uri %q is missing the cmd query parameter", uri)
+	}
+	query, err := url.ParseQuery(uri[i+1:])
+	if err != nil {
+		return "", fmt.Errorf("error parsing query of uri %q: %w", uri, err)
+	}
+	command := query.Get("cmd")
+	if len(command) == 0 {
+		return "", fmt.Errorf("uri %q is missing the cmd query parameter", uri)
+	}
+	return command, nil
+}
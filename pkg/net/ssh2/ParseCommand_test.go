@@ -0,0 +1,30 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	command, err := ParseCommand("ssh+cmd://user@example.com?cmd=journalctl%20-o%20json")
+	require.NoError(t, err)
+	assert.Equal(t, "journalctl -o json", command)
+
+	command, err = ParseCommand("ssh+cmd://example.com:2222/?cmd=cat+/var/log/syslog")
+	require.NoError(t, err)
+	assert.Equal(t, "cat /var/log/syslog", command)
+
+	_, err = ParseCommand("ssh+cmd://example.com")
+	assert.Error(t, err)
+	_, err = ParseCommand("ssh+cmd://example.com?command=ls")
+	assert.Error(t, err)
+}
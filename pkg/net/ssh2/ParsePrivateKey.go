@@ -0,0 +1,51 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ParsePrivateKeyInput contains the input parameters for ParsePrivateKey.
+type ParsePrivateKeyInput struct {
+	PrivateKey         []byte                 // PEM-encoded private key, which may be encrypted
+	Passphrase         []byte                 // passphrase of an encrypted private key
+	PassphraseProvider func() ([]byte, error) // if the private key is encrypted and the passphrase is not set, then called for the passphrase, such as by prompting the user
+}
+
+// ParsePrivateKey parses a private key, which may be protected by a passphrase.
+// The passphrase provider is only called if the private key is encrypted and the passphrase is not set.
+func ParsePrivateKey(input *ParsePrivateKeyInput) (PrivateKey, error) {
+	key, err := ssh.ParsePrivateKey(input.PrivateKey)
+	if err == nil {
+		return key, nil
+	}
+	var passphraseMissingError *ssh.PassphraseMissingError
+	if !errors.As(err, &passphraseMissingError) {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+	passphrase := input.Passphrase
+	if len(passphrase) == 0 {
+		if input.PassphraseProvider == nil {
+			return nil, fmt.Errorf("error parsing private key: private key is encrypted, but no passphrase was provided")
+		}
+		p, errPassphrase := input.PassphraseProvider()
+		if errPassphrase != nil {
+			return nil, fmt.Errorf("error reading passphrase for private key: %w", errPassphrase)
+		}
+		passphrase = p
+	}
+	key, err = ssh.ParsePrivateKeyWithPassphrase(input.PrivateKey, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing encrypted private key: %w", err)
+	}
+	return key, nil
+}
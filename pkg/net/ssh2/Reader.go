@@ -0,0 +1,87 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Reader implements the io.ReadCloser interface to enable reading
+// the standard output of a command run on a SSH server and closing the underlying connection.
+type Reader struct {
+	command   string
+	session   *ssh.Session
+	stdout    io.Reader
+	stderr    *bytes.Buffer
+	sshClient *ssh.Client
+	eof       bool
+}
+
+// Read implements the io.Reader interface.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.stdout.Read(p)
+	if err == io.EOF {
+		r.eof = true
+	}
+	return n, err
+}
+
+// Close closes the session and the SSH connection.
+// If all the output was read, then returns an error if the command failed.
+func (r *Reader) Close() error {
+	var err error
+	if r.eof {
+		// the command has written all its output, so wait for the exit status
+		if errWait := r.session.Wait(); errWait != nil {
+			err = newCommandError(r.command, errWait, r.stderr.String())
+		}
+	}
+	_ = r.session.Close()
+	if r.sshClient != nil {
+		if errClose := r.sshClient.Close(); errClose != nil && err == nil {
+			err = fmt.Errorf("error closing SSH client connection: %w", errClose)
+		}
+	}
+	return err
+}
+
+// NewReader starts the command on the SSH server and returns a Reader for the standard output.
+// The SSH client is closed when the reader is closed.
+func NewReader(sshClient *ssh.Client, command string) (*Reader, error) {
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSH session: %w", err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		_ = session.Close()
+		return nil, fmt.Errorf("error creating pipe for standard output: %w", err)
+	}
+	stderr := &bytes.Buffer{}
+	session.Stderr = stderr
+	err = session.Start(command)
+	if err != nil {
+		_ = session.Close()
+		return nil, fmt.Errorf("error starting command %q: %w", command, err)
+	}
+	return &Reader{command: command, session: session, stdout: stdout, stderr: stderr, sshClient: sshClient}, nil
+}
+
+// NewFileReader returns a Reader for the file at the path on the SSH server, starting at the offset.
+// The file is read with cat, or tail if the offset is greater than zero.
+// Relative paths are relative to the home directory of the user.
+func NewFileReader(sshClient *ssh.Client, p string, offset int64) (*Reader, error) {
+	if offset > 0 {
+		return NewReader(sshClient, fmt.Sprintf("tail -c +%d -- %s", offset+1, quote(p)))
+	}
+	return NewReader(sshClient, "cat -- "+quote(p))
+}
@@ -0,0 +1,98 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/ssh2/ssh2test"
+)
+
+// newCommandServer returns a new server that only allows commands.
+func newCommandServer() *ssh2test.Server {
+	return ssh2test.NewServer(func(s *ssh2test.Server) {
+		s.DisableSFTP = true
+	})
+}
+
+// dialCommandServer returns a client for a server created by newCommandServer.
+func dialCommandServer(t *testing.T, server *ssh2test.Server) *ssh.Client {
+	client, err := Dial(server.URI(SchemeSSH, ""), func(config *ClientConfig) error {
+		config.Auth = []ssh.AuthMethod{ssh.Password(server.Password)}
+		config.HostKeyCallback = ssh.FixedHostKey(server.HostKey.PublicKey())
+		return nil
+	})
+	require.NoError(t, err)
+	return client.Client
+}
+
+func TestNewFileReader(t *testing.T) {
+	server := newCommandServer()
+	defer server.Close()
+
+	// the quote in the name is escaped
+	p := filepath.Join(t.TempDir(), "it's -hello.txt")
+	require.NoError(t, os.WriteFile(p, []byte("hello world"), 0600))
+
+	r, err := NewFileReader(dialCommandServer(t, server), p, 0)
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+	assert.NoError(t, r.Close())
+
+	r, err = NewFileReader(dialCommandServer(t, server), p, 6)
+	require.NoError(t, err)
+	b, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(b))
+	assert.NoError(t, r.Close())
+
+	r, err = NewFileReader(dialCommandServer(t, server), p+".missing", 0)
+	require.NoError(t, err)
+	b, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Empty(t, b)
+	err = r.Close()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exited with status 1")
+
+	client := dialCommandServer(t, server)
+	defer client.Close()
+	assert.NoError(t, CheckFileRead(client, p))
+	assert.Error(t, CheckFileRead(client, p+".missing"))
+	assert.Error(t, CheckFileRead(client, filepath.Dir(p)))
+}
+
+func TestNewReader(t *testing.T) {
+	server := newCommandServer()
+	defer server.Close()
+
+	r, err := NewReader(dialCommandServer(t, server), "echo hello; echo world")
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", string(b))
+	assert.NoError(t, r.Close())
+
+	r, err = NewReader(dialCommandServer(t, server), "echo partial; echo failed >&2; exit 3")
+	require.NoError(t, err)
+	b, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "partial\n", string(b))
+	err = r.Close()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exited with status 3: failed")
+}
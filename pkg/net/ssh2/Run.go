@@ -0,0 +1,58 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Run runs the command on the SSH server and returns the standard output.
+// If the command fails, then the error includes the standard error of the command.
+func Run(client *ssh.Client, command string) ([]byte, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSH session: %w", err)
+	}
+	defer session.Close()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	session.Stdout = stdout
+	session.Stderr = stderr
+	err = session.Run(command)
+	if err != nil {
+		return nil, newCommandError(command, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// newCommandError returns an error for a command that failed on a SSH server,
+// including the standard error of the command.
+func newCommandError(command string, err error, stderr string) error {
+	stderr = strings.TrimSpace(stderr)
+	var exitError *ssh.ExitError
+	if errors.As(err, &exitError) {
+		if len(stderr) > 0 {
+			return fmt.Errorf("command %q exited with status %d: %s", command, exitError.ExitStatus(), stderr)
+		}
+		return fmt.Errorf("command %q exited with status %d", command, exitError.ExitStatus())
+	}
+	if len(stderr) > 0 {
+		return fmt.Errorf("error running command %q: %w: %s", command, err, stderr)
+	}
+	return fmt.Errorf("error running command %q: %w", command, err)
+}
+
+// quote returns the string quoted for a POSIX shell, so that it is passed as a single argument.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
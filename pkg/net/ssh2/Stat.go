@@ -0,0 +1,117 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Stat returns the file info of the file at the path on the SSH server using the stat command,
+// which supports both the GNU and BSD variants of stat.  Symbolic links are followed.
+// Returns a bool indicating whether the file exists, the file info, and an error if any.
+// If the file does not exist, then the error is supressed and returns false, nil, nil
+func Stat(client *ssh.Client, p string) (bool, os.FileInfo, error) {
+	command := fmt.Sprintf(
+		"if test -e %s; then stat -L -c '%%f %%s %%Y' %s 2>/dev/null || stat -L -f '%%Xp %%z %%m' %s; fi",
+		quote(p), quote(p), quote(p))
+	b, err := Run(client, command)
+	if err != nil {
+		return false, nil, fmt.Errorf("error stating file at path %q: %w", p, err)
+	}
+	output := strings.TrimSpace(string(b))
+	if len(output) == 0 {
+		return false, nil, nil
+	}
+	fields := strings.Fields(output)
+	if len(fields) != 3 {
+		return false, nil, fmt.Errorf("error stating file at path %q: unexpected output %q", p, output)
+	}
+	mode, err := strconv.ParseUint(fields[0], 16, 32)
+	if err != nil {
+		return false, nil, fmt.Errorf("error parsing mode %q of file at path %q: %w", fields[0], p, err)
+	}
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return false, nil, fmt.Errorf("error parsing size %q of file at path %q: %w", fields[1], p, err)
+	}
+	modTime, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return false, nil, fmt.Errorf("error parsing last modified time %q of file at path %q: %w", fields[2], p, err)
+	}
+	return true, &fileInfo{
+		name:    path.Base(p),
+		size:    size,
+		mode:    fileMode(uint32(mode)),
+		modTime: time.Unix(modTime, 0),
+	}, nil
+}
+
+// fileMode returns the os.FileMode for the mode of a file in the format of st_mode.
+func fileMode(mode uint32) os.FileMode {
+	m := os.FileMode(mode & 0777)
+	switch mode & 0170000 {
+	case 0040000:
+		m |= os.ModeDir
+	case 0120000:
+		m |= os.ModeSymlink
+	case 0010000:
+		m |= os.ModeNamedPipe
+	case 0140000:
+		m |= os.ModeSocket
+	case 0020000:
+		m |= os.ModeDevice | os.ModeCharDevice
+	case 0060000:
+		m |= os.ModeDevice
+	}
+	return m
+}
+
+// fileInfo implements the os.FileInfo interface for a file on a SSH server.
+type fileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// Name returns the base name of the file.
+func (fi *fileInfo) Name() string {
+	return fi.name
+}
+
+// Size returns the length in bytes of the file.
+func (fi *fileInfo) Size() int64 {
+	return fi.size
+}
+
+// Mode returns the file mode.
+func (fi *fileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+
+// ModTime returns the last modified time of the file.
+func (fi *fileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+
+// IsDir returns true if the file is a directory.
+func (fi *fileInfo) IsDir() bool {
+	return fi.mode.IsDir()
+}
+
+// Sys returns nil.
+func (fi *fileInfo) Sys() interface{} {
+	return nil
+}
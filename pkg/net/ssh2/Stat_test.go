@@ -0,0 +1,49 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStat(t *testing.T) {
+	server := newCommandServer()
+	defer server.Close()
+
+	client := dialCommandServer(t, server)
+	defer client.Close()
+
+	dir := t.TempDir()
+	p := filepath.Join(dir, "it's hello.txt")
+	require.NoError(t, os.WriteFile(p, []byte("hello world"), 0640))
+	expected, err := os.Stat(p)
+	require.NoError(t, err)
+
+	exists, fi, err := Stat(client, p)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "it's hello.txt", fi.Name())
+	assert.Equal(t, int64(11), fi.Size())
+	assert.Equal(t, os.FileMode(0640), fi.Mode())
+	assert.Equal(t, expected.ModTime().Unix(), fi.ModTime().Unix())
+
+	exists, fi, err = Stat(client, dir)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.True(t, fi.IsDir())
+
+	exists, fi, err = Stat(client, p+".missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.Nil(t, fi)
+}
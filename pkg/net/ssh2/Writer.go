@@ -0,0 +1,94 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Writer implements the io.WriteCloser interface to enable writing
+// to the standard input of a command run on a SSH server.
+// The SSH connection is not closed when the writer is closed.
+type Writer struct {
+	command string
+	session *ssh.Session
+	stdin   io.WriteCloser
+	stderr  *bytes.Buffer
+}
+
+// Write implements the io.Writer interface.
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.stdin.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("error writing to command %q: %w", w.command, err)
+	}
+	return n, nil
+}
+
+// Close closes the standard input, waits for the command to exit, and closes the session.
+// Returns an error if the command failed.
+func (w *Writer) Close() error {
+	err := w.stdin.Close()
+	if err != nil {
+		_ = w.session.Close()
+		return fmt.Errorf("error closing standard input of command %q: %w", w.command, err)
+	}
+	err = w.session.Wait()
+	_ = w.session.Close()
+	if err != nil {
+		return newCommandError(w.command, err, w.stderr.String())
+	}
+	return nil
+}
+
+// NewWriter starts the command on the SSH server and returns a Writer for the standard input.
+// The standard output of the command is discarded.
+func NewWriter(sshClient *ssh.Client, command string) (*Writer, error) {
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSH session: %w", err)
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		_ = session.Close()
+		return nil, fmt.Errorf("error creating pipe for standard input: %w", err)
+	}
+	stderr := &bytes.Buffer{}
+	session.Stderr = stderr
+	err = session.Start(command)
+	if err != nil {
+		_ = session.Close()
+		return nil, fmt.Errorf("error starting command %q: %w", command, err)
+	}
+	return &Writer{command: command, session: session, stdin: stdin, stderr: stderr}, nil
+}
+
+// NewFileWriter returns a Writer for the file at the path on the SSH server, which is written with cat.
+// If appendToFile is true, then the data is appended to the file, otherwise the file is truncated.
+// If parents is true, then the parent directories are created as needed.
+// If mode is not zero, then the mode of the file is changed once all the data is written.
+// Relative paths are relative to the home directory of the user.
+func NewFileWriter(sshClient *ssh.Client, p string, appendToFile bool, parents bool, mode uint32) (*Writer, error) {
+	redirect := ">"
+	if appendToFile {
+		redirect = ">>"
+	}
+	command := fmt.Sprintf("cat %s %s", redirect, quote(p))
+	if parents {
+		command = fmt.Sprintf("mkdir -p -- %s && %s", quote(path.Dir(p)), command)
+	}
+	if mode != 0 {
+		command = fmt.Sprintf("%s && chmod %o -- %s", command, mode, quote(p))
+	}
+	return NewWriter(sshClient, command)
+}
@@ -0,0 +1,84 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFileWriter(t *testing.T) {
+	server := newCommandServer()
+	defer server.Close()
+
+	client := dialCommandServer(t, server)
+	defer client.Close()
+
+	p := filepath.Join(t.TempDir(), "a", "b", "hello.txt")
+
+	// the parent directories do not exist
+	w, err := NewFileWriter(client, p, false, false, 0)
+	require.NoError(t, err)
+	_, _ = w.Write([]byte("hello"))
+	assert.Error(t, w.Close())
+
+	w, err = NewFileWriter(client, p, false, true, 0640)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	w, err = NewFileWriter(client, p, true, false, 0)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(" world"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	b, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+	fi, err := os.Stat(p)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), fi.Mode().Perm())
+
+	assert.Error(t, CheckFileWrite(client, p, false, false))
+	assert.NoError(t, CheckFileWrite(client, p, true, false))
+	assert.NoError(t, CheckFileWrite(client, p, false, true))
+	assert.NoError(t, CheckFileWrite(client, p+".new", false, false))
+	assert.NoError(t, CheckFileWrite(client, "/dev/null", false, false))
+}
+
+func TestNewWriter(t *testing.T) {
+	server := newCommandServer()
+	defer server.Close()
+
+	client := dialCommandServer(t, server)
+	defer client.Close()
+
+	p := filepath.Join(t.TempDir(), "upper.txt")
+	w, err := NewWriter(client, "tr a-z A-Z > "+quote(p))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("hello world"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	b, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO WORLD", string(b))
+
+	w, err = NewWriter(client, "cat > /dev/null; echo failed >&2; exit 2")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("hello world"))
+	require.NoError(t, err)
+	err = w.Close()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exited with status 2: failed")
+}
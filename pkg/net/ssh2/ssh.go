@@ -15,6 +15,12 @@ const (
 	DefaultPort    = 22
 	DefaultTimeout = 5 * time.Second
 
-	SchemeSSH  = "ssh"
-	SchemeSFTP = "sftp"
+	MaxJumpHosts = 8
+
+	DefaultConfigFile     = "~/.ssh/config"
+	DefaultKnownHostsFile = "~/.ssh/known_hosts"
+
+	SchemeSSH        = "ssh"
+	SchemeSSHCommand = "ssh+cmd"
+	SchemeSFTP       = "sftp"
 )
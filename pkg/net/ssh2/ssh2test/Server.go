@@ -0,0 +1,310 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Server is an in-process SSH server listening on the loopback interface.
+// Sessions can request the "sftp" subsystem, which serves the local file system.
+// Sessions can also execute commands with the local shell.
+// Clients can also open "direct-tcpip" channels, so the server can be used as a jump host.
+// Since the server serves the local file system, uris should use absolute paths, e.g., sftp://127.0.0.1:2222//tmp/file.
+// The settings are read by the goroutines serving connections, so they must not be changed after NewServer returns.
+// Use a ServerOption to change them instead.
+type Server struct {
+	Listener       net.Listener
+	HostKey        ssh.Signer      // host key of the server
+	User           string          // name of the user allowed to log in
+	Password       string          // if set, then password authentication with this password is allowed
+	AuthorizedKeys []ssh.PublicKey // public keys allowed to log in
+	DisableSFTP    bool            // if true, then the "sftp" subsystem is rejected, like servers that only allow commands
+	mutex          *sync.Mutex
+	conns          map[*ssh.ServerConn]struct{}
+	handshakes     int
+	wg             *sync.WaitGroup
+}
+
+// NewServer starts and returns a new Server with a new ed25519 host key.
+// The user "grw" is allowed to log in with the password "secret".
+// The options are applied before the server starts serving connections.
+// The caller should call Close when finished, to shut it down.
+func NewServer(options ...ServerOption) *Server {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(fmt.Errorf("ssh2test: error generating host key: %w", err))
+	}
+	hostKey, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		panic(fmt.Errorf("ssh2test: error creating host key: %w", err))
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Errorf("ssh2test: failed to listen on a port: %w", err))
+	}
+	s := &Server{
+		Listener: listener,
+		HostKey:  hostKey,
+		User:     "grw",
+		Password: "secret",
+		mutex:    &sync.Mutex{},
+		conns:    map[*ssh.ServerConn]struct{}{},
+		wg:       &sync.WaitGroup{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// Addr returns the address of the server as host:port.
+func (s *Server) Addr() string {
+	return s.Listener.Addr().String()
+}
+
+// URI returns the uri for the absolute path on the server with the given scheme, e.g., sftp://grw@127.0.0.1:2222//tmp/file.
+func (s *Server) URI(scheme string, p string) string {
+	return fmt.Sprintf("%s://%s@%s/%s", scheme, s.User, s.Addr(), p)
+}
+
+// KnownHostsLine returns the line for the server in a known_hosts file.
+func (s *Server) KnownHostsLine() string {
+	return knownhosts.Line([]string{s.Addr()}, s.HostKey.PublicKey())
+}
+
+// Handshakes returns the number of connections that completed the SSH handshake.
+func (s *Server) Handshakes() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.handshakes
+}
+
+// Connections returns the number of open connections that completed the SSH handshake.
+func (s *Server) Connections() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.conns)
+}
+
+// CloseConnections closes all open connections, like a server that drops idle connections, but keeps accepting new connections.
+func (s *Server) CloseConnections() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+}
+
+// Close shuts down the server and closes all open connections.
+func (s *Server) Close() {
+	_ = s.Listener.Close()
+	s.mutex.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mutex.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) config() *ssh.ServerConfig {
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() != s.User {
+				return nil, errors.New("unknown user")
+			}
+			for _, authorizedKey := range s.AuthorizedKeys {
+				if bytes.Equal(authorizedKey.Marshal(), key.Marshal()) {
+					return &ssh.Permissions{}, nil
+				}
+			}
+			return nil, errors.New("unknown public key")
+		},
+	}
+	if len(s.Password) > 0 {
+		config.PasswordCallback = func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() != s.User || string(password) != s.Password {
+				return nil, errors.New("invalid user or password")
+			}
+			return &ssh.Permissions{}, nil
+		}
+	}
+	config.AddHostKey(s.HostKey)
+	return config
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.Listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveConn(conn)
+		}()
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, s.config())
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	s.mutex.Lock()
+	s.conns[serverConn] = struct{}{}
+	s.handshakes++
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, serverConn)
+		s.mutex.Unlock()
+		_ = serverConn.Close()
+	}()
+	go ssh.DiscardRequests(requests)
+	wg := &sync.WaitGroup{}
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			channel, channelRequests, errAccept := newChannel.Accept()
+			if errAccept != nil {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.serveSession(channel, channelRequests)
+			}()
+		case "direct-tcpip":
+			wg.Add(1)
+			go func(newChannel ssh.NewChannel) {
+				defer wg.Done()
+				s.serveDirectTCPIP(newChannel)
+			}(newChannel)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+		}
+	}
+	wg.Wait()
+}
+
+// serveDirectTCPIP forwards a channel to the requested address.
+func (s *Server) serveDirectTCPIP(newChannel ssh.NewChannel) {
+	var payload struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid payload")
+		return
+	}
+	conn, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	// once either side is closed, close both
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(channel, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, channel)
+		done <- struct{}{}
+	}()
+	<-done
+	_ = channel.Close()
+	_ = conn.Close()
+	<-done
+}
+
+func (s *Server) serveSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for request := range requests {
+		switch request.Type {
+		case "subsystem":
+			var payload struct{ Name string }
+			if err := ssh.Unmarshal(request.Payload, &payload); err != nil || payload.Name != "sftp" || s.DisableSFTP {
+				_ = request.Reply(false, nil)
+				continue
+			}
+			_ = request.Reply(true, nil)
+			server, err := sftp.NewServer(channel)
+			if err != nil {
+				return
+			}
+			_ = server.Serve()
+			_ = server.Close()
+			return
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(request.Payload, &payload); err != nil {
+				_ = request.Reply(false, nil)
+				continue
+			}
+			_ = request.Reply(true, nil)
+			go ssh.DiscardRequests(requests)
+			s.exec(channel, payload.Command)
+			return
+		default:
+			_ = request.Reply(false, nil)
+		}
+	}
+}
+
+// exec runs the command with the local shell, connecting the standard streams to the channel,
+// and then sends the exit status of the command.
+func (s *Server) exec(channel ssh.Channel, command string) {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = channel
+	cmd.Stderr = channel.Stderr()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return
+	}
+	go func() {
+		_, _ = io.Copy(stdin, channel)
+		_ = stdin.Close()
+	}()
+	status := uint32(0)
+	if errRun := cmd.Run(); errRun != nil {
+		status = 127
+		var exitError *exec.ExitError
+		if errors.As(errRun, &exitError) && exitError.ExitCode() >= 0 {
+			status = uint32(exitError.ExitCode())
+		}
+	}
+	_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{Status: status}))
+}
@@ -0,0 +1,11 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package ssh2test
+
+// ServerOption is a function that configures a Server before it starts serving connections.
+type ServerOption func(s *Server)
@@ -0,0 +1,9 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+// Package ssh2test provides an in-process SSH server with a SFTP subsystem for testing.
+package ssh2test
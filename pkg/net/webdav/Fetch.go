@@ -0,0 +1,30 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"io"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// Fetch returns a Reader for a resource at the given WebDAV address.
+// Fetch returns the Reader and error, if any.
+//
+// Fetch returns an error if the address cannot be reached,
+// the userinfo cannot be parsed,
+// the user and password are invalid,
+// the file cannot be retrieved, or
+// the server responds with a status code other than 2xx.
+func Fetch(uri string, options ...http.ClientOption) (io.ReadCloser, error) {
+	response, err := Get(&http.GetInput{URI: uri, Options: options})
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
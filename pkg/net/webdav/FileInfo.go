@@ -0,0 +1,102 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileInfo implements the os.FileInfo interface for a resource on a WebDAV server.
+type FileInfo struct {
+	name        string
+	size        int64
+	modTime     time.Time
+	dir         bool
+	etag        string
+	contentType string
+}
+
+// Name returns the base name of the resource.
+func (f *FileInfo) Name() string {
+	return f.name
+}
+
+// Size returns the length in bytes of the resource.
+func (f *FileInfo) Size() int64 {
+	return f.size
+}
+
+// Mode returns the file mode.
+// WebDAV does not expose permissions, so collections are reported as 0755 directories and all other resources as 0644 files.
+func (f *FileInfo) Mode() os.FileMode {
+	if f.dir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+
+// ModTime returns the last modified time of the resource.
+func (f *FileInfo) ModTime() time.Time {
+	return f.modTime
+}
+
+// IsDir returns true if the resource is a collection.
+func (f *FileInfo) IsDir() bool {
+	return f.dir
+}
+
+// Sys returns nil.
+func (f *FileInfo) Sys() interface{} {
+	return nil
+}
+
+// ETag returns the entity tag of the resource, if any.
+func (f *FileInfo) ETag() string {
+	return f.etag
+}
+
+// ContentType returns the content type of the resource, if any.
+func (f *FileInfo) ContentType() string {
+	return f.contentType
+}
+
+// newFileInfo returns the file info from the successful properties in the response.
+func newFileInfo(r response) *FileInfo {
+	href := r.Href
+	if u, err := url.Parse(href); err == nil {
+		href = u.Path
+	}
+	fi := &FileInfo{name: path.Base(strings.TrimSuffix(href, "/"))}
+	for _, ps := range r.Propstats {
+		if !strings.Contains(ps.Status, " 200 ") {
+			continue
+		}
+		if ps.Prop.ResourceType.Collection != nil {
+			fi.dir = true
+		}
+		if n, err := strconv.ParseInt(strings.TrimSpace(ps.Prop.ContentLength), 10, 64); err == nil {
+			fi.size = n
+		}
+		if t, err := http.ParseTime(strings.TrimSpace(ps.Prop.LastModified)); err == nil {
+			fi.modTime = t
+		}
+		if len(ps.Prop.ETag) > 0 {
+			fi.etag = ps.Prop.ETag
+		}
+		if len(ps.Prop.ContentType) > 0 {
+			fi.contentType = ps.Prop.ContentType
+		}
+	}
+	return fi
+}
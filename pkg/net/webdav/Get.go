@@ -0,0 +1,31 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	stdhttp "net/http"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// Get sends a GET request for a resource at the given WebDAV address and returns the response.
+// The URI of the input uses the webdav or webdavs scheme.
+// See http.Get for how the offset and IfRange validator are handled.
+func Get(input *http.GetInput) (*stdhttp.Response, error) {
+	httpURI, err := HTTPURI(input.URI)
+	if err != nil {
+		return nil, err
+	}
+	return http.Get(&http.GetInput{
+		URI:     httpURI,
+		Offset:  input.Offset,
+		IfRange: input.IfRange,
+		Options: input.Options,
+		Context: input.Context,
+	})
+}
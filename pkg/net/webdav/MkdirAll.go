@@ -0,0 +1,64 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"context"
+	"fmt"
+	stdhttp "net/http"
+	"strings"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// MkdirAll creates the collection at the given WebDAV address, along with any necessary parents.
+// Starting from the collection, MkdirAll walks upward with PROPFIND requests until it finds a collection that exists,
+// and then creates the missing collections downward using MKCOL requests.
+// So no requests are sent for the collections above the first collection that exists,
+// which servers may not allow access to.
+func MkdirAll(ctx context.Context, uri string, options ...http.ClientOption) error {
+	scheme, fullpath := splitPath(uri)
+	parts := []string{}
+	for _, part := range strings.Split(fullpath, "/") {
+		if len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	collection := func(n int) string {
+		return scheme + "/" + strings.Join(append(parts[:n:n], ""), "/")
+	}
+	// find the deepest collection that exists
+	n := len(parts)
+	for ; n > 0; n-- {
+		exists, info, err := Stat(ctx, collection(n), options...)
+		if err != nil {
+			return fmt.Errorf("error creating collection at uri %q: %w", uri, err)
+		}
+		if exists {
+			if !info.IsDir() {
+				return fmt.Errorf("error creating collection at uri %q: resource at uri %q is not a collection", uri, collection(n))
+			}
+			break
+		}
+	}
+	for n++; n <= len(parts); n++ {
+		response, err := do(ctx, "MKCOL", collection(n), nil, nil, options)
+		if err != nil {
+			return fmt.Errorf("error creating collection at uri %q: %w", uri, err)
+		}
+		_ = response.Body.Close()
+		switch response.StatusCode {
+		case stdhttp.StatusCreated, stdhttp.StatusOK:
+		case stdhttp.StatusMethodNotAllowed:
+			// MKCOL is not allowed on resources that already exist, such as a collection created concurrently.
+		default:
+			return fmt.Errorf("error creating collection at uri %q: %w", collection(n), &http.ErrUnexpectedStatus{URI: collection(n), StatusCode: response.StatusCode})
+		}
+	}
+	return nil
+}
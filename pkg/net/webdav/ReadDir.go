@@ -0,0 +1,45 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// ReadDir returns the file info for the members of the collection at the given WebDAV address,
+// using a PROPFIND request with a depth of 1.
+// The collection itself is not included.
+func ReadDir(ctx context.Context, uri string, options ...http.ClientOption) ([]*FileInfo, error) {
+	responses, err := propfind(ctx, uri, "1", options)
+	if err != nil {
+		return nil, fmt.Errorf("error listing collection at uri %q: %w", uri, err)
+	}
+	httpURI, err := HTTPURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	_, p := splitPath(httpURI)
+	self := strings.TrimSuffix(p, "/")
+	entries := make([]*FileInfo, 0, len(responses))
+	for _, r := range responses {
+		href := r.Href
+		if u, errParse := url.Parse(href); errParse == nil {
+			href = u.Path
+		}
+		if strings.TrimSuffix(href, "/") == self {
+			continue
+		}
+		entries = append(entries, newFileInfo(r))
+	}
+	return entries, nil
+}
@@ -0,0 +1,29 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// Remove deletes the resource at the given WebDAV address using a DELETE request.
+// If the resource is a collection, then the server deletes the collection and all its members.
+func Remove(ctx context.Context, uri string, options ...http.ClientOption) error {
+	response, err := do(ctx, "DELETE", uri, nil, nil, options)
+	if err != nil {
+		return fmt.Errorf("error deleting resource at uri %q: %w", uri, err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("error deleting resource at uri %q: %w", uri, &http.ErrUnexpectedStatus{URI: uri, StatusCode: response.StatusCode})
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"context"
+	"fmt"
+	stdhttp "net/http"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// Stat stats the resource at the given WebDAV address using a PROPFIND request.
+// Returns a bool indicating whether the resource exists, file info, and an error if any.
+// If the server responds with 404 Not Found, then the error is supressed and returns false, nil, nil
+func Stat(ctx context.Context, uri string, options ...http.ClientOption) (bool, *FileInfo, error) {
+	responses, err := propfind(ctx, uri, "0", options)
+	if err != nil {
+		if isStatus(err, stdhttp.StatusNotFound) {
+			return false, nil, nil
+		}
+		return false, nil, fmt.Errorf("error stating resource at uri %q: %w", uri, err)
+	}
+	if len(responses) == 0 {
+		return false, nil, fmt.Errorf("error stating resource at uri %q: PROPFIND response is empty", uri)
+	}
+	return true, newFileInfo(responses[0]), nil
+}
@@ -0,0 +1,47 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+)
+
+// WriteFile returns a Writer that uploads a resource to the given WebDAV address using a PUT request.
+// If the resource already exists, then it is replaced.
+// The parent collection must already exist; see MkdirAll.
+// If the context is cancelled before the writer is closed, then the request is aborted.
+func WriteFile(ctx context.Context, uri string, options ...http.ClientOption) (*Writer, error) {
+	if _, err := HTTPURI(uri); err != nil {
+		return nil, err
+	}
+	pipeReader, pipeWriter := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		response, err := do(ctx, "PUT", uri, nil, pipeReader, options)
+		if err != nil {
+			err = fmt.Errorf("error writing resource at uri %q: %w", uri, err)
+			_ = pipeReader.CloseWithError(err)
+			done <- err
+			return
+		}
+		_ = response.Body.Close()
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			err = fmt.Errorf("error writing resource at uri %q: %w", uri, &http.ErrUnexpectedStatus{URI: uri, StatusCode: response.StatusCode})
+			_ = pipeReader.CloseWithError(err)
+			done <- err
+			return
+		}
+		_ = pipeReader.Close()
+		done <- nil
+	}()
+	return &Writer{pipeWriter: pipeWriter, done: done}, nil
+}
@@ -0,0 +1,42 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"io"
+)
+
+// Writer implements the io.WriteCloser interface to enable writing
+// a resource on a WebDAV server with a streaming PUT request.
+// The request completes when the writer is closed.
+type Writer struct {
+	pipeWriter *io.PipeWriter
+	done       chan error
+}
+
+// Write implements the io.Writer interface.
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.pipeWriter.Write(p)
+}
+
+// Close closes the body of the request and waits for the response from the server.
+func (w *Writer) Close() error {
+	err := w.pipeWriter.Close()
+	if err != nil {
+		return err
+	}
+	return <-w.done
+}
+
+// CloseWithError aborts the request with the error, instead of completing it, and waits for the request to finish.
+// The server may have already created an empty resource, depending on when it received the request.
+func (w *Writer) CloseWithError(err error) error {
+	_ = w.pipeWriter.CloseWithError(err)
+	<-w.done
+	return nil
+}
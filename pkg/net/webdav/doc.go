@@ -0,0 +1,10 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+// Package webdav provides functions for interacting with a WebDAV server.
+// The webdav and webdavs schemes are mapped to http and https, respectively.
+package webdav
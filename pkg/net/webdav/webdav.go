@@ -0,0 +1,135 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package webdav
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	stdhttp "net/http"
+	"strings"
+
+	"github.com/spatialcurrent/go-reader-writer/pkg/net/http"
+	"github.com/spatialcurrent/go-reader-writer/pkg/schemes"
+	"github.com/spatialcurrent/go-reader-writer/pkg/splitter"
+)
+
+// propfindBody is the body of PROPFIND requests, which requests only the properties used by FileInfo.
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<D:propfind xmlns:D="DAV:">
+  <D:prop>
+    <D:resourcetype/>
+    <D:getcontentlength/>
+    <D:getlastmodified/>
+    <D:getetag/>
+    <D:getcontenttype/>
+  </D:prop>
+</D:propfind>`
+
+type multistatus struct {
+	XMLName   xml.Name   `xml:"DAV: multistatus"`
+	Responses []response `xml:"DAV: response"`
+}
+
+type response struct {
+	Href      string     `xml:"DAV: href"`
+	Propstats []propstat `xml:"DAV: propstat"`
+}
+
+type propstat struct {
+	Status string `xml:"DAV: status"`
+	Prop   prop   `xml:"DAV: prop"`
+}
+
+type prop struct {
+	ResourceType  resourceType `xml:"DAV: resourcetype"`
+	ContentLength string       `xml:"DAV: getcontentlength"`
+	LastModified  string       `xml:"DAV: getlastmodified"`
+	ETag          string       `xml:"DAV: getetag"`
+	ContentType   string       `xml:"DAV: getcontenttype"`
+}
+
+type resourceType struct {
+	Collection *struct{} `xml:"DAV: collection"`
+}
+
+// HTTPURI returns the HTTP address for the given WebDAV address.
+// The webdav scheme is replaced with http and the webdavs scheme is replaced with https.
+func HTTPURI(uri string) (string, error) {
+	scheme, fullpath := splitter.SplitURI(uri)
+	switch scheme {
+	case schemes.SchemeWebDAV:
+		return "http://" + fullpath, nil
+	case schemes.SchemeWebDAVS:
+		return "https://" + fullpath, nil
+	}
+	return "", fmt.Errorf("error parsing uri %q: only schemes %s and %s are supported", uri, schemes.SchemeWebDAV, schemes.SchemeWebDAVS)
+}
+
+// splitPath splits the HTTP address into the prefix with the scheme and authority and the path.
+func splitPath(uri string) (string, string) {
+	scheme, fullpath := splitter.SplitURI(uri)
+	if i := strings.Index(fullpath, "/"); i != -1 {
+		return scheme + "://" + fullpath[0:i], fullpath[i:]
+	}
+	return scheme + "://" + fullpath, "/"
+}
+
+// do sends a request with the given method to the WebDAV address and returns the response.
+func do(ctx context.Context, method string, uri string, header map[string]string, body io.Reader, options []http.ClientOption) (*stdhttp.Response, error) {
+	httpURI, err := HTTPURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	client, err := http.NewClient(options...)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(method, httpURI, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		request.Header.Set(k, v)
+	}
+	response, err := client.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error sending %s request to uri %q: %w", method, uri, err)
+	}
+	return response, nil
+}
+
+// propfind sends a PROPFIND request with the given depth to the WebDAV address and returns the parsed responses.
+// If the resource does not exist, then returns an ErrUnexpectedStatus with status code 404.
+func propfind(ctx context.Context, uri string, depth string, options []http.ClientOption) ([]response, error) {
+	resp, err := do(ctx, "PROPFIND", uri, map[string]string{
+		"Content-Type": "application/xml; charset=utf-8",
+		"Depth":        depth,
+	}, strings.NewReader(propfindBody), options)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != stdhttp.StatusMultiStatus {
+		return nil, &http.ErrUnexpectedStatus{URI: uri, StatusCode: resp.StatusCode}
+	}
+	ms := multistatus{}
+	err = xml.NewDecoder(resp.Body).Decode(&ms)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding PROPFIND response from uri %q: %w", uri, err)
+	}
+	return ms.Responses, nil
+}
+
+// isStatus returns true if the error is an ErrUnexpectedStatus with the given status code.
+func isStatus(err error, statusCode int) bool {
+	var e *http.ErrUnexpectedStatus
+	return errors.As(err, &e) && e.StatusCode == statusCode
+}
@@ -0,0 +1,92 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/textproto"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/sftp"
+)
+
+// IsRetryable returns true if the error is likely transient, and the failed operation could succeed if attempted again.
+// The following errors are retryable:
+//  - connection resets, broken pipes and timeouts, and unexpected end of streams
+//  - temporary DNS errors
+//  - FTP replies with a transient negative completion code (4xx)
+//  - AWS errors that the AWS SDK considers retryable or throttling
+//  - lost SFTP connections
+//  - any error that implements "Temporary() bool" and returns true, such as HTTP 429 and 5xx status errors.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	for _, errno := range []syscall.Errno{
+		syscall.ECONNABORTED,
+		syscall.ECONNRESET,
+		syscall.EPIPE,
+		syscall.ETIMEDOUT,
+	} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+
+	if errors.Is(err, sftp.ErrSSHFxConnectionLost) || errors.Is(err, sftp.ErrSSHFxNoConnection) {
+		return true
+	}
+
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		return dnsError.IsTemporary || dnsError.IsTimeout
+	}
+
+	var opError *net.OpError
+	if errors.As(err, &opError) {
+		// other network errors, such as a refused connection, an unreachable host or a closed connection,
+		// are unlikely to succeed on the next attempt.
+		return opError.Timeout()
+	}
+
+	var textprotoError *textproto.Error
+	if errors.As(err, &textprotoError) {
+		return textprotoError.Code >= 400 && textprotoError.Code < 500
+	}
+
+	var awsError awserr.Error
+	if errors.As(err, &awsError) {
+		return request.IsErrorRetryable(awsError) || request.IsErrorThrottle(awsError)
+	}
+
+	var netError net.Error
+	if errors.As(err, &netError) && netError.Timeout() {
+		return true
+	}
+
+	var temporaryError interface{ Temporary() bool }
+	if errors.As(err, &temporaryError) {
+		return temporaryError.Temporary()
+	}
+
+	return false
+}
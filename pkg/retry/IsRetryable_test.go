@@ -0,0 +1,65 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+)
+
+type temporaryError bool
+
+func (e temporaryError) Error() string   { return "temporary error" }
+func (e temporaryError) Temporary() bool { return bool(e) }
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		err       error
+		retryable bool
+	}{
+		{err: nil, retryable: false},
+		{err: errors.New("permanent"), retryable: false},
+		{err: io.EOF, retryable: false},
+		{err: context.Canceled, retryable: false},
+		{err: io.ErrUnexpectedEOF, retryable: true},
+		{err: fmt.Errorf("error reading: %w", io.ErrUnexpectedEOF), retryable: true},
+		{err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, retryable: true},
+		{err: &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)}, retryable: true},
+		{err: &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, retryable: true},
+		{err: &net.OpError{Op: "read", Net: "tcp", Err: net.ErrClosed}, retryable: false},
+		{err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.EMFILE)}, retryable: false},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, retryable: false},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.EHOSTUNREACH)}, retryable: false},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)}, retryable: false},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}, retryable: true},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}}, retryable: false},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "timeout", Name: "example.com", IsTimeout: true}}, retryable: true},
+		{err: &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}, retryable: false},
+		{err: &net.DNSError{Err: "timeout", Name: "example.com", IsTimeout: true}, retryable: true},
+		{err: &textproto.Error{Code: 421, Msg: "too many connections"}, retryable: true},
+		{err: &textproto.Error{Code: 550, Msg: "file not found"}, retryable: false},
+		{err: awserr.New("RequestError", "send request failed", nil), retryable: true},
+		{err: awserr.New("Throttling", "rate exceeded", nil), retryable: true},
+		{err: awserr.New("NoSuchKey", "no such key", nil), retryable: false},
+		{err: temporaryError(true), retryable: true},
+		{err: temporaryError(false), retryable: false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.retryable, IsRetryable(testCase.err), "unexpected result for error %v", testCase.err)
+	}
+}
@@ -0,0 +1,120 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Policy describes how many times and how often a failed operation is attempted.
+// A nil Policy attempts an operation only once.
+type Policy struct {
+	Attempts  int                  // maximum number of attempts, including the first attempt
+	BaseDelay time.Duration        // delay before the first retry, doubled for every following retry
+	MaxDelay  time.Duration        // maximum delay between attempts
+	Jitter    float64              // fraction of the delay that is randomized, between 0 and 1
+	Retryable func(err error) bool // classifies errors as retryable, defaults to IsRetryable
+}
+
+// NewDefaultPolicy returns a new Policy using the default attempts, delays, and jitter.
+func NewDefaultPolicy() *Policy {
+	return &Policy{
+		Attempts:  DefaultAttempts,
+		BaseDelay: DefaultBaseDelay,
+		MaxDelay:  DefaultMaxDelay,
+		Jitter:    DefaultJitter,
+	}
+}
+
+// Retry returns true if the operation that failed with the given error should be attempted again.
+// attempt is the number of attempts that have already been made.
+func (p *Policy) Retry(err error, attempt int) bool {
+	if p == nil || err == nil || attempt >= p.Attempts {
+		return false
+	}
+	return p.IsRetryable(err)
+}
+
+// IsRetryable returns true if the error is classified as retryable by the policy, regardless of the number of attempts.
+// The error is classified by the Retryable function of the policy, or by IsRetryable if the policy is nil or has no function.
+func (p *Policy) IsRetryable(err error) bool {
+	if p != nil && p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// Delay returns the duration to wait after the given number of failed attempts.
+// The delay grows exponentially from the base delay up to the maximum delay,
+// and then a random fraction of the delay, as set by the jitter, is subtracted.
+func (p *Policy) Delay(attempt int) time.Duration {
+	if p == nil || attempt < 1 {
+		return 0
+	}
+	d := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		d -= time.Duration(jitter * rand.Float64() * float64(d))
+	}
+	return d
+}
+
+// Do calls the function fn until it succeeds, returns an error that is not retryable,
+// or the maximum number of attempts is reached.
+// Do returns the error from the last attempt, if any.
+// If the context is done while waiting to retry, then Do stops and returns an error that wraps the error of the context.
+func (p *Policy) Do(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if !p.Retry(err, attempt) {
+			if attempt > 1 {
+				return fmt.Errorf("error after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
+		if errWait := wait(ctx, p.Delay(attempt)); errWait != nil {
+			return fmt.Errorf("error waiting to retry after %d attempts: %v: %w", attempt, err, errWait)
+		}
+	}
+}
+
+// wait waits for the duration, unless the context is done first, in which case wait returns the error of the context.
+func wait(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyDelay(t *testing.T) {
+	p := &Policy{Attempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	assert.Equal(t, time.Duration(0), p.Delay(0))
+	assert.Equal(t, time.Second, p.Delay(1))
+	assert.Equal(t, 2*time.Second, p.Delay(2))
+	assert.Equal(t, 4*time.Second, p.Delay(3))
+	assert.Equal(t, 5*time.Second, p.Delay(4))
+	assert.Equal(t, 5*time.Second, p.Delay(100))
+}
+
+func TestPolicyDelayJitter(t *testing.T) {
+	p := &Policy{Attempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		d := p.Delay(2)
+		assert.True(t, d > time.Second && d <= 2*time.Second, "delay %s out of range", d)
+	}
+}
+
+func TestPolicyRetry(t *testing.T) {
+	p := &Policy{Attempts: 3}
+	assert.True(t, p.Retry(io.ErrUnexpectedEOF, 1))
+	assert.True(t, p.Retry(io.ErrUnexpectedEOF, 2))
+	assert.False(t, p.Retry(io.ErrUnexpectedEOF, 3))
+	assert.False(t, p.Retry(errors.New("permanent"), 1))
+	assert.False(t, p.Retry(nil, 1))
+}
+
+func TestPolicyRetryNil(t *testing.T) {
+	var p *Policy
+	assert.False(t, p.Retry(io.ErrUnexpectedEOF, 1))
+	assert.Equal(t, time.Duration(0), p.Delay(1))
+}
+
+func TestPolicyRetryable(t *testing.T) {
+	errCustom := errors.New("custom")
+	p := &Policy{Attempts: 3, Retryable: func(err error) bool { return errors.Is(err, errCustom) }}
+	assert.True(t, p.Retry(errCustom, 1))
+	assert.False(t, p.Retry(io.ErrUnexpectedEOF, 1))
+	assert.True(t, p.IsRetryable(errCustom))
+	assert.False(t, p.IsRetryable(io.ErrUnexpectedEOF))
+
+	// a nil policy classifies errors with IsRetryable
+	var nilPolicy *Policy
+	assert.True(t, nilPolicy.IsRetryable(io.ErrUnexpectedEOF))
+	assert.False(t, nilPolicy.IsRetryable(errCustom))
+}
+
+func TestPolicyDo(t *testing.T) {
+	p := &Policy{Attempts: 4, BaseDelay: time.Millisecond}
+	calls := 0
+	err := p.Do(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return io.ErrUnexpectedEOF
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestPolicyDoExhausted(t *testing.T) {
+	p := &Policy{Attempts: 3, BaseDelay: time.Millisecond}
+	calls := 0
+	err := p.Do(context.Background(), func() error {
+		calls++
+		return io.ErrUnexpectedEOF
+	})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, 3, calls)
+}
+
+func TestPolicyDoNotRetryable(t *testing.T) {
+	p := &Policy{Attempts: 3, BaseDelay: time.Millisecond}
+	errPermanent := errors.New("permanent")
+	calls := 0
+	err := p.Do(context.Background(), func() error {
+		calls++
+		return errPermanent
+	})
+	assert.Equal(t, errPermanent, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestPolicyDoCanceled(t *testing.T) {
+	p := &Policy{Attempts: 4, BaseDelay: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := p.Do(ctx, func() error {
+		calls++
+		cancel()
+		return io.ErrUnexpectedEOF
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
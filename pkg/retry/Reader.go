@@ -0,0 +1,105 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// OpenFunc opens a stream of bytes starting at the given byte offset.
+type OpenFunc func(offset int64) (io.ReadCloser, error)
+
+// Reader implements the io.ReadCloser interface by wrapping a stream that is reopened at
+// the last byte offset read when a read fails with a retryable error.
+// The number of retries and the delay between attempts is set by the policy.
+// The attempts are counted from the last successful read.
+// If the context is done while waiting to reopen the stream, then the read fails with an error that wraps the error of the context.
+type Reader struct {
+	ctx    context.Context
+	reader io.ReadCloser
+	open   OpenFunc
+	policy *Policy
+	offset int64
+	err    error // error that is returned by the next read
+}
+
+// NewReader returns a new Reader for the stream r, which was opened at the given byte offset.
+// If r is nil, then the stream is opened on the first read.
+func NewReader(ctx context.Context, r io.ReadCloser, offset int64, open OpenFunc, policy *Policy) *Reader {
+	return &Reader{ctx: ctx, reader: r, open: open, policy: policy, offset: offset}
+}
+
+// Offset returns the current byte offset of the stream.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+func (r *Reader) reopen() error {
+	if r.reader != nil {
+		_ = r.reader.Close() // the stream has already failed, so ignore errors when closing
+		r.reader = nil
+	}
+	rc, err := r.open(r.offset)
+	if err != nil {
+		return fmt.Errorf("error reopening stream at offset %d: %w", r.offset, err)
+	}
+	r.reader = rc
+	return nil
+}
+
+// Read implements the io.Reader interface.
+// If the underlying stream fails with a retryable error, then the stream is reopened
+// at the current offset and the read is attempted again.
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	var err error
+	if r.reader == nil {
+		err = r.reopen()
+	}
+	for attempt := 1; ; attempt++ {
+		if err == nil {
+			var n int
+			n, err = r.reader.Read(p)
+			r.offset += int64(n)
+			if err == nil || err == io.EOF {
+				return n, err
+			}
+			if n > 0 {
+				// return the bytes read now, and either reopen the stream or return the error on the next read
+				if r.policy.Retry(err, attempt) {
+					_ = r.reader.Close()
+					r.reader = nil
+				} else {
+					r.err = err
+				}
+				return n, nil
+			}
+		}
+		if !r.policy.Retry(err, attempt) {
+			return 0, err
+		}
+		if errWait := wait(r.ctx, r.policy.Delay(attempt)); errWait != nil {
+			return 0, fmt.Errorf("error waiting to reopen stream at offset %d: %v: %w", r.offset, err, errWait)
+		}
+		err = r.reopen()
+	}
+}
+
+// Close closes the underlying stream.
+func (r *Reader) Close() error {
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
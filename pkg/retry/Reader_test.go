@@ -0,0 +1,105 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package retry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyReader returns an error after reading a limited number of bytes.
+type flakyReader struct {
+	reader io.Reader
+	limit  int
+	err    error
+}
+
+func (r *flakyReader) Read(p []byte) (int, error) {
+	if r.limit <= 0 {
+		return 0, r.err
+	}
+	if len(p) > r.limit {
+		p = p[:r.limit]
+	}
+	n, err := r.reader.Read(p)
+	r.limit -= n
+	return n, err
+}
+
+func (r *flakyReader) Close() error {
+	return nil
+}
+
+func TestReaderResume(t *testing.T) {
+	data := []byte("hello world, this is a stream of bytes that fails every 5 bytes")
+	offsets := []int64{}
+	open := func(offset int64) (io.ReadCloser, error) {
+		offsets = append(offsets, offset)
+		return &flakyReader{reader: bytes.NewReader(data[offset:]), limit: 5, err: io.ErrUnexpectedEOF}, nil
+	}
+	first, err := open(0)
+	require.NoError(t, err)
+	r := NewReader(context.Background(), first, 0, open, &Policy{Attempts: 2, BaseDelay: time.Millisecond})
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+	assert.Equal(t, int64(len(data)), r.Offset())
+	for i, offset := range offsets {
+		assert.Equal(t, int64(i*5), offset)
+	}
+	assert.NoError(t, r.Close())
+}
+
+func TestReaderNotRetryable(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	opens := 0
+	open := func(offset int64) (io.ReadCloser, error) {
+		opens++
+		return &flakyReader{reader: bytes.NewReader([]byte("hello world")), limit: 5, err: errPermanent}, nil
+	}
+	r := NewReader(context.Background(), nil, 0, open, &Policy{Attempts: 3, BaseDelay: time.Millisecond})
+	got, err := io.ReadAll(r)
+	assert.Equal(t, errPermanent, err)
+	assert.Equal(t, []byte("hello"), got)
+	assert.Equal(t, 1, opens)
+}
+
+func TestReaderExhausted(t *testing.T) {
+	opens := 0
+	open := func(offset int64) (io.ReadCloser, error) {
+		opens++
+		return nil, io.ErrUnexpectedEOF
+	}
+	r := NewReader(context.Background(), &flakyReader{reader: bytes.NewReader([]byte("hello world")), limit: 0, err: io.ErrUnexpectedEOF}, 0, open, &Policy{Attempts: 3, BaseDelay: time.Millisecond})
+	_, err := io.ReadAll(r)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, 2, opens)
+	assert.NoError(t, r.Close())
+}
+
+func TestReaderCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	opens := 0
+	open := func(offset int64) (io.ReadCloser, error) {
+		opens++
+		return nil, io.ErrUnexpectedEOF
+	}
+	r := NewReader(ctx, &flakyReader{reader: bytes.NewReader([]byte("hello world")), limit: 0, err: io.ErrUnexpectedEOF}, 0, open, &Policy{Attempts: 3, BaseDelay: time.Hour})
+	cancel()
+	_, err := io.ReadAll(r)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, opens)
+	assert.NoError(t, r.Close())
+}
@@ -0,0 +1,11 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+// Package retry provides a policy for retrying failed operations with exponential backoff,
+// and a reader that resumes a stream from the last byte offset when a read fails.
+// The policy is shared across all the schemes supported by the grw package.
+package retry
@@ -0,0 +1,19 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package retry
+
+import (
+	"time"
+)
+
+const (
+	DefaultAttempts  = 4
+	DefaultBaseDelay = 500 * time.Millisecond
+	DefaultMaxDelay  = 30 * time.Second
+	DefaultJitter    = 0.2
+)
@@ -8,21 +8,33 @@
 package schemes
 
 const (
-	SchemeFile  = "file"
-	SchemeFTP   = "ftp"
-	SchemeHTTP  = "http"
-	SchemeHTTPS = "https"
-	SchemeS3    = "s3"
-	SchemeSFTP  = "sftp"
+	SchemeAzureBlob  = "azblob"
+	SchemeFile       = "file"
+	SchemeFTP        = "ftp"
+	SchemeGCS        = "gs"
+	SchemeHTTP       = "http"
+	SchemeHTTPS      = "https"
+	SchemeS3         = "s3"
+	SchemeSFTP       = "sftp"
+	SchemeSSH        = "ssh"
+	SchemeSSHCommand = "ssh+cmd"
+	SchemeWebDAV     = "webdav"
+	SchemeWebDAVS    = "webdavs"
 )
 
 var (
 	Schemes = []string{
+		SchemeAzureBlob,
 		SchemeFile,
 		SchemeFTP,
+		SchemeGCS,
 		SchemeHTTP,
 		SchemeHTTPS,
 		SchemeS3,
 		SchemeSFTP,
+		SchemeSSH,
+		SchemeSSHCommand,
+		SchemeWebDAV,
+		SchemeWebDAVS,
 	}
 )
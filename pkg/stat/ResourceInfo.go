@@ -0,0 +1,179 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package stat
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// NewResourceInfoInput contains the input parameters for NewResourceInfo.
+type NewResourceInfoInput struct {
+	URI         string      // uri of the resource
+	Name        string      // base name of the resource
+	Size        int64       // length in bytes
+	ModTime     time.Time   // last modified time, if known
+	Mode        os.FileMode // file mode, if known
+	ContentType string      // content type, if known
+	ETag        string      // entity tag, if known
+	Dir         bool        // the resource is a directory
+	Prefix      bool        // the resource is a prefix shared by objects in object storage rather than an object
+}
+
+// ResourceInfo describes a resource located by a uri,
+// such as a local file, a file on a remote server, or an object in object storage.
+// ResourceInfo implements the Info and os.FileInfo interfaces.
+// Fields that a scheme does not provide, such as the mode of an object in object storage, are left empty.
+type ResourceInfo struct {
+	uri         string
+	name        string
+	size        int64
+	modTime     time.Time
+	mode        os.FileMode
+	contentType string
+	etag        string
+	prefix      bool
+}
+
+// NewResourceInfo returns a new ResourceInfo.
+// Directories and prefixes always have the os.ModeDir bit set in their mode.
+func NewResourceInfo(input *NewResourceInfoInput) *ResourceInfo {
+	mode := input.Mode
+	if input.Dir || input.Prefix {
+		mode |= os.ModeDir
+	}
+	return &ResourceInfo{
+		uri:         input.URI,
+		name:        input.Name,
+		size:        input.Size,
+		modTime:     input.ModTime,
+		mode:        mode,
+		contentType: input.ContentType,
+		etag:        input.ETag,
+		prefix:      input.Prefix,
+	}
+}
+
+// NewResourceInfoFromFileInfo returns a new ResourceInfo for the resource at the uri described by the os.FileInfo.
+func NewResourceInfoFromFileInfo(uri string, fi os.FileInfo) *ResourceInfo {
+	return NewResourceInfo(&NewResourceInfoInput{
+		URI:     uri,
+		Name:    fi.Name(),
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+		Mode:    fi.Mode(),
+	})
+}
+
+// URI returns the uri of the resource.
+func (r *ResourceInfo) URI() string {
+	return r.uri
+}
+
+// Name returns the base name of the resource.
+func (r *ResourceInfo) Name() string {
+	return r.name
+}
+
+// Size returns the length in bytes of the resource.
+func (r *ResourceInfo) Size() int64 {
+	return r.size
+}
+
+// ModTime returns the last modified time of the resource, or the zero time if unknown.
+func (r *ResourceInfo) ModTime() time.Time {
+	return r.modTime
+}
+
+// Mode returns the file mode of the resource.
+func (r *ResourceInfo) Mode() os.FileMode {
+	return r.mode
+}
+
+// Perm returns the file mode permissions bits.
+func (r *ResourceInfo) Perm() os.FileMode {
+	return r.mode.Perm()
+}
+
+// IsDir returns true if the resource is a directory or a prefix.
+func (r *ResourceInfo) IsDir() bool {
+	return r.mode.IsDir()
+}
+
+// IsPrefix returns true if the resource is a prefix shared by objects in object storage.
+func (r *ResourceInfo) IsPrefix() bool {
+	return r.prefix
+}
+
+// IsRegular returns true if the resource is a regular file or an object.
+func (r *ResourceInfo) IsRegular() bool {
+	return r.mode.IsRegular()
+}
+
+// IsDevice returns true if the resource is a device.
+func (r *ResourceInfo) IsDevice() bool {
+	return r.mode&os.ModeDevice != 0
+}
+
+// IsCharacterDevice returns true if the resource is a character device.
+func (r *ResourceInfo) IsCharacterDevice() bool {
+	return r.mode&os.ModeCharDevice != 0
+}
+
+// IsNamedPipe returns true if the resource is a named pipe.
+func (r *ResourceInfo) IsNamedPipe() bool {
+	return r.mode&os.ModeNamedPipe != 0
+}
+
+// ContentType returns the content type of the resource, if any.
+func (r *ResourceInfo) ContentType() string {
+	return r.contentType
+}
+
+// ETag returns the entity tag of the resource, if any.
+func (r *ResourceInfo) ETag() string {
+	return r.etag
+}
+
+// Sys returns nil.
+func (r *ResourceInfo) Sys() interface{} {
+	return nil
+}
+
+// MarshalJSON returns the resource info as a JSON object.
+// The last modified time, mode, content type, and entity tag are omitted if unknown.
+func (r *ResourceInfo) MarshalJSON() ([]byte, error) {
+	obj := struct {
+		URI         string     `json:"uri"`
+		Name        string     `json:"name"`
+		Size        int64      `json:"size"`
+		ModTime     *time.Time `json:"modTime,omitempty"`
+		Mode        string     `json:"mode,omitempty"`
+		ContentType string     `json:"contentType,omitempty"`
+		ETag        string     `json:"etag,omitempty"`
+		Dir         bool       `json:"dir"`
+		Prefix      bool       `json:"prefix"`
+	}{
+		URI:         r.uri,
+		Name:        r.name,
+		Size:        r.size,
+		ContentType: r.contentType,
+		ETag:        r.etag,
+		Dir:         r.IsDir(),
+		Prefix:      r.prefix,
+	}
+	if !r.modTime.IsZero() {
+		modTime := r.modTime.UTC()
+		obj.ModTime = &modTime
+	}
+	if r.mode.Perm() != 0 {
+		obj.Mode = r.mode.String()
+	}
+	return json.Marshal(obj)
+}
@@ -0,0 +1,72 @@
+// =================================================================
+//
+// Copyright (C) 2026 Spatial Current, Inc. - All Rights Reserved
+// Released as open source under the MIT License.  See LICENSE file.
+//
+// =================================================================
+
+package stat
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceInfo(t *testing.T) {
+	var info Info = NewResourceInfo(&NewResourceInfoInput{
+		URI:  "file:///tmp/a.txt",
+		Name: "a.txt",
+		Size: 11,
+		Mode: 0644,
+	})
+	assert.True(t, info.IsRegular())
+	assert.Equal(t, os.FileMode(0644), info.Perm())
+	assert.Equal(t, int64(11), info.Size())
+
+	var fi os.FileInfo = NewResourceInfo(&NewResourceInfoInput{
+		URI:    "s3://bucket/a/",
+		Name:   "a",
+		Prefix: true,
+	})
+	assert.True(t, fi.IsDir())
+	assert.False(t, fi.Mode().IsRegular())
+}
+
+func TestResourceInfoMarshalJSON(t *testing.T) {
+	info := NewResourceInfo(&NewResourceInfoInput{
+		URI:         "sftp://example.com/a.txt",
+		Name:        "a.txt",
+		Size:        11,
+		ModTime:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Mode:        0644,
+		ContentType: "text/plain",
+		ETag:        `"abc"`,
+	})
+	b, err := json.Marshal(info)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"uri": "sftp://example.com/a.txt",
+		"name": "a.txt",
+		"size": 11,
+		"modTime": "2026-01-02T03:04:05Z",
+		"mode": "-rw-r--r--",
+		"contentType": "text/plain",
+		"etag": "\"abc\"",
+		"dir": false,
+		"prefix": false
+	}`, string(b))
+
+	prefix := NewResourceInfo(&NewResourceInfoInput{
+		URI:    "s3://bucket/a/",
+		Name:   "a",
+		Prefix: true,
+	})
+	b, err = json.Marshal(prefix)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"uri": "s3://bucket/a/", "name": "a", "size": 0, "dir": true, "prefix": true}`, string(b))
+}